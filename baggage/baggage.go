@@ -186,6 +186,7 @@ func fromInternalProperties(iProps []baggage.Property) properties {
 			key:      p.Key,
 			value:    p.Value,
 			hasValue: p.HasValue,
+			hasData:  true,
 		}
 	}
 	return props
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/middleware-labs/otel/internal/baggage"
 )
@@ -667,8 +668,8 @@ func TestBaggageMembers(t *testing.T) {
 			key:   "foo",
 			value: "1",
 			properties: properties{
-				{key: "state", value: "on", hasValue: true},
-				{key: "red"},
+				{key: "state", value: "on", hasValue: true, hasData: true},
+				{key: "red", hasData: true},
 			},
 			hasData: true,
 		},
@@ -676,7 +677,7 @@ func TestBaggageMembers(t *testing.T) {
 			key:   "bar",
 			value: "2",
 			properties: properties{
-				{key: "yellow"},
+				{key: "yellow", hasData: true},
 			},
 			hasData: true,
 		},
@@ -705,6 +706,20 @@ func TestBaggageMember(t *testing.T) {
 	assert.Equal(t, Member{}, bag.Member("bar"))
 }
 
+func TestBaggageMemberPropertiesReuse(t *testing.T) {
+	p, err := NewKeyValueProperty("p", "v")
+	require.NoError(t, err)
+	m, err := NewMember("k", "v", p)
+	require.NoError(t, err)
+	b, err := New(m)
+	require.NoError(t, err)
+
+	// Properties of a Member returned from Baggage are valid.
+	m2, err := NewMember("k2", "v", b.Member("k").Properties()...)
+	require.NoError(t, err)
+	assert.Equal(t, []Property{p}, m2.Properties())
+}
+
 func TestMemberKey(t *testing.T) {
 	m := Member{}
 	assert.Equal(t, "", m.Key(), "even invalid values should be returned")
@@ -20,6 +20,7 @@ import (
 	"github.com/middleware-labs/otel/bridge/opencensus/internal"
 	"github.com/middleware-labs/otel/bridge/opencensus/internal/oc2otel"
 	"github.com/middleware-labs/otel/bridge/opencensus/internal/otel2oc"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
 	"github.com/middleware-labs/otel/trace"
 )
 
@@ -30,6 +31,29 @@ func NewTracer(tracer trace.Tracer) octrace.Tracer {
 	return internal.NewTracer(tracer)
 }
 
+// NewSpanExporter returns an OpenTelemetry SpanExporter which exports spans
+// with an OpenCensus Exporter. Using this SpanExporter allows libraries and
+// applications to be instrumented with OpenTelemetry before the OpenCensus
+// exporter is replaced.
+//
+// Events named "message send" and "message receive", as recorded for the
+// OpenCensus message events by the Tracer returned from NewTracer, are
+// exported as message events. Other events are exported as annotations. The
+// Error status code is exported as the Unknown status code. The resource and
+// instrumentation scope of spans are not exported.
+func NewSpanExporter(exporter octrace.Exporter) sdktrace.SpanExporter {
+	return internal.NewSpanExporter(exporter)
+}
+
+// NewReverseTracerProvider returns an OpenTelemetry TracerProvider which
+// synchronously exports the spans it creates with an OpenCensus Exporter. See
+// NewSpanExporter for more information. The TracerProvider is configured
+// with opts.
+func NewReverseTracerProvider(exporter octrace.Exporter, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	opts = append([]sdktrace.TracerProviderOption{sdktrace.WithSyncer(NewSpanExporter(exporter))}, opts...)
+	return sdktrace.NewTracerProvider(opts...)
+}
+
 // OTelSpanContextToOC converts from an OpenTelemetry SpanContext to an
 // OpenCensus SpanContext, and handles any incompatibilities with the global
 // error handler.
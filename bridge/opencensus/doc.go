@@ -21,10 +21,11 @@
 //
 // There are known limitations to this bridge:
 //
-// - The AddLink method for OpenCensus Spans is not compatible with the
-// OpenTelemetry Span. No link can be added to an OpenTelemetry Span once it
-// is started. Any calls to this method for the OpenCensus Span will result
-// in an error being sent to the OpenTelemetry default ErrorHandler.
+// - The AddLink method for OpenCensus Spans is only supported if the
+// OpenTelemetry Span allows links to be added once it is started, like the
+// spans of the OpenTelemetry SDK. Otherwise, any calls to this method for the
+// OpenCensus Span will result in an error being sent to the OpenTelemetry
+// default ErrorHandler.
 //
 // - The NewContext method of the OpenCensus Tracer cannot embed an OpenCensus
 // Span in a context unless that Span was created by that Tracer.
@@ -32,4 +33,10 @@
 // - Conversion of custom OpenCensus Samplers to OpenTelemetry is not
 // implemented. An error will be sent to the OpenTelemetry default
 // ErrorHandler if this is attempted.
+//
+// The bridge can also be used in the reverse direction, to instrument with
+// OpenTelemetry while spans are still exported by an OpenCensus exporter.
+// The NewSpanExporter function creates an OpenTelemetry SpanExporter from an
+// OpenCensus Exporter, and NewReverseTracerProvider an OpenTelemetry
+// TracerProvider exporting its spans with it.
 package opencensus // import "github.com/middleware-labs/otel/bridge/opencensus"
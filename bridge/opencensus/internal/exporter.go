@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/bridge/opencensus/internal"
+
+import (
+	"context"
+	"sync"
+
+	octrace "go.opencensus.io/trace"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/bridge/opencensus/internal/oc2otel"
+	"github.com/middleware-labs/otel/bridge/opencensus/internal/otel2oc"
+	"github.com/middleware-labs/otel/codes"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/trace"
+)
+
+// SpanExporter exports OpenTelemetry spans to an OpenCensus exporter.
+type SpanExporter struct {
+	exporter octrace.Exporter
+
+	stoppedMu sync.RWMutex
+	stopped   bool
+}
+
+var _ sdktrace.SpanExporter = (*SpanExporter)(nil)
+
+// NewSpanExporter returns a SpanExporter exporting spans to exporter.
+func NewSpanExporter(exporter octrace.Exporter) *SpanExporter {
+	return &SpanExporter{exporter: exporter}
+}
+
+// ExportSpans converts spans to OpenCensus SpanData and exports them.
+func (e *SpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.stoppedMu.RLock()
+	stopped := e.stopped
+	e.stoppedMu.RUnlock()
+	if stopped {
+		return nil
+	}
+
+	for _, s := range spans {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e.exporter.ExportSpan(SpanData(s))
+	}
+	return nil
+}
+
+// Shutdown stops the exporter. The OpenCensus exporter is flushed if it has
+// a Flush method.
+func (e *SpanExporter) Shutdown(ctx context.Context) error {
+	e.stoppedMu.Lock()
+	e.stopped = true
+	e.stoppedMu.Unlock()
+
+	if f, ok := e.exporter.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+	return ctx.Err()
+}
+
+// SpanData converts an OpenTelemetry span to OpenCensus SpanData.
+//
+// Events with the MessageSendEvent and MessageReceiveEvent names are
+// converted to message events, other events to annotations. The link type of
+// links is read from the oc2otel.LinkTypeKey attribute. The Error status code
+// is converted to the Unknown status code, other status codes to the OK
+// status code.
+func SpanData(s sdktrace.ReadOnlySpan) *octrace.SpanData {
+	sd := &octrace.SpanData{
+		SpanContext:            otel2oc.SpanContext(s.SpanContext()),
+		ParentSpanID:           octrace.SpanID(s.Parent().SpanID()),
+		SpanKind:               spanKind(s.SpanKind()),
+		Name:                   s.Name(),
+		StartTime:              s.StartTime(),
+		EndTime:                s.EndTime(),
+		Attributes:             attributes(s.Attributes()),
+		HasRemoteParent:        s.Parent().IsRemote(),
+		DroppedAttributeCount:  s.DroppedAttributes(),
+		DroppedAnnotationCount: s.DroppedEvents(),
+		DroppedLinkCount:       s.DroppedLinks(),
+		ChildSpanCount:         s.ChildSpanCount(),
+	}
+	if st := s.Status(); st.Code == codes.Error {
+		sd.Status = octrace.Status{Code: octrace.StatusCodeUnknown, Message: st.Description}
+	}
+
+	for _, e := range s.Events() {
+		if me, ok := messageEvent(e); ok {
+			sd.MessageEvents = append(sd.MessageEvents, me)
+			continue
+		}
+		sd.Annotations = append(sd.Annotations, octrace.Annotation{
+			Time:       e.Time,
+			Message:    e.Name,
+			Attributes: attributes(e.Attributes),
+		})
+	}
+
+	for _, l := range s.Links() {
+		link := octrace.Link{
+			TraceID: octrace.TraceID(l.SpanContext.TraceID()),
+			SpanID:  octrace.SpanID(l.SpanContext.SpanID()),
+		}
+		attrs := make([]attribute.KeyValue, 0, len(l.Attributes))
+		for _, kv := range l.Attributes {
+			if kv.Key == oc2otel.LinkTypeKey {
+				switch kv.Value.AsString() {
+				case "child":
+					link.Type = octrace.LinkTypeChild
+				case "parent":
+					link.Type = octrace.LinkTypeParent
+				}
+				continue
+			}
+			attrs = append(attrs, kv)
+		}
+		link.Attributes = attributes(attrs)
+		sd.Links = append(sd.Links, link)
+	}
+	return sd
+}
+
+func spanKind(kind trace.SpanKind) int {
+	switch kind {
+	case trace.SpanKindServer:
+		return octrace.SpanKindServer
+	case trace.SpanKindClient:
+		return octrace.SpanKindClient
+	}
+	return octrace.SpanKindUnspecified
+}
+
+// attributes converts attrs to OpenCensus attribute values. Slice values are
+// converted to strings. Nil is returned if attrs is empty.
+func attributes(attrs []attribute.KeyValue) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(attrs))
+	for _, kv := range attrs {
+		switch kv.Value.Type() {
+		case attribute.BOOL, attribute.INT64, attribute.FLOAT64, attribute.STRING:
+			m[string(kv.Key)] = kv.Value.AsInterface()
+		default:
+			m[string(kv.Key)] = kv.Value.Emit()
+		}
+	}
+	return m
+}
+
+// messageEvent returns the OpenCensus message event recorded as e by the
+// Span bridge, if any.
+func messageEvent(e sdktrace.Event) (octrace.MessageEvent, bool) {
+	me := octrace.MessageEvent{Time: e.Time}
+	switch e.Name {
+	case MessageSendEvent:
+		me.EventType = octrace.MessageEventTypeSent
+	case MessageReceiveEvent:
+		me.EventType = octrace.MessageEventTypeRecv
+	default:
+		return me, false
+	}
+	for _, kv := range e.Attributes {
+		if kv.Value.Type() != attribute.INT64 {
+			return me, false
+		}
+		switch kv.Key {
+		case MessageIDKey:
+			me.MessageID = kv.Value.AsInt64()
+		case UncompressedKey:
+			me.UncompressedByteSize = kv.Value.AsInt64()
+		case CompressedKey:
+			me.CompressedByteSize = kv.Value.AsInt64()
+		default:
+			return me, false
+		}
+	}
+	return me, true
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	octrace "go.opencensus.io/trace"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/bridge/opencensus/internal"
+	"github.com/middleware-labs/otel/bridge/opencensus/internal/oc2otel"
+	"github.com/middleware-labs/otel/codes"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	"github.com/middleware-labs/otel/trace"
+)
+
+type recordingExporter struct {
+	spans   []*octrace.SpanData
+	flushed bool
+}
+
+func (e *recordingExporter) ExportSpan(s *octrace.SpanData) { e.spans = append(e.spans, s) }
+func (e *recordingExporter) Flush()                         { e.flushed = true }
+
+func TestSpanData(t *testing.T) {
+	start, end := time.Unix(1, 0), time.Unix(2, 0)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{3},
+		Remote:  true,
+	})
+	link := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{4},
+		SpanID:  trace.SpanID{5},
+	})
+
+	span := tracetest.SpanStub{
+		Name:        "span",
+		SpanContext: sc,
+		Parent:      parent,
+		SpanKind:    trace.SpanKindServer,
+		StartTime:   start,
+		EndTime:     end,
+		Attributes: []attribute.KeyValue{
+			attribute.Bool("bool", true),
+			attribute.Int64("int64", 1),
+			attribute.Float64("float64", 1.5),
+			attribute.String("string", "v"),
+			attribute.StringSlice("slice", []string{"a", "b"}),
+		},
+		Events: []sdktrace.Event{
+			{Name: "annotation", Time: start, Attributes: []attribute.KeyValue{attribute.String("k", "v")}},
+			{Name: internal.MessageSendEvent, Time: start, Attributes: []attribute.KeyValue{
+				internal.MessageIDKey.Int64(1),
+				internal.UncompressedKey.Int64(10),
+				internal.CompressedKey.Int64(5),
+			}},
+			{Name: internal.MessageReceiveEvent, Time: end, Attributes: []attribute.KeyValue{
+				internal.MessageIDKey.Int64(2),
+			}},
+			{Name: internal.MessageReceiveEvent, Time: end, Attributes: []attribute.KeyValue{
+				attribute.String("other", "v"),
+			}},
+		},
+		Links: []sdktrace.Link{{
+			SpanContext: link,
+			Attributes:  []attribute.KeyValue{oc2otel.LinkTypeKey.String("parent"), attribute.Int64("a", 1)},
+		}},
+		Status:            sdktrace.Status{Code: codes.Error, Description: "failure"},
+		DroppedAttributes: 1,
+		DroppedEvents:     2,
+		DroppedLinks:      3,
+		ChildSpanCount:    4,
+	}
+
+	want := &octrace.SpanData{
+		SpanContext: octrace.SpanContext{
+			TraceID:      octrace.TraceID{1},
+			SpanID:       octrace.SpanID{2},
+			TraceOptions: octrace.TraceOptions(1),
+		},
+		ParentSpanID: octrace.SpanID{3},
+		SpanKind:     octrace.SpanKindServer,
+		Name:         "span",
+		StartTime:    start,
+		EndTime:      end,
+		Attributes: map[string]interface{}{
+			"bool":    true,
+			"int64":   int64(1),
+			"float64": 1.5,
+			"string":  "v",
+			"slice":   "[a b]",
+		},
+		Annotations: []octrace.Annotation{
+			{Time: start, Message: "annotation", Attributes: map[string]interface{}{"k": "v"}},
+			{Time: end, Message: internal.MessageReceiveEvent, Attributes: map[string]interface{}{"other": "v"}},
+		},
+		MessageEvents: []octrace.MessageEvent{
+			{Time: start, EventType: octrace.MessageEventTypeSent, MessageID: 1, UncompressedByteSize: 10, CompressedByteSize: 5},
+			{Time: end, EventType: octrace.MessageEventTypeRecv, MessageID: 2},
+		},
+		Status: octrace.Status{Code: octrace.StatusCodeUnknown, Message: "failure"},
+		Links: []octrace.Link{{
+			TraceID:    octrace.TraceID{4},
+			SpanID:     octrace.SpanID{5},
+			Type:       octrace.LinkTypeParent,
+			Attributes: map[string]interface{}{"a": int64(1)},
+		}},
+		HasRemoteParent:        true,
+		DroppedAttributeCount:  1,
+		DroppedAnnotationCount: 2,
+		DroppedLinkCount:       3,
+		ChildSpanCount:         4,
+	}
+
+	got := internal.SpanData(span.Snapshot())
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SpanData() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSpanDataStatusAndKind(t *testing.T) {
+	for _, tc := range []struct {
+		kind     trace.SpanKind
+		status   codes.Code
+		wantKind int
+	}{
+		{kind: trace.SpanKindClient, status: codes.Ok, wantKind: octrace.SpanKindClient},
+		{kind: trace.SpanKindProducer, status: codes.Unset, wantKind: octrace.SpanKindUnspecified},
+		{kind: trace.SpanKindInternal, status: codes.Ok, wantKind: octrace.SpanKindUnspecified},
+	} {
+		span := tracetest.SpanStub{
+			SpanKind: tc.kind,
+			Status:   sdktrace.Status{Code: tc.status, Description: "ignored"},
+		}
+		got := internal.SpanData(span.Snapshot())
+		if got.SpanKind != tc.wantKind {
+			t.Errorf("SpanData() kind = %d for %v, want %d", got.SpanKind, tc.kind, tc.wantKind)
+		}
+		if got.Status != (octrace.Status{}) {
+			t.Errorf("SpanData() status = %v for %v, want OK", got.Status, tc.status)
+		}
+	}
+}
+
+func TestSpanExporter(t *testing.T) {
+	oc := &recordingExporter{}
+	exp := internal.NewSpanExporter(oc)
+	ctx := context.Background()
+
+	spans := tracetest.SpanStubs{{Name: "a"}, {Name: "b"}}.Snapshots()
+	if err := exp.ExportSpans(ctx, spans); err != nil {
+		t.Fatalf("ExportSpans() error = %v", err)
+	}
+	if len(oc.spans) != 2 || oc.spans[0].Name != "a" || oc.spans[1].Name != "b" {
+		t.Errorf("exported %+v, want spans a and b", oc.spans)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := exp.ExportSpans(canceled, spans); err != context.Canceled {
+		t.Errorf("ExportSpans() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+
+	if err := exp.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !oc.flushed {
+		t.Error("Shutdown() did not flush the OpenCensus exporter")
+	}
+	if err := exp.ExportSpans(ctx, spans); err != nil {
+		t.Fatalf("ExportSpans() after Shutdown error = %v", err)
+	}
+	if len(oc.spans) != 2 {
+		t.Errorf("ExportSpans() after Shutdown exported %d spans, want none", len(oc.spans)-2)
+	}
+}
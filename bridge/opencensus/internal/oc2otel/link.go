@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc2otel // import "github.com/middleware-labs/otel/bridge/opencensus/internal/oc2otel"
+
+import (
+	"sort"
+
+	octrace "go.opencensus.io/trace"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/trace"
+)
+
+// LinkTypeKey is used for the OpenCensus link type attribute.
+var LinkTypeKey = attribute.Key("link type")
+
+// Link converts an OpenCensus link to an OpenTelemetry link. The link type
+// is recorded with the LinkTypeKey attribute, if it is specified.
+func Link(l octrace.Link) trace.Link {
+	attrs := make([]attribute.KeyValue, 0, len(l.Attributes)+1)
+	switch l.Type {
+	case octrace.LinkTypeChild:
+		attrs = append(attrs, LinkTypeKey.String("child"))
+	case octrace.LinkTypeParent:
+		attrs = append(attrs, LinkTypeKey.String("parent"))
+	}
+	keys := make([]string, 0, len(l.Attributes))
+	for k := range l.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, attribute.KeyValue{
+			Key:   attribute.Key(k),
+			Value: AttributeValue(l.Attributes[k]),
+		})
+	}
+	return trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID(l.TraceID),
+			SpanID:  trace.SpanID(l.SpanID),
+		}),
+		Attributes: attrs,
+	}
+}
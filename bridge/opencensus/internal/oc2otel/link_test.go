@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc2otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	octrace "go.opencensus.io/trace"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/trace"
+)
+
+func TestLink(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID([16]byte{1}),
+		SpanID:  trace.SpanID([8]byte{2}),
+	})
+	for _, tc := range []struct {
+		desc     string
+		input    octrace.Link
+		expected trace.Link
+	}{
+		{
+			desc: "unspecified type",
+			input: octrace.Link{
+				TraceID: octrace.TraceID([16]byte{1}),
+				SpanID:  octrace.SpanID([8]byte{2}),
+			},
+			expected: trace.Link{SpanContext: sc, Attributes: []attribute.KeyValue{}},
+		},
+		{
+			desc: "child with attributes",
+			input: octrace.Link{
+				TraceID: octrace.TraceID([16]byte{1}),
+				SpanID:  octrace.SpanID([8]byte{2}),
+				Type:    octrace.LinkTypeChild,
+				Attributes: map[string]interface{}{
+					"int":  int64(1),
+					"bool": true,
+				},
+			},
+			expected: trace.Link{SpanContext: sc, Attributes: []attribute.KeyValue{
+				LinkTypeKey.String("child"),
+				attribute.Bool("bool", true),
+				attribute.Int64("int", 1),
+			}},
+		},
+		{
+			desc: "parent",
+			input: octrace.Link{
+				TraceID: octrace.TraceID([16]byte{1}),
+				SpanID:  octrace.SpanID([8]byte{2}),
+				Type:    octrace.LinkTypeParent,
+			},
+			expected: trace.Link{SpanContext: sc, Attributes: []attribute.KeyValue{
+				LinkTypeKey.String("parent"),
+			}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Link(tc.input))
+		})
+	}
+}
@@ -17,8 +17,11 @@ package internal // import "github.com/middleware-labs/otel/bridge/opencensus/in
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 
 	ocmetricdata "go.opencensus.io/metric/metricdata"
+	octrace "go.opencensus.io/trace"
 
 	"github.com/middleware-labs/otel/attribute"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
@@ -30,9 +33,13 @@ var (
 	errMismatchedValueTypes         = errors.New("wrong value type for data point")
 	errNumberDataPoint              = errors.New("converting a number data point")
 	errHistogramDataPoint           = errors.New("converting a histogram data point")
+	errSummaryDataPoint             = errors.New("converting a summary data point")
 	errNegativeDistributionCount    = errors.New("distribution count is negative")
 	errNegativeBucketCount          = errors.New("distribution bucket count is negative")
+	errNegativeSummaryCount         = errors.New("summary count is negative")
+	errInvalidPercentile            = errors.New("summary percentile is not in the range (0, 100]")
 	errMismatchedAttributeKeyValues = errors.New("mismatched number of attribute keys and values")
+	errInvalidSpanContext           = errors.New("exemplar span context attachment is not an OpenCensus SpanContext")
 )
 
 // ConvertMetrics converts metric data from OpenCensus to OpenTelemetry.
@@ -76,7 +83,10 @@ func convertAggregation(metric *ocmetricdata.Metric) (metricdata.Aggregation, er
 		return convertSum[float64](labelKeys, metric.TimeSeries)
 	case ocmetricdata.TypeCumulativeDistribution:
 		return convertHistogram(labelKeys, metric.TimeSeries)
-		// TODO: Support summaries, once it is in the OTel data types.
+	case ocmetricdata.TypeSummary:
+		return convertSummary(labelKeys, metric.TimeSeries)
+		// Gauge distributions are not supported: OpenTelemetry has no
+		// histogram data type with gauge semantics.
 	}
 	return nil, fmt.Errorf("%w: %q", errAggregationType, metric.Descriptor.Type)
 }
@@ -151,7 +161,11 @@ func convertHistogram(labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.Time
 				errInfo = append(errInfo, fmt.Sprintf("%v: %d", errNegativeDistributionCount, dist.Count))
 				continue
 			}
-			// TODO: handle exemplars
+			exemplars, err := convertExemplars(dist.Buckets)
+			if err != nil {
+				// The data point is still valid without its exemplars.
+				errInfo = append(errInfo, err.Error())
+			}
 			points = append(points, metricdata.HistogramDataPoint[float64]{
 				Attributes:   attrs,
 				StartTime:    t.StartTime,
@@ -160,6 +174,7 @@ func convertHistogram(labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.Time
 				Sum:          dist.Sum,
 				Bounds:       dist.BucketOptions.Bounds,
 				BucketCounts: bucketCounts,
+				Exemplars:    exemplars,
 			})
 		}
 	}
@@ -182,6 +197,145 @@ func convertBucketCounts(buckets []ocmetricdata.Bucket) ([]uint64, error) {
 	return bucketCounts, nil
 }
 
+// convertExemplars converts the exemplars of OpenCensus distribution buckets
+// to OpenTelemetry exemplars. Exemplars with an invalid span context
+// attachment are dropped and reported in the returned error.
+func convertExemplars(buckets []ocmetricdata.Bucket) ([]metricdata.Exemplar[float64], error) {
+	var exemplars []metricdata.Exemplar[float64]
+	var errInfo []string
+	for _, bucket := range buckets {
+		if bucket.Exemplar == nil {
+			continue
+		}
+		e, err := convertExemplar(bucket.Exemplar)
+		if err != nil {
+			errInfo = append(errInfo, err.Error())
+			continue
+		}
+		exemplars = append(exemplars, e)
+	}
+	if len(errInfo) > 0 {
+		return exemplars, fmt.Errorf("%v", errInfo)
+	}
+	return exemplars, nil
+}
+
+// convertExemplar converts an OpenCensus exemplar to an OpenTelemetry
+// exemplar. The span context attachment sets the trace and span IDs of the
+// exemplar, other attachments are converted to filtered attributes.
+func convertExemplar(ocExemplar *ocmetricdata.Exemplar) (metricdata.Exemplar[float64], error) {
+	exemplar := metricdata.Exemplar[float64]{
+		Value: ocExemplar.Value,
+		Time:  ocExemplar.Timestamp,
+	}
+	keys := make([]string, 0, len(ocExemplar.Attachments))
+	for k := range ocExemplar.Attachments {
+		keys = append(keys, k)
+	}
+	// Sort for a deterministic order of the filtered attributes.
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := ocExemplar.Attachments[k]
+		if k == ocmetricdata.AttachmentKeySpanContext {
+			sc, ok := v.(octrace.SpanContext)
+			if !ok {
+				return exemplar, fmt.Errorf("%w: %T", errInvalidSpanContext, v)
+			}
+			exemplar.TraceID = sc.TraceID[:]
+			exemplar.SpanID = sc.SpanID[:]
+			continue
+		}
+		exemplar.FilteredAttributes = append(exemplar.FilteredAttributes, convertAttachment(k, v))
+	}
+	return exemplar, nil
+}
+
+// convertAttachment converts an OpenCensus exemplar attachment to an
+// attribute. Values of an unsupported type are converted to a string.
+func convertAttachment(k string, v interface{}) attribute.KeyValue {
+	key := attribute.Key(k)
+	switch v := v.(type) {
+	case string:
+		return key.String(v)
+	case bool:
+		return key.Bool(v)
+	case int:
+		return key.Int(v)
+	case int64:
+		return key.Int64(v)
+	case float64:
+		return key.Float64(v)
+	case fmt.Stringer:
+		return key.String(v.String())
+	}
+	return key.String(fmt.Sprint(v))
+}
+
+// convertSummary converts OpenCensus Summary timeseries to an OpenTelemetry
+// Summary aggregation.
+func convertSummary(labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) (metricdata.Summary, error) {
+	points := make([]metricdata.SummaryDataPoint, 0, len(ts))
+	var errInfo []string
+	for _, t := range ts {
+		attrs, err := convertAttrs(labelKeys, t.LabelValues)
+		if err != nil {
+			errInfo = append(errInfo, err.Error())
+			continue
+		}
+		for _, p := range t.Points {
+			summary, ok := p.Value.(*ocmetricdata.Summary)
+			if !ok {
+				errInfo = append(errInfo, fmt.Sprintf("%v: %d", errMismatchedValueTypes, p.Value))
+				continue
+			}
+			if summary.Count < 0 {
+				errInfo = append(errInfo, fmt.Sprintf("%v: %d", errNegativeSummaryCount, summary.Count))
+				continue
+			}
+			quantiles, err := convertQuantiles(summary.Snapshot)
+			if err != nil {
+				errInfo = append(errInfo, err.Error())
+				continue
+			}
+			points = append(points, metricdata.SummaryDataPoint{
+				Attributes:     attrs,
+				StartTime:      t.StartTime,
+				Time:           p.Time,
+				Count:          uint64(summary.Count),
+				Sum:            summary.Sum,
+				QuantileValues: quantiles,
+			})
+		}
+	}
+	var aggregatedError error
+	if len(errInfo) > 0 {
+		aggregatedError = fmt.Errorf("%w: %v", errSummaryDataPoint, errInfo)
+	}
+	return metricdata.Summary{DataPoints: points}, aggregatedError
+}
+
+// convertQuantiles converts the percentiles of an OpenCensus summary snapshot
+// to OpenTelemetry quantile values, sorted by quantile.
+func convertQuantiles(snapshot ocmetricdata.Snapshot) ([]metricdata.QuantileValue, error) {
+	if len(snapshot.Percentiles) == 0 {
+		return nil, nil
+	}
+	quantiles := make([]metricdata.QuantileValue, 0, len(snapshot.Percentiles))
+	for percentile, value := range snapshot.Percentiles {
+		if math.IsNaN(percentile) || percentile <= 0 || percentile > 100 {
+			return nil, fmt.Errorf("%w: %v", errInvalidPercentile, percentile)
+		}
+		quantiles = append(quantiles, metricdata.QuantileValue{
+			Quantile: percentile / 100.0,
+			Value:    value,
+		})
+	}
+	sort.Slice(quantiles, func(i, j int) bool {
+		return quantiles[i].Quantile < quantiles[j].Quantile
+	})
+	return quantiles, nil
+}
+
 // convertAttrs converts from OpenCensus attribute keys and values to an
 // OpenTelemetry attribute Set.
 func convertAttrs(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) (attribute.Set, error) {
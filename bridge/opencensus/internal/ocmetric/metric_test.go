@@ -19,7 +19,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	ocmetricdata "go.opencensus.io/metric/metricdata"
+	octrace "go.opencensus.io/trace"
 
 	"github.com/middleware-labs/otel/attribute"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
@@ -552,6 +554,220 @@ func TestConvertMetrics(t *testing.T) {
 				},
 			},
 			expectedErr: errConversion,
+		}, {
+			desc: "summary",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/summary-a",
+						Description: "a testing summary",
+						Unit:        ocmetricdata.UnitMilliseconds,
+						Type:        ocmetricdata.TypeSummary,
+						LabelKeys: []ocmetricdata.LabelKey{
+							{Key: "g"},
+						},
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							LabelValues: []ocmetricdata.LabelValue{
+								{
+									Value:   "ding",
+									Present: true,
+								},
+							},
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewSummaryPoint(endTime1, &ocmetricdata.Summary{
+									Count:          10,
+									Sum:            13.2,
+									HasCountAndSum: true,
+									Snapshot: ocmetricdata.Snapshot{
+										Percentiles: map[float64]float64{
+											99.0: 2.4,
+											50.0: 1.2,
+										},
+									},
+								}),
+								ocmetricdata.NewSummaryPoint(endTime2, &ocmetricdata.Summary{
+									Count:          12,
+									Sum:            14.1,
+									HasCountAndSum: true,
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/summary-a",
+					Description: "a testing summary",
+					Unit:        "ms",
+					Data: metricdata.Summary{
+						DataPoints: []metricdata.SummaryDataPoint{
+							{
+								Attributes: attribute.NewSet(attribute.String("g", "ding")),
+								StartTime:  startTime,
+								Time:       endTime1,
+								Count:      10,
+								Sum:        13.2,
+								QuantileValues: []metricdata.QuantileValue{
+									{Quantile: 0.5, Value: 1.2},
+									{Quantile: 0.99, Value: 2.4},
+								},
+							}, {
+								Attributes: attribute.NewSet(attribute.String("g", "ding")),
+								StartTime:  startTime,
+								Time:       endTime2,
+								Count:      12,
+								Sum:        14.1,
+							},
+						},
+					},
+				},
+			},
+		}, {
+			desc: "histogram with exemplars",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/histogram-exemplars",
+						Unit: ocmetricdata.UnitDimensionless,
+						Type: ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 2,
+									Sum:   4.5,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{1.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{
+											Count: 1,
+											Exemplar: &ocmetricdata.Exemplar{
+												Value:     0.5,
+												Timestamp: endTime2,
+												Attachments: map[string]interface{}{
+													ocmetricdata.AttachmentKeySpanContext: octrace.SpanContext{
+														TraceID: octrace.TraceID([16]byte{1}),
+														SpanID:  octrace.SpanID([8]byte{2}),
+													},
+													"user": "alice",
+												},
+											},
+										},
+										{
+											Count: 1,
+											Exemplar: &ocmetricdata.Exemplar{
+												Value:     4.0,
+												Timestamp: endTime1,
+											},
+										},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/histogram-exemplars",
+					Unit: "1",
+					Data: metricdata.Histogram[float64]{
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   *attribute.EmptySet(),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        2,
+								Sum:          4.5,
+								Bounds:       []float64{1.0},
+								BucketCounts: []uint64{1, 1},
+								Exemplars: []metricdata.Exemplar[float64]{
+									{
+										FilteredAttributes: []attribute.KeyValue{attribute.String("user", "alice")},
+										Time:               endTime2,
+										Value:              0.5,
+										TraceID:            []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+										SpanID:             []byte{2, 0, 0, 0, 0, 0, 0, 0},
+									},
+									{
+										Time:  endTime1,
+										Value: 4.0,
+									},
+								},
+							},
+						},
+						Temporality: metricdata.CumulativeTemporality,
+					},
+				},
+			},
+		}, {
+			desc: "summary with negative count",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/bad-summary",
+						Type: ocmetricdata.TypeSummary,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewSummaryPoint(endTime1, &ocmetricdata.Summary{
+									Count:          -1,
+									HasCountAndSum: true,
+								}),
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errConversion,
+		}, {
+			desc: "summary with invalid percentile",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/bad-summary",
+						Type: ocmetricdata.TypeSummary,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewSummaryPoint(endTime1, &ocmetricdata.Summary{
+									Snapshot: ocmetricdata.Snapshot{
+										Percentiles: map[float64]float64{150.0: 1.0},
+									},
+								}),
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errConversion,
+		}, {
+			desc: "summary with a distribution point",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/bad-summary",
+						Type: ocmetricdata.TypeSummary,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{}),
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errConversion,
 		}, {
 			desc: "unsupported Gauge Distribution type",
 			input: []*ocmetricdata.Metric{
@@ -579,6 +795,27 @@ func TestConvertMetrics(t *testing.T) {
 	}
 }
 
+func TestConvertExemplarInvalidSpanContext(t *testing.T) {
+	_, err := convertExemplars([]ocmetricdata.Bucket{{
+		Exemplar: &ocmetricdata.Exemplar{
+			Value: 1.0,
+			Attachments: map[string]interface{}{
+				ocmetricdata.AttachmentKeySpanContext: "not a span context",
+			},
+		},
+	}})
+	assert.ErrorContains(t, err, errInvalidSpanContext.Error())
+}
+
+func TestConvertAttachment(t *testing.T) {
+	assert.Equal(t, attribute.String("k", "v"), convertAttachment("k", "v"))
+	assert.Equal(t, attribute.Bool("k", true), convertAttachment("k", true))
+	assert.Equal(t, attribute.Int("k", 1), convertAttachment("k", 1))
+	assert.Equal(t, attribute.Int64("k", 2), convertAttachment("k", int64(2)))
+	assert.Equal(t, attribute.Float64("k", 3.5), convertAttachment("k", 3.5))
+	assert.Equal(t, attribute.String("k", "[1 2]"), convertAttachment("k", []int{1, 2}))
+}
+
 func TestConvertAttributes(t *testing.T) {
 	setWithMultipleKeys := attribute.NewSet(
 		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1")},
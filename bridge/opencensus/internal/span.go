@@ -34,6 +34,8 @@ const (
 )
 
 var (
+	// MessageIDKey is used for the message ID attribute.
+	MessageIDKey = attribute.Key("message id")
 	// UncompressedKey is used for the uncompressed byte size attribute.
 	UncompressedKey = attribute.Key("uncompressed byte size")
 	// CompressedKey is used for the compressed byte size attribute.
@@ -70,9 +72,15 @@ func (s *Span) SetName(name string) {
 	s.otelSpan.SetName(name)
 }
 
-// SetStatus sets the status of this span, if it is recording events.
+// SetStatus sets the status of this span, if it is recording events. The OK
+// status code is mapped to the Unset status code, all other codes to the
+// Error status code.
 func (s *Span) SetStatus(status octrace.Status) {
-	s.otelSpan.SetStatus(codes.Code(status.Code), status.Message)
+	code := codes.Error
+	if status.Code == octrace.StatusCodeOK {
+		code = codes.Unset
+	}
+	s.otelSpan.SetStatus(code, status.Message)
 }
 
 // AddAttributes sets attributes in this span.
@@ -94,6 +102,10 @@ func (s *Span) Annotatef(attributes []octrace.Attribute, format string, a ...int
 func (s *Span) AddMessageSendEvent(messageID, uncompressedByteSize, compressedByteSize int64) {
 	s.otelSpan.AddEvent(MessageSendEvent,
 		trace.WithAttributes(
+			attribute.KeyValue{
+				Key:   MessageIDKey,
+				Value: attribute.Int64Value(messageID),
+			},
 			attribute.KeyValue{
 				Key:   UncompressedKey,
 				Value: attribute.Int64Value(uncompressedByteSize),
@@ -109,6 +121,10 @@ func (s *Span) AddMessageSendEvent(messageID, uncompressedByteSize, compressedBy
 func (s *Span) AddMessageReceiveEvent(messageID, uncompressedByteSize, compressedByteSize int64) {
 	s.otelSpan.AddEvent(MessageReceiveEvent,
 		trace.WithAttributes(
+			attribute.KeyValue{
+				Key:   MessageIDKey,
+				Value: attribute.Int64Value(messageID),
+			},
 			attribute.KeyValue{
 				Key:   UncompressedKey,
 				Value: attribute.Int64Value(uncompressedByteSize),
@@ -120,8 +136,20 @@ func (s *Span) AddMessageReceiveEvent(messageID, uncompressedByteSize, compresse
 	)
 }
 
-// AddLink adds a link to this span.
+// linkAdder is implemented by OpenTelemetry spans that support adding links
+// after their creation.
+type linkAdder interface {
+	AddLink(trace.Link)
+}
+
+// AddLink adds a link to this span. If the wrapped OpenTelemetry span does
+// not support adding links after its creation, the link is dropped and an
+// error is sent to the error handler.
 func (s *Span) AddLink(l octrace.Link) {
+	if la, ok := s.otelSpan.(linkAdder); ok {
+		la.AddLink(oc2otel.Link(l))
+		return
+	}
 	Handle(fmt.Errorf("ignoring OpenCensus link %+v for span %q because OpenTelemetry doesn't support setting links after creation", l, s.String()))
 }
 
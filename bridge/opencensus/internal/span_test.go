@@ -101,19 +101,28 @@ func TestSpanSetName(t *testing.T) {
 }
 
 func TestSpanSetStatus(t *testing.T) {
-	// OpenCensus does not set a status if not recording.
-	s := &span{recording: true}
-	ocS := internal.NewSpan(s)
-
-	c, d := codes.Error, "error"
-	status := octrace.Status{Code: int32(c), Message: d}
-	ocS.SetStatus(status)
-
-	if s.sCode != c {
-		t.Error("span.SetStatus failed to set OpenTelemetry status code")
-	}
-	if s.sMsg != d {
-		t.Error("span.SetStatus failed to set OpenTelemetry status description")
+	for _, tc := range []struct {
+		code int32
+		want codes.Code
+	}{
+		{code: octrace.StatusCodeOK, want: codes.Unset},
+		{code: octrace.StatusCodeCancelled, want: codes.Error},
+		{code: octrace.StatusCodeUnknown, want: codes.Error},
+		{code: octrace.StatusCodeNotFound, want: codes.Error},
+	} {
+		// OpenCensus does not set a status if not recording.
+		s := &span{recording: true}
+		ocS := internal.NewSpan(s)
+
+		d := "error"
+		ocS.SetStatus(octrace.Status{Code: tc.code, Message: d})
+
+		if s.sCode != tc.want {
+			t.Errorf("span.SetStatus set OpenTelemetry status code %v for %d, want %v", s.sCode, tc.code, tc.want)
+		}
+		if s.sMsg != d {
+			t.Error("span.SetStatus failed to set OpenTelemetry status description")
+		}
 	}
 }
 
@@ -183,12 +192,12 @@ func TestSpanAnnotatef(t *testing.T) {
 }
 
 func TestSpanAddMessageSendEvent(t *testing.T) {
-	var u, c int64 = 1, 2
+	var id, u, c int64 = 5, 1, 2
 
 	// OpenCensus does not set events if not recording.
 	s := &span{recording: true}
 	ocS := internal.NewSpan(s)
-	ocS.AddMessageSendEvent(0, u, c)
+	ocS.AddMessageSendEvent(id, u, c)
 
 	if s.eName != internal.MessageSendEvent {
 		t.Error("span.AddMessageSendEvent did not set event name")
@@ -196,28 +205,33 @@ func TestSpanAddMessageSendEvent(t *testing.T) {
 
 	config := trace.NewEventConfig(s.eOpts...)
 	got := config.Attributes()
-	if len(got) != 2 {
-		t.Fatalf("span.AddMessageSendEvent set %d attributes, want 2", len(got))
+	if len(got) != 3 {
+		t.Fatalf("span.AddMessageSendEvent set %d attributes, want 3", len(got))
 	}
 
-	want := attribute.KeyValue{Key: internal.UncompressedKey, Value: attribute.Int64Value(u)}
+	want := attribute.KeyValue{Key: internal.MessageIDKey, Value: attribute.Int64Value(id)}
 	if got[0] != want {
-		t.Errorf("span.AddMessageSendEvent wrong uncompressed attribute: %v", got[0])
+		t.Errorf("span.AddMessageSendEvent wrong message ID attribute: %v", got[0])
 	}
 
-	want = attribute.KeyValue{Key: internal.CompressedKey, Value: attribute.Int64Value(c)}
+	want = attribute.KeyValue{Key: internal.UncompressedKey, Value: attribute.Int64Value(u)}
 	if got[1] != want {
-		t.Errorf("span.AddMessageSendEvent wrong compressed attribute: %v", got[1])
+		t.Errorf("span.AddMessageSendEvent wrong uncompressed attribute: %v", got[1])
+	}
+
+	want = attribute.KeyValue{Key: internal.CompressedKey, Value: attribute.Int64Value(c)}
+	if got[2] != want {
+		t.Errorf("span.AddMessageSendEvent wrong compressed attribute: %v", got[2])
 	}
 }
 
 func TestSpanAddMessageReceiveEvent(t *testing.T) {
-	var u, c int64 = 3, 4
+	var id, u, c int64 = 6, 3, 4
 
 	// OpenCensus does not set events if not recording.
 	s := &span{recording: true}
 	ocS := internal.NewSpan(s)
-	ocS.AddMessageReceiveEvent(0, u, c)
+	ocS.AddMessageReceiveEvent(id, u, c)
 
 	if s.eName != internal.MessageReceiveEvent {
 		t.Error("span.AddMessageReceiveEvent did not set event name")
@@ -225,18 +239,23 @@ func TestSpanAddMessageReceiveEvent(t *testing.T) {
 
 	config := trace.NewEventConfig(s.eOpts...)
 	got := config.Attributes()
-	if len(got) != 2 {
-		t.Fatalf("span.AddMessageReceiveEvent set %d attributes, want 2", len(got))
+	if len(got) != 3 {
+		t.Fatalf("span.AddMessageReceiveEvent set %d attributes, want 3", len(got))
 	}
 
-	want := attribute.KeyValue{Key: internal.UncompressedKey, Value: attribute.Int64Value(u)}
+	want := attribute.KeyValue{Key: internal.MessageIDKey, Value: attribute.Int64Value(id)}
 	if got[0] != want {
-		t.Errorf("span.AddMessageReceiveEvent wrong uncompressed attribute: %v", got[0])
+		t.Errorf("span.AddMessageReceiveEvent wrong message ID attribute: %v", got[0])
 	}
 
-	want = attribute.KeyValue{Key: internal.CompressedKey, Value: attribute.Int64Value(c)}
+	want = attribute.KeyValue{Key: internal.UncompressedKey, Value: attribute.Int64Value(u)}
 	if got[1] != want {
-		t.Errorf("span.AddMessageReceiveEvent wrong compressed attribute: %v", got[1])
+		t.Errorf("span.AddMessageReceiveEvent wrong uncompressed attribute: %v", got[1])
+	}
+
+	want = attribute.KeyValue{Key: internal.CompressedKey, Value: attribute.Int64Value(c)}
+	if got[2] != want {
+		t.Errorf("span.AddMessageReceiveEvent wrong compressed attribute: %v", got[2])
 	}
 }
 
@@ -254,6 +273,40 @@ func TestSpanAddLinkFails(t *testing.T) {
 	}
 }
 
+type linkSpan struct {
+	span
+
+	links []trace.Link
+}
+
+func (s *linkSpan) AddLink(l trace.Link) { s.links = append(s.links, l) }
+
+func TestSpanAddLink(t *testing.T) {
+	h, restore := withHandler()
+	defer restore()
+
+	s := &linkSpan{span: span{recording: true}}
+	ocS := internal.NewSpan(s)
+	ocS.AddLink(octrace.Link{
+		TraceID: octrace.TraceID([16]byte{1}),
+		SpanID:  octrace.SpanID([8]byte{2}),
+	})
+
+	if h.err != nil {
+		t.Errorf("span.AddLink raised an error: %v", h.err)
+	}
+	if len(s.links) != 1 {
+		t.Fatalf("span.AddLink added %d links, want 1", len(s.links))
+	}
+	want := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID([16]byte{1}),
+		SpanID:  trace.SpanID([8]byte{2}),
+	})
+	if !s.links[0].SpanContext.Equal(want) {
+		t.Errorf("span.AddLink wrong span context: %v", s.links[0].SpanContext)
+	}
+}
+
 func TestSpanString(t *testing.T) {
 	sc := trace.NewSpanContext(trace.SpanContextConfig{
 		TraceID: [16]byte{1},
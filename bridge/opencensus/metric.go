@@ -16,6 +16,7 @@ package opencensus // import "github.com/middleware-labs/otel/bridge/opencensus"
 
 import (
 	"context"
+	"fmt"
 
 	ocmetricdata "go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricexport"
@@ -31,15 +32,61 @@ import (
 
 const scopeName = "github.com/middleware-labs/otel/bridge/opencensus"
 
+// MetricOption applies an option to the metric.Producer returned by
+// NewMetricProducer.
+type MetricOption interface {
+	apply(metricConfig) metricConfig
+}
+
+type metricConfig struct {
+	views []metric.View
+}
+
+type metricOptionFunc func(metricConfig) metricConfig
+
+func (fn metricOptionFunc) apply(cfg metricConfig) metricConfig {
+	return fn(cfg)
+}
+
+// WithViews returns a MetricOption that applies views to the metrics
+// fetched from OpenCensus, the same views can be passed to the
+// MeterProvider. The Instrument matched by a view has the name,
+// description, and unit of the OpenCensus metric, a kind determined by the
+// type of the metric, and a scope derived from the metric name.
+//
+// A view can rename a metric, change its description and unit, filter its
+// attributes, or drop it with the aggregation.Drop aggregation. Data points
+// with the same filtered attributes are merged. OpenCensus metrics are
+// already aggregated, views setting any other aggregation are reported as
+// an error and the aggregation is unchanged.
+func WithViews(views ...metric.View) MetricOption {
+	return metricOptionFunc(func(cfg metricConfig) metricConfig {
+		cfg.views = append(cfg.views, views...)
+		return cfg
+	})
+}
+
 type producer struct {
 	manager *metricproducer.Manager
+	views   []metric.View
 }
 
 // NewMetricProducer returns a metric.Producer that fetches metrics from
 // OpenCensus.
-func NewMetricProducer() metric.Producer {
+//
+// Metrics are grouped by an instrumentation scope derived from the name of
+// the OpenCensus view or metric: the part of the name before the last "/".
+// For example, "grpc.io/client/roundtrip_latency" has the scope
+// "grpc.io/client". Metrics with a name without "/" have the scope of the
+// bridge.
+func NewMetricProducer(opts ...MetricOption) metric.Producer {
+	var cfg metricConfig
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
 	return &producer{
 		manager: metricproducer.GlobalManager(),
+		views:   cfg.views,
 	}
 }
 
@@ -53,12 +100,15 @@ func (p *producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
 	if len(otelmetrics) == 0 {
 		return nil, err
 	}
-	return []metricdata.ScopeMetrics{{
-		Scope: instrumentation.Scope{
-			Name: scopeName,
-		},
-		Metrics: otelmetrics,
-	}}, err
+	scopeMetrics, vErr := applyViews(p.views, otelmetrics)
+	if vErr != nil {
+		if err == nil {
+			err = vErr
+		} else {
+			err = fmt.Errorf("%w; %v", err, vErr)
+		}
+	}
+	return scopeMetrics, err
 }
 
 // exporter implements the OpenCensus metric Exporter interface using an
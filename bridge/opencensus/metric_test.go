@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ocmetricdata "go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
@@ -28,6 +29,7 @@ import (
 	"github.com/middleware-labs/otel/attribute"
 	"github.com/middleware-labs/otel/sdk/instrumentation"
 	"github.com/middleware-labs/otel/sdk/metric"
+	"github.com/middleware-labs/otel/sdk/metric/aggregation"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata/metricdatatest"
 	"github.com/middleware-labs/otel/sdk/resource"
@@ -153,6 +155,193 @@ func TestMetricProducer(t *testing.T) {
 	}
 }
 
+func TestMetricProducerViews(t *testing.T) {
+	now := time.Now()
+	series := func(method, status string, v int64) *ocmetricdata.TimeSeries {
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{
+				ocmetricdata.NewLabelValue(method),
+				ocmetricdata.NewLabelValue(status),
+			},
+			StartTime: now,
+			Points:    []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, v)},
+		}
+	}
+	labelKeys := []ocmetricdata.LabelKey{{Key: "method"}, {Key: "status"}}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "grpc.io/client/completed_rpcs",
+				Unit:      ocmetricdata.UnitDimensionless,
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: labelKeys,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				series("get", "OK", 1),
+				series("get", "ERROR", 2),
+				series("put", "OK", 4),
+			},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "grpc.io/server/completed_rpcs",
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: labelKeys,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{series("get", "OK", 1)},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "queue_size",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: labelKeys,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{series("get", "OK", 8)},
+		},
+	}
+	fakeProducer := &fakeOCProducer{metrics: input}
+	metricproducer.GlobalManager().AddProducer(fakeProducer)
+	defer metricproducer.GlobalManager().DeleteProducer(fakeProducer)
+
+	p := NewMetricProducer(WithViews(
+		metric.NewView(
+			metric.Instrument{Name: "*/completed_rpcs", Kind: metric.InstrumentKindCounter, Scope: instrumentation.Scope{Name: "grpc.io/client"}},
+			metric.Stream{AttributeFilter: func(kv attribute.KeyValue) bool { return kv.Key == "method" }},
+		),
+		metric.NewView(
+			metric.Instrument{Scope: instrumentation.Scope{Name: "grpc.io/server"}},
+			metric.Stream{Aggregation: aggregation.Drop{}},
+		),
+		metric.NewView(
+			metric.Instrument{Name: "queue_size", Kind: metric.InstrumentKindObservableGauge},
+			metric.Stream{Name: "queue.size", Description: "renamed"},
+		),
+	))
+	output, err := p.Produce(context.Background())
+	require.NoError(t, err)
+
+	expected := []metricdata.ScopeMetrics{
+		{
+			Scope: instrumentation.Scope{Name: "grpc.io/client"},
+			Metrics: []metricdata.Metrics{{
+				Name: "grpc.io/client/completed_rpcs",
+				Unit: "1",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{
+							Attributes: attribute.NewSet(attribute.String("method", "get")),
+							StartTime:  now,
+							Time:       now,
+							Value:      3,
+						},
+						{
+							Attributes: attribute.NewSet(attribute.String("method", "put")),
+							StartTime:  now,
+							Time:       now,
+							Value:      4,
+						},
+					},
+				},
+			}},
+		},
+		{
+			Scope: instrumentation.Scope{Name: scopeName},
+			Metrics: []metricdata.Metrics{{
+				Name:        "queue.size",
+				Description: "renamed",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Attributes: attribute.NewSet(
+							attribute.String("method", "get"),
+							attribute.String("status", "OK"),
+						),
+						StartTime: now,
+						Time:      now,
+						Value:     8,
+					}},
+				},
+			}},
+		},
+	}
+	require.Len(t, output, len(expected))
+	for i := range output {
+		metricdatatest.AssertEqual(t, expected[i], output[i])
+	}
+}
+
+func TestMetricProducerViewsUnsupportedAggregation(t *testing.T) {
+	fakeProducer := &fakeOCProducer{metrics: []*ocmetricdata.Metric{{
+		Descriptor: ocmetricdata.Descriptor{
+			Name: "foo.com/count",
+			Type: ocmetricdata.TypeCumulativeInt64,
+		},
+		TimeSeries: []*ocmetricdata.TimeSeries{{
+			Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), 1)},
+		}},
+	}}}
+	metricproducer.GlobalManager().AddProducer(fakeProducer)
+	defer metricproducer.GlobalManager().DeleteProducer(fakeProducer)
+
+	p := NewMetricProducer(WithViews(metric.NewView(
+		metric.Instrument{Name: "foo.com/count"},
+		metric.Stream{Aggregation: aggregation.LastValue{}},
+	)))
+	output, err := p.Produce(context.Background())
+	require.ErrorIs(t, err, errAggregation)
+
+	// The metric is still produced with its original aggregation.
+	require.Len(t, output, 1)
+	assert.Equal(t, instrumentation.Scope{Name: "foo.com"}, output[0].Scope)
+	require.Len(t, output[0].Metrics, 1)
+	assert.IsType(t, metricdata.Sum[int64]{}, output[0].Metrics[0].Data)
+}
+
+func TestFilterHistogramPoints(t *testing.T) {
+	f := func(kv attribute.KeyValue) bool { return kv.Key == "a" }
+	pts := []metricdata.HistogramDataPoint[float64]{
+		{
+			Attributes:   attribute.NewSet(attribute.Int("a", 1), attribute.Int("b", 1)),
+			Count:        2,
+			Bounds:       []float64{1},
+			BucketCounts: []uint64{1, 1},
+			Min:          metricdata.NewExtrema(0.5),
+			Max:          metricdata.NewExtrema(2.),
+			Sum:          2.5,
+		},
+		{
+			Attributes:   attribute.NewSet(attribute.Int("a", 1), attribute.Int("b", 2)),
+			Count:        1,
+			Bounds:       []float64{1},
+			BucketCounts: []uint64{0, 1},
+			Min:          metricdata.NewExtrema(3.),
+			Max:          metricdata.NewExtrema(3.),
+			Sum:          3,
+		},
+	}
+	got := filterHistogramPoints(f, pts)
+	want := []metricdata.HistogramDataPoint[float64]{{
+		Attributes:   attribute.NewSet(attribute.Int("a", 1)),
+		Count:        3,
+		Bounds:       []float64{1},
+		BucketCounts: []uint64{1, 2},
+		Min:          metricdata.NewExtrema(0.5),
+		Max:          metricdata.NewExtrema(3.),
+		Sum:          5.5,
+	}}
+	metricdatatest.AssertEqual(t, metricdata.Histogram[float64]{DataPoints: want}, metricdata.Histogram[float64]{DataPoints: got})
+	// The input must not be modified.
+	assert.Equal(t, []uint64{1, 1}, pts[0].BucketCounts)
+}
+
+func TestViewScope(t *testing.T) {
+	assert.Equal(t, "grpc.io/client", viewScope("grpc.io/client/roundtrip_latency").Name)
+	assert.Equal(t, "opencensus.io/http/server", viewScope("opencensus.io/http/server/latency").Name)
+	assert.Equal(t, scopeName, viewScope("latency").Name)
+	assert.Equal(t, scopeName, viewScope("/latency").Name)
+}
+
 type fakeOCProducer struct {
 	metrics []*ocmetricdata.Metric
 }
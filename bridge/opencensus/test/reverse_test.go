@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	octrace "go.opencensus.io/trace"
+
+	"github.com/middleware-labs/otel/attribute"
+	ocbridge "github.com/middleware-labs/otel/bridge/opencensus"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/trace"
+)
+
+// nativeTracer is the OpenCensus Tracer implementation. It is captured
+// before other tests replace the DefaultTracer with the bridge.
+var nativeTracer = octrace.DefaultTracer
+
+type ocExporter struct {
+	mu    sync.Mutex
+	spans []*octrace.SpanData
+}
+
+func (e *ocExporter) ExportSpan(s *octrace.SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, s)
+}
+
+// normalized is the comparable part of an OpenCensus SpanData.
+type normalized struct {
+	Name            string
+	Parent          string
+	SpanKind        int
+	Attributes      map[string]interface{}
+	Annotations     []octrace.Annotation
+	MessageEvents   []octrace.MessageEvent
+	Links           []octrace.Link
+	Status          octrace.Status
+	ChildSpanCount  int
+	HasRemoteParent bool
+}
+
+// normalize removes IDs and timestamps from spans and sorts them by name.
+func normalize(spans []*octrace.SpanData) []normalized {
+	names := make(map[octrace.SpanID]string, len(spans))
+	for _, s := range spans {
+		names[s.SpanID] = s.Name
+	}
+	out := make([]normalized, 0, len(spans))
+	for _, s := range spans {
+		n := normalized{
+			Name:            s.Name,
+			Parent:          names[s.ParentSpanID],
+			SpanKind:        s.SpanKind,
+			Attributes:      s.Attributes,
+			Links:           s.Links,
+			Status:          s.Status,
+			ChildSpanCount:  s.ChildSpanCount,
+			HasRemoteParent: s.HasRemoteParent,
+		}
+		for _, a := range s.Annotations {
+			a.Time = time.Time{}
+			n.Annotations = append(n.Annotations, a)
+		}
+		for _, e := range s.MessageEvents {
+			e.Time = time.Time{}
+			n.MessageEvents = append(n.MessageEvents, e)
+		}
+		out = append(out, n)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ocScenario records spans using the OpenCensus API.
+func ocScenario(tracer octrace.Tracer) {
+	ctx, parent := tracer.StartSpan(context.Background(), "parent", octrace.WithSpanKind(octrace.SpanKindServer))
+	parent.AddAttributes(
+		octrace.BoolAttribute("bool", true),
+		octrace.Int64Attribute("int64", 1),
+		octrace.Float64Attribute("float64", 1.5),
+		octrace.StringAttribute("string", "value"),
+	)
+	parent.Annotate([]octrace.Attribute{octrace.Int64Attribute("n", 1)}, "annotation")
+	parent.AddMessageSendEvent(1, 10, 5)
+	parent.AddMessageReceiveEvent(2, 20, 0)
+
+	_, child := tracer.StartSpan(ctx, "child", octrace.WithSpanKind(octrace.SpanKindClient))
+	child.AddLink(octrace.Link{
+		TraceID:    octrace.TraceID{1},
+		SpanID:     octrace.SpanID{2},
+		Type:       octrace.LinkTypeChild,
+		Attributes: map[string]interface{}{"a": "b"},
+	})
+	child.SetStatus(octrace.Status{Code: octrace.StatusCodeUnknown, Message: "failed"})
+	child.End()
+	parent.End()
+}
+
+// TestReverseConformanceOC verifies that spans recorded with the OpenCensus
+// API through the forward and reverse bridges are exported as the native
+// OpenCensus implementation would export them.
+func TestReverseConformanceOC(t *testing.T) {
+	native := new(ocExporter)
+	octrace.RegisterExporter(native)
+	octrace.ApplyConfig(octrace.Config{DefaultSampler: octrace.AlwaysSample()})
+	ocScenario(nativeTracer)
+	octrace.UnregisterExporter(native)
+
+	bridged := new(ocExporter)
+	tp := ocbridge.NewReverseTracerProvider(bridged)
+	ocScenario(ocbridge.NewTracer(tp.Tracer("conformance")))
+
+	want, got := normalize(native.spans), normalize(bridged.spans)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("bridged spans differ from native spans:\nwant %+v\ngot  %+v", want, got)
+	}
+}
+
+// TestReverseConformanceOTel verifies that spans recorded with the
+// OpenTelemetry API are exported according to the documented mapping.
+func TestReverseConformanceOTel(t *testing.T) {
+	exp := new(ocExporter)
+	tracer := ocbridge.NewReverseTracerProvider(exp).Tracer("conformance")
+
+	link := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	ctx, parent := tracer.Start(context.Background(), "parent",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("string", "value")),
+	)
+	parent.AddEvent("annotation", trace.WithAttributes(attribute.Int64("n", 1)))
+	_, child := tracer.Start(ctx, "child",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithLinks(trace.Link{SpanContext: link}),
+	)
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	want := []normalized{
+		{
+			Name:     "child",
+			Parent:   "parent",
+			SpanKind: octrace.SpanKindUnspecified,
+			Links: []octrace.Link{{
+				TraceID: octrace.TraceID{1},
+				SpanID:  octrace.SpanID{2},
+			}},
+			Status: octrace.Status{Code: octrace.StatusCodeUnknown, Message: "failed"},
+		},
+		{
+			Name:       "parent",
+			SpanKind:   octrace.SpanKindServer,
+			Attributes: map[string]interface{}{"string": "value"},
+			Annotations: []octrace.Annotation{{
+				Message:    "annotation",
+				Attributes: map[string]interface{}{"n": int64(1)},
+			}},
+			ChildSpanCount: 1,
+		},
+	}
+	if got := normalize(exp.spans); !reflect.DeepEqual(want, got) {
+		t.Errorf("exported spans differ from the mapping:\nwant %+v\ngot  %+v", want, got)
+	}
+}
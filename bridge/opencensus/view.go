@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "github.com/middleware-labs/otel/bridge/opencensus"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/metric"
+	"github.com/middleware-labs/otel/sdk/metric/aggregation"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+)
+
+var errAggregation = errors.New("the aggregation of an OpenCensus metric cannot be changed by a view")
+
+// viewScope returns the instrumentation scope of the OpenCensus view or
+// metric with the given name. It is the part of the name before the last
+// "/", e.g. "grpc.io/client" for "grpc.io/client/roundtrip_latency", or the
+// scope of the bridge if the name has no "/".
+func viewScope(name string) instrumentation.Scope {
+	if i := strings.LastIndex(name, "/"); i > 0 {
+		return instrumentation.Scope{Name: name[:i]}
+	}
+	return instrumentation.Scope{Name: scopeName}
+}
+
+// instrumentKind returns the kind of instrument that would have produced
+// data. The zero InstrumentKind is returned for summaries, they are only
+// matched by views not selecting an instrument kind.
+func instrumentKind(data metricdata.Aggregation) metric.InstrumentKind {
+	switch d := data.(type) {
+	case metricdata.Gauge[int64], metricdata.Gauge[float64]:
+		return metric.InstrumentKindObservableGauge
+	case metricdata.Sum[int64]:
+		return sumKind(d.IsMonotonic)
+	case metricdata.Sum[float64]:
+		return sumKind(d.IsMonotonic)
+	case metricdata.Histogram[int64], metricdata.Histogram[float64]:
+		return metric.InstrumentKindHistogram
+	}
+	return 0
+}
+
+func sumKind(monotonic bool) metric.InstrumentKind {
+	if monotonic {
+		return metric.InstrumentKindCounter
+	}
+	return metric.InstrumentKindUpDownCounter
+}
+
+// applyViews groups metrics by the scope derived from their name and
+// applies views to them the same way a MeterProvider applies views to its
+// instruments: every matching view produces a stream, and the metric is
+// unchanged if no view matches.
+func applyViews(views []metric.View, metrics []metricdata.Metrics) ([]metricdata.ScopeMetrics, error) {
+	var (
+		out   []metricdata.ScopeMetrics
+		index = make(map[instrumentation.Scope]int)
+		errs  []string
+	)
+	for _, m := range metrics {
+		inst := metric.Instrument{
+			Name:        m.Name,
+			Description: m.Description,
+			Kind:        instrumentKind(m.Data),
+			Unit:        m.Unit,
+			Scope:       viewScope(m.Name),
+		}
+
+		var streams []metricdata.Metrics
+		matched := false
+		for _, v := range views {
+			stream, ok := v(inst)
+			if !ok {
+				continue
+			}
+			matched = true
+			s, err := applyStream(stream, m)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", m.Name, err))
+			}
+			if s != nil {
+				streams = append(streams, *s)
+			}
+		}
+		if !matched {
+			streams = append(streams, m)
+		}
+		if len(streams) == 0 {
+			continue
+		}
+
+		i, ok := index[inst.Scope]
+		if !ok {
+			i = len(out)
+			index[inst.Scope] = i
+			out = append(out, metricdata.ScopeMetrics{Scope: inst.Scope})
+		}
+		out[i].Metrics = append(out[i].Metrics, streams...)
+	}
+	if len(errs) > 0 {
+		return out, fmt.Errorf("%w: %s", errAggregation, strings.Join(errs, "; "))
+	}
+	return out, nil
+}
+
+// applyStream returns m updated with the stream, or nil if the stream drops
+// it. The data of OpenCensus metrics is already aggregated, only the Drop
+// aggregation is supported.
+func applyStream(s metric.Stream, m metricdata.Metrics) (*metricdata.Metrics, error) {
+	var err error
+	switch s.Aggregation.(type) {
+	case nil, aggregation.Default:
+	case aggregation.Drop:
+		return nil, nil
+	default:
+		err = fmt.Errorf("unsupported aggregation %T", s.Aggregation)
+	}
+
+	out := metricdata.Metrics{
+		Name:        nonZero(s.Name, m.Name),
+		Description: nonZero(s.Description, m.Description),
+		Unit:        nonZero(s.Unit, m.Unit),
+		Data:        m.Data,
+	}
+	if s.AttributeFilter != nil {
+		out.Data = filterAggregation(s.AttributeFilter, m.Data)
+	}
+	return &out, err
+}
+
+func nonZero(v, def string) string {
+	if v != "" {
+		return v
+	}
+	return def
+}
+
+// filterAggregation returns data with the attributes of its data points
+// filtered. Data points with the same filtered attributes are merged.
+func filterAggregation(f attribute.Filter, data metricdata.Aggregation) metricdata.Aggregation {
+	switch d := data.(type) {
+	case metricdata.Gauge[int64]:
+		d.DataPoints = filterPoints(f, d.DataPoints, mergeGauge[int64])
+		return d
+	case metricdata.Gauge[float64]:
+		d.DataPoints = filterPoints(f, d.DataPoints, mergeGauge[float64])
+		return d
+	case metricdata.Sum[int64]:
+		d.DataPoints = filterPoints(f, d.DataPoints, mergeSum[int64])
+		return d
+	case metricdata.Sum[float64]:
+		d.DataPoints = filterPoints(f, d.DataPoints, mergeSum[float64])
+		return d
+	case metricdata.Histogram[int64]:
+		d.DataPoints = filterHistogramPoints(f, d.DataPoints)
+		return d
+	case metricdata.Histogram[float64]:
+		d.DataPoints = filterHistogramPoints(f, d.DataPoints)
+		return d
+	case metricdata.Summary:
+		d.DataPoints = filterSummaryPoints(f, d.DataPoints)
+		return d
+	}
+	return data
+}
+
+func filterPoints[N int64 | float64](f attribute.Filter, pts []metricdata.DataPoint[N], merge func(a, b metricdata.DataPoint[N]) metricdata.DataPoint[N]) []metricdata.DataPoint[N] {
+	out := make([]metricdata.DataPoint[N], 0, len(pts))
+	index := make(map[attribute.Distinct]int, len(pts))
+	for _, p := range pts {
+		p.Attributes, _ = p.Attributes.Filter(f)
+		key := p.Attributes.Equivalent()
+		if i, ok := index[key]; ok {
+			out[i] = merge(out[i], p)
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, p)
+	}
+	return out
+}
+
+// mergeGauge keeps the most recent of the gauge values.
+func mergeGauge[N int64 | float64](a, b metricdata.DataPoint[N]) metricdata.DataPoint[N] {
+	if b.Time.After(a.Time) {
+		return b
+	}
+	return a
+}
+
+// mergeSum adds the sum values.
+func mergeSum[N int64 | float64](a, b metricdata.DataPoint[N]) metricdata.DataPoint[N] {
+	if b.StartTime.Before(a.StartTime) {
+		a.StartTime = b.StartTime
+	}
+	if b.Time.After(a.Time) {
+		a.Time = b.Time
+	}
+	a.Value += b.Value
+	a.Exemplars = append(a.Exemplars, b.Exemplars...)
+	return a
+}
+
+func filterHistogramPoints[N int64 | float64](f attribute.Filter, pts []metricdata.HistogramDataPoint[N]) []metricdata.HistogramDataPoint[N] {
+	out := make([]metricdata.HistogramDataPoint[N], 0, len(pts))
+	index := make(map[attribute.Distinct]int, len(pts))
+	for _, p := range pts {
+		p.Attributes, _ = p.Attributes.Filter(f)
+		key := p.Attributes.Equivalent()
+		i, ok := index[key]
+		if !ok || !equalBounds(out[i].Bounds, p.Bounds) {
+			// Points with different bounds cannot be merged.
+			index[key] = len(out)
+			out = append(out, p)
+			continue
+		}
+
+		a := &out[i]
+		if p.StartTime.Before(a.StartTime) {
+			a.StartTime = p.StartTime
+		}
+		if p.Time.After(a.Time) {
+			a.Time = p.Time
+		}
+		a.Count += p.Count
+		a.Sum += p.Sum
+		counts := make([]uint64, len(a.BucketCounts))
+		for j := range counts {
+			counts[j] = a.BucketCounts[j] + p.BucketCounts[j]
+		}
+		a.BucketCounts = counts
+		if v, ok := p.Min.Value(); ok {
+			if cur, ok := a.Min.Value(); !ok || v < cur {
+				a.Min = metricdata.NewExtrema(v)
+			}
+		}
+		if v, ok := p.Max.Value(); ok {
+			if cur, ok := a.Max.Value(); !ok || v > cur {
+				a.Max = metricdata.NewExtrema(v)
+			}
+		}
+		a.Exemplars = append(a.Exemplars, p.Exemplars...)
+	}
+	return out
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// filterSummaryPoints merges the count and sum of summaries. Quantiles
+// cannot be merged and are dropped from merged points.
+func filterSummaryPoints(f attribute.Filter, pts []metricdata.SummaryDataPoint) []metricdata.SummaryDataPoint {
+	out := make([]metricdata.SummaryDataPoint, 0, len(pts))
+	index := make(map[attribute.Distinct]int, len(pts))
+	for _, p := range pts {
+		p.Attributes, _ = p.Attributes.Filter(f)
+		key := p.Attributes.Equivalent()
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(out)
+			out = append(out, p)
+			continue
+		}
+
+		a := &out[i]
+		if p.StartTime.Before(a.StartTime) {
+			a.StartTime = p.StartTime
+		}
+		if p.Time.After(a.Time) {
+			a.Time = p.Time
+		}
+		a.Count += p.Count
+		a.Sum += p.Sum
+		a.QuantileValues = nil
+	}
+	return out
+}
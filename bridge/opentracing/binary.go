@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing // import "github.com/middleware-labs/otel/bridge/opentracing"
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	"github.com/middleware-labs/otel/baggage"
+	"github.com/middleware-labs/otel/propagation"
+)
+
+const (
+	// maxBinarySpanContextLen is the maximum length of the span context
+	// part of the Binary format. It leaves room for future versions of the
+	// binary traceparent encoding.
+	maxBinarySpanContextLen = 256
+	// maxBinaryBaggageLen is the maximum length of the baggage part of the
+	// Binary format, as defined by the W3C Baggage specification.
+	maxBinaryBaggageLen = 8192
+)
+
+// marshalBinary writes sc to w using the Binary format of the BridgeTracer.
+//
+// The format is made of two length-prefixed fields, each length being a
+// big-endian uint32:
+//
+//	span context length | span context (propagation.BinaryTraceContext encoding)
+//	baggage length      | baggage (W3C baggage-string)
+//
+// The baggage is encoded as a W3C baggage-string, so member properties are
+// propagated along with their values. The trace state is not propagated.
+func marshalBinary(w io.Writer, sc *bridgeSpanContext) error {
+	scBytes := propagation.BinaryTraceContext{}.Marshal(sc.otelSpanContext)
+	bagBytes := []byte(sc.bag.String())
+
+	buf := make([]byte, 0, 8+len(scBytes)+len(bagBytes))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(scBytes)))
+	buf = append(buf, scBytes...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(bagBytes)))
+	buf = append(buf, bagBytes...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// unmarshalBinary reads a span context written with marshalBinary from r.
+func unmarshalBinary(r io.Reader) (*bridgeSpanContext, error) {
+	scBytes, err := readBinaryField(r, maxBinarySpanContextLen)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ot.ErrSpanContextNotFound
+		}
+		return nil, ot.ErrSpanContextCorrupted
+	}
+	otelSC, err := propagation.BinaryTraceContext{}.Unmarshal(scBytes)
+	if err != nil {
+		return nil, ot.ErrSpanContextCorrupted
+	}
+
+	bagBytes, err := readBinaryField(r, maxBinaryBaggageLen)
+	if err != nil {
+		return nil, ot.ErrSpanContextCorrupted
+	}
+	bag, err := baggage.Parse(string(bagBytes))
+	if err != nil {
+		return nil, ot.ErrSpanContextCorrupted
+	}
+	return &bridgeSpanContext{bag: bag, otelSpanContext: otelSC}, nil
+}
+
+// readBinaryField reads a length-prefixed field, of at most max bytes, from
+// r. An io.EOF error is only returned if r is empty.
+func readBinaryField(r io.Reader, max uint32) ([]byte, error) {
+	var n [4]byte
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, err
+	}
+	l := binary.BigEndian.Uint32(n[:])
+	if l > max {
+		return nil, errors.New("binary field too long")
+	}
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
@@ -17,7 +17,9 @@ package opentracing // import "github.com/middleware-labs/otel/bridge/opentracin
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 
@@ -55,7 +57,10 @@ func newBridgeSpanContext(otelSpanContext trace.SpanContext, parentOtSpanContext
 		bag:             baggage.Baggage{},
 		otelSpanContext: otelSpanContext,
 	}
-	if parentOtSpanContext != nil {
+	if parentBridgeSC, ok := parentOtSpanContext.(*bridgeSpanContext); ok {
+		// Members are copied directly to keep their properties.
+		bCtx.bag = parentBridgeSC.bag
+	} else if parentOtSpanContext != nil {
 		parentOtSpanContext.ForeachBaggageItem(func(key, value string) bool {
 			bCtx.setBaggageItem(key, value)
 			return true
@@ -76,9 +81,16 @@ func (c *bridgeSpanContext) IsSampled() bool {
 	return c.otelSpanContext.IsSampled()
 }
 
-func (c *bridgeSpanContext) setBaggageItem(restrictedKey, value string) {
+// setBaggageItem sets the baggage member for restrictedKey to value. If props
+// are not provided, the properties of the existing member are kept.
+func (c *bridgeSpanContext) setBaggageItem(restrictedKey, value string, props ...baggage.Property) {
 	crk := http.CanonicalHeaderKey(restrictedKey)
-	m, err := baggage.NewMember(crk, value)
+	if len(props) == 0 {
+		props = c.bag.Member(crk).Properties()
+	}
+	// NewMember expects a URL encoded value, encode it so any value round
+	// trips.
+	m, err := baggage.NewMember(crk, url.QueryEscape(value), props...)
 	if err != nil {
 		return
 	}
@@ -129,14 +141,29 @@ func (s *bridgeSpan) FinishWithOptions(opts ot.FinishOptions) {
 	s.otelSpan.End(otelOpts...)
 }
 
+// logRecord adds record as an event of the span. A zero timestamp means the
+// event happened now.
 func (s *bridgeSpan) logRecord(record ot.LogRecord) {
+	attrs := otLogFieldsToOTelAttrs(record.Fields)
 	s.otelSpan.AddEvent(
-		"",
+		otLogEventName(attrs),
 		trace.WithTimestamp(record.Timestamp),
-		trace.WithAttributes(otLogFieldsToOTelAttrs(record.Fields)...),
+		trace.WithAttributes(attrs...),
 	)
 }
 
+// otLogEventName returns the name of the event for the attributes of an
+// OpenTracing log. Following the OpenTracing conventions, it is the value of
+// the "event" field, if it is a string, and "log" otherwise.
+func otLogEventName(attrs []attribute.KeyValue) string {
+	for _, attr := range attrs {
+		if attr.Key == "event" && attr.Value.Type() == attribute.STRING {
+			return attr.Value.AsString()
+		}
+	}
+	return "log"
+}
+
 func (s *bridgeSpan) Context() ot.SpanContext {
 	return s.ctx
 }
@@ -170,10 +197,7 @@ func (s *bridgeSpan) SetTag(key string, value interface{}) ot.Span {
 }
 
 func (s *bridgeSpan) LogFields(fields ...otlog.Field) {
-	s.otelSpan.AddEvent(
-		"",
-		trace.WithAttributes(otLogFieldsToOTelAttrs(fields)...),
-	)
+	s.logRecord(ot.LogRecord{Fields: fields})
 }
 
 type bridgeFieldEncoder struct {
@@ -284,8 +308,7 @@ func (s *bridgeSpan) LogEventWithPayload(event string, payload interface{}) {
 }
 
 func (s *bridgeSpan) Log(data ot.LogData) {
-	record := data.ToLogRecord()
-	s.LogFields(record.Fields...)
+	s.logRecord(data.ToLogRecord())
 }
 
 type bridgeSetTracer struct {
@@ -380,7 +403,7 @@ func (t *BridgeTracer) baggageSetHook(ctx context.Context, list iBaggage.List) c
 		return ctx
 	}
 	for k, v := range list {
-		bSpan.setBaggageItemOnly(k, v.Value)
+		bSpan.ctx.setBaggageItem(k, v.Value, otelPropsToBaggageProps(v.Properties)...)
 	}
 	return ctx
 }
@@ -412,12 +435,53 @@ func (t *BridgeTracer) baggageGetHook(ctx context.Context, list iBaggage.List) i
 
 	for k, v := range items {
 		// Overwrite according to OpenTelemetry specification.
-		merged[k] = iBaggage.Item{Value: v}
+		merged[k] = iBaggage.Item{
+			Value:      v,
+			Properties: baggagePropsToOTelProps(bSpan.ctx.baggageItem(k).Properties()),
+		}
 	}
 
 	return merged
 }
 
+// otelPropsToBaggageProps converts the properties of an OpenTelemetry baggage
+// list item to baggage properties. Invalid properties are dropped.
+func otelPropsToBaggageProps(props []iBaggage.Property) []baggage.Property {
+	if len(props) == 0 {
+		return nil
+	}
+	out := make([]baggage.Property, 0, len(props))
+	for _, p := range props {
+		var (
+			bp  baggage.Property
+			err error
+		)
+		if p.HasValue {
+			bp, err = baggage.NewKeyValueProperty(p.Key, p.Value)
+		} else {
+			bp, err = baggage.NewKeyProperty(p.Key)
+		}
+		if err == nil {
+			out = append(out, bp)
+		}
+	}
+	return out
+}
+
+// baggagePropsToOTelProps converts baggage properties to the properties of an
+// OpenTelemetry baggage list item.
+func baggagePropsToOTelProps(props []baggage.Property) []iBaggage.Property {
+	if len(props) == 0 {
+		return nil
+	}
+	out := make([]iBaggage.Property, len(props))
+	for i, p := range props {
+		v, ok := p.Value()
+		out[i] = iBaggage.Property{Key: p.Key(), Value: v, HasValue: ok}
+	}
+	return out
+}
+
 // StartSpan is a part of the implementation of the OpenTracing Tracer
 // interface.
 func (t *BridgeTracer) StartSpan(operationName string, opts ...ot.StartSpanOption) ot.Span {
@@ -649,7 +713,11 @@ func (s fakeSpan) SpanContext() trace.SpanContext {
 // Inject is a part of the implementation of the OpenTracing Tracer
 // interface.
 //
-// Currently only the HTTPHeaders and TextMap formats are supported.
+// The HTTPHeaders, TextMap, and Binary formats are supported. The HTTPHeaders
+// and TextMap formats are injected with the TextMapPropagator of the
+// BridgeTracer. The Binary format, which requires an io.Writer carrier,
+// encodes the span context in the binary form of the W3C traceparent and the
+// baggage as a W3C baggage-string, including member properties.
 func (t *BridgeTracer) Inject(sm ot.SpanContext, format interface{}, carrier interface{}) error {
 	bridgeSC, ok := sm.(*bridgeSpanContext)
 	if !ok {
@@ -678,6 +746,12 @@ func (t *BridgeTracer) Inject(sm ot.SpanContext, format interface{}, carrier int
 		if textCarrier, ok = carrier.(propagation.TextMapCarrier); !ok {
 			textCarrier, err = newTextMapWrapperForInject(carrier)
 		}
+	case ot.Binary:
+		w, ok := carrier.(io.Writer)
+		if !ok {
+			return ot.ErrInvalidCarrier
+		}
+		return marshalBinary(w, bridgeSC)
 	default:
 		err = ot.ErrUnsupportedFormat
 	}
@@ -698,7 +772,8 @@ func (t *BridgeTracer) Inject(sm ot.SpanContext, format interface{}, carrier int
 // Extract is a part of the implementation of the OpenTracing Tracer
 // interface.
 //
-// Currently only the HTTPHeaders and TextMap formats are supported.
+// The HTTPHeaders, TextMap, and Binary formats are supported. The Binary
+// format requires an io.Reader carrier. See Inject for more information.
 func (t *BridgeTracer) Extract(format interface{}, carrier interface{}) (ot.SpanContext, error) {
 	builtinFormat, ok := format.(ot.BuiltinFormat)
 	if !ok {
@@ -719,6 +794,12 @@ func (t *BridgeTracer) Extract(format interface{}, carrier interface{}) (ot.Span
 		if textCarrier, ok = carrier.(propagation.TextMapCarrier); !ok {
 			textCarrier, err = newTextMapWrapperForExtract(carrier)
 		}
+	case ot.Binary:
+		r, ok := carrier.(io.Reader)
+		if !ok {
+			return nil, ot.ErrInvalidCarrier
+		}
+		return unmarshalBinary(r)
 	default:
 		err = ot.ErrUnsupportedFormat
 	}
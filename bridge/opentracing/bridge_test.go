@@ -15,6 +15,7 @@
 package opentracing
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -22,14 +23,17 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	ot "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/bridge/opentracing/internal"
 	"github.com/middleware-labs/otel/propagation"
 	"github.com/middleware-labs/otel/trace"
@@ -336,15 +340,29 @@ func TestBridgeTracer_ExtractAndInject(t *testing.T) {
 			extractErr:         ot.ErrInvalidCarrier,
 		},
 		{
-			name:              "inject: unsupported format type",
+			name:              "inject: format type is Binary, but carrier is not io.Writer",
 			injectCarrierType: ot.Binary,
+			injectCarrier:     struct{}{},
+			injectErr:         ot.ErrInvalidCarrier,
+		},
+		{
+			name:               "extract: format type is Binary, but carrier is not io.Reader",
+			injectCarrierType:  ot.Binary,
+			injectCarrier:      new(bytes.Buffer),
+			extractCarrierType: ot.Binary,
+			extractCarrier:     struct{}{},
+			extractErr:         ot.ErrInvalidCarrier,
+		},
+		{
+			name:              "inject: unsupported format type",
+			injectCarrierType: ot.BuiltinFormat(255),
 			injectErr:         ot.ErrUnsupportedFormat,
 		},
 		{
 			name:               "extract: unsupported format type",
 			injectCarrierType:  ot.TextMap,
 			injectCarrier:      otTextMap,
-			extractCarrierType: ot.Binary,
+			extractCarrierType: ot.BuiltinFormat(255),
 			extractCarrier:     struct{}{},
 			extractErr:         ot.ErrUnsupportedFormat,
 		},
@@ -378,6 +396,76 @@ func TestBridgeTracer_ExtractAndInject(t *testing.T) {
 	}
 }
 
+func TestBridgeTracer_Binary(t *testing.T) {
+	bridge := NewBridgeTracer()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    [16]byte{1},
+		SpanID:     [8]byte{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	prop, err := baggage.NewKeyValueProperty("p", "v")
+	require.NoError(t, err)
+	m, err := baggage.NewMember("K", "val", prop)
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+	bsc := &bridgeSpanContext{bag: bag, otelSpanContext: sc}
+
+	var buf bytes.Buffer
+	require.NoError(t, bridge.Inject(bsc, ot.Binary, &buf))
+	got, err := bridge.Extract(ot.Binary, &buf)
+	require.NoError(t, err)
+
+	gotBSC, ok := got.(*bridgeSpanContext)
+	require.True(t, ok)
+	assert.Equal(t, sc.WithRemote(true), gotBSC.otelSpanContext)
+	assert.Equal(t, bag, gotBSC.bag)
+}
+
+func TestBridgeTracer_ExtractBinaryErrors(t *testing.T) {
+	bridge := NewBridgeTracer()
+
+	var valid bytes.Buffer
+	require.NoError(t, bridge.Inject(newBridgeSpanContext(trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: [16]byte{1},
+		SpanID:  [8]byte{2},
+	}), nil), ot.Binary, &valid))
+
+	testCases := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "empty",
+			err:  ot.ErrSpanContextNotFound,
+		},
+		{
+			name: "truncated",
+			data: valid.Bytes()[:valid.Len()-2],
+			err:  ot.ErrSpanContextCorrupted,
+		},
+		{
+			name: "length too large",
+			data: []byte{0xff, 0xff, 0xff, 0xff},
+			err:  ot.ErrSpanContextCorrupted,
+		},
+		{
+			name: "invalid span context",
+			data: []byte{0, 0, 0, 1, 0, 0, 0, 0, 0},
+			err:  ot.ErrSpanContextCorrupted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := bridge.Extract(ot.Binary, bytes.NewReader(tc.data))
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
 type nonDeferWrapperTracer struct {
 	*WrapperTracer
 }
@@ -546,3 +634,65 @@ func TestBridge_SpanContext_IsSampled(t *testing.T) {
 		})
 	}
 }
+
+func TestBridgeSpan_BaggageItemRoundTrip(t *testing.T) {
+	b, _ := NewTracerPair(internal.NewMockTracer())
+
+	parent := b.StartSpan("parent")
+	value := "a value; with=special,chars%2F"
+	parent.SetBaggageItem("key", value)
+	assert.Equal(t, value, parent.BaggageItem("key"))
+
+	// Properties of members set through OpenTelemetry are kept when the
+	// value is updated through OpenTracing and in child spans.
+	bsc := parent.Context().(*bridgeSpanContext)
+	prop, err := baggage.NewKeyProperty("prop")
+	require.NoError(t, err)
+	m, err := baggage.NewMember("Other", "1", prop)
+	require.NoError(t, err)
+	bsc.bag, err = bsc.bag.SetMember(m)
+	require.NoError(t, err)
+	parent.SetBaggageItem("other", "2")
+
+	child := b.StartSpan("child", ot.ChildOf(parent.Context()))
+	assert.Equal(t, value, child.BaggageItem("key"))
+	got := child.Context().(*bridgeSpanContext).bag.Member("Other")
+	assert.Equal(t, "2", got.Value())
+	assert.Equal(t, []baggage.Property{prop}, got.Properties())
+}
+
+func TestBridgeSpan_LogRecords(t *testing.T) {
+	b, _ := NewTracerPair(internal.NewMockTracer())
+	ts := time.Unix(1, 0)
+	finish := time.Unix(2, 0)
+
+	span := b.StartSpan("test")
+	span.SetOperationName("renamed")
+	span.LogKV("event", "named", "k", 1)
+	span.Log(ot.LogData{Timestamp: ts, Event: "legacy"})
+	span.FinishWithOptions(ot.FinishOptions{
+		FinishTime: finish,
+		LogRecords: []ot.LogRecord{{
+			Timestamp: ts,
+			Fields:    []otlog.Field{otlog.String("k", "v")},
+		}},
+	})
+
+	mock := span.(*bridgeSpan).otelSpan.(*internal.MockSpan)
+	assert.Contains(t, mock.Attributes, internal.NameKey.String("renamed"))
+	assert.Equal(t, finish, mock.EndTime)
+	require.Len(t, mock.Events, 3)
+
+	assert.Equal(t, "named", mock.Events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("event", "named"),
+		attribute.Int64("k", 1),
+	}, mock.Events[0].Attributes)
+
+	assert.Equal(t, "legacy", mock.Events[1].Name)
+	assert.Equal(t, ts, mock.Events[1].Timestamp)
+
+	assert.Equal(t, "log", mock.Events[2].Name)
+	assert.Equal(t, ts, mock.Events[2].Timestamp)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("k", "v")}, mock.Events[2].Attributes)
+}
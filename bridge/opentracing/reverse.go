@@ -0,0 +1,536 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing // import "github.com/middleware-labs/otel/bridge/opentracing"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+	otext "github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/bridge/opentracing/migration"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/propagation"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+const (
+	// statusCodeTag is the tag of the status code of a span.
+	statusCodeTag = "otel.status_code"
+	// statusDescriptionTag is the tag of the status description of a span.
+	statusDescriptionTag = "otel.status_description"
+
+	// uberTraceIDKey is the carrier key used by Jaeger clients.
+	uberTraceIDKey = "uber-trace-id"
+)
+
+// ReverseTracerProvider is an OpenTelemetry TracerProvider that forwards the
+// spans created with the OpenTelemetry API to an OpenTracing Tracer. It is
+// the reverse of the BridgeTracer: it allows instrumenting with OpenTelemetry
+// while an OpenTracing tracer (i.e. a Jaeger client) still records the
+// spans.
+//
+// The OpenTelemetry API is mapped to the OpenTracing API as follows:
+//
+//	OpenTelemetry                 | OpenTracing
+//	------------------------------+----------------------------------------------
+//	span name, SetName            | operation name, SetOperationName
+//	start and end timestamps      | StartTime, FinishOptions.FinishTime
+//	parent span                   | ChildOf reference
+//	links                         | FollowsFrom references (see below)
+//	span kind                     | span.kind tag (internal spans have no tag)
+//	attributes                    | tags
+//	instrumentation scope         | otel.scope.name and otel.scope.version tags
+//	events                        | log records, the name is the "event" field
+//	RecordError                   | "error" log record with the error.kind,
+//	                              | error.object, and message fields
+//	Error status                  | error=true, otel.status_code=ERROR, and
+//	                              | otel.status_description tags
+//	Ok status                     | error=false and otel.status_code=OK tags
+//
+// Events are buffered and passed to the OpenTracing span when it finishes so
+// their timestamps are kept.
+//
+// OpenTracing span contexts are opaque. The SpanContext of a span, and the
+// OpenTracing span context of remote parents and links, are converted using
+// the W3C traceparent or the Jaeger uber-trace-id formats of the
+// OpenTracing tracer TextMap Inject and Extract methods. If the tracer
+// supports neither, spans have an invalid SpanContext and links are recorded
+// as "link" log records, with the trace_id and span_id fields, instead of
+// FollowsFrom references. Link attributes are not forwarded. Use Propagator
+// to propagate span contexts in the native format of the tracer.
+type ReverseTracerProvider struct {
+	tracer ot.Tracer
+
+	tracers map[wrappedTracerKey]*reverseTracer
+	mtx     sync.Mutex
+}
+
+var _ trace.TracerProvider = (*ReverseTracerProvider)(nil)
+
+// NewReverseTracerProvider returns a new ReverseTracerProvider that forwards
+// spans to tracer.
+func NewReverseTracerProvider(tracer ot.Tracer) *ReverseTracerProvider {
+	return &ReverseTracerProvider{
+		tracer:  tracer,
+		tracers: make(map[wrappedTracerKey]*reverseTracer),
+	}
+}
+
+// Tracer returns a Tracer that forwards the spans it creates to the
+// OpenTracing tracer of p. Repeated calls to Tracer() with the same
+// configuration will return the same Tracer.
+func (p *ReverseTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	c := trace.NewTracerConfig(opts...)
+	key := wrappedTracerKey{
+		name:    name,
+		version: c.InstrumentationVersion(),
+	}
+
+	if t, ok := p.tracers[key]; ok {
+		return t
+	}
+
+	t := &reverseTracer{provider: p, name: key.name, version: key.version}
+	p.tracers[key] = t
+	return t
+}
+
+// Propagator returns a TextMapPropagator that injects and extracts span
+// contexts with the TextMap format of the OpenTracing tracer of p.
+//
+// Span contexts extracted by the propagator are used as the parent of spans
+// started by the Tracers of p.
+func (p *ReverseTracerProvider) Propagator() propagation.TextMapPropagator {
+	return reversePropagator{provider: p}
+}
+
+// otelSpanContext returns the OpenTelemetry SpanContext of sc. An invalid
+// SpanContext is returned if the tracer does not use the W3C traceparent or
+// Jaeger uber-trace-id formats.
+func (p *ReverseTracerProvider) otelSpanContext(sc ot.SpanContext) trace.SpanContext {
+	carrier := propagation.MapCarrier{}
+	if err := p.tracer.Inject(sc, ot.TextMap, ot.TextMapCarrier(carrier)); err != nil {
+		return trace.SpanContext{}
+	}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	if otelSC := trace.SpanContextFromContext(ctx); otelSC.IsValid() {
+		return otelSC.WithRemote(false)
+	}
+	return parseUberTraceID(carrier)
+}
+
+// otSpanContext returns the OpenTracing span context of sc, if the tracer
+// uses the W3C traceparent or Jaeger uber-trace-id formats.
+func (p *ReverseTracerProvider) otSpanContext(sc trace.SpanContext) (ot.SpanContext, bool) {
+	if !sc.IsValid() {
+		return nil, false
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	carrier.Set(uberTraceIDKey, formatUberTraceID(sc))
+	otSC, err := p.tracer.Extract(ot.TextMap, ot.TextMapCarrier(carrier))
+	if err != nil {
+		return nil, false
+	}
+	return otSC, true
+}
+
+// parseUberTraceID returns the SpanContext encoded in the Jaeger
+// uber-trace-id format ({trace-id}:{span-id}:{parent-span-id}:{flags}) of
+// carrier, if any.
+func parseUberTraceID(carrier propagation.MapCarrier) trace.SpanContext {
+	parts := strings.Split(carrier.Get(uberTraceIDKey), ":")
+	if len(parts) != 4 {
+		return trace.SpanContext{}
+	}
+	var scc trace.SpanContextConfig
+	if !decodePaddedHex(parts[0], scc.TraceID[:]) || !decodePaddedHex(parts[1], scc.SpanID[:]) {
+		return trace.SpanContext{}
+	}
+	var flags [1]byte
+	if !decodePaddedHex(parts[3], flags[:]) {
+		return trace.SpanContext{}
+	}
+	scc.TraceFlags = trace.TraceFlags(flags[0]) & trace.FlagsSampled
+	return trace.NewSpanContext(scc)
+}
+
+func formatUberTraceID(sc trace.SpanContext) string {
+	return fmt.Sprintf("%s:%s:0:%x", sc.TraceID(), sc.SpanID(), byte(sc.TraceFlags()&trace.FlagsSampled))
+}
+
+// decodePaddedHex decodes s, a hex number with leading zeros possibly
+// omitted, into dst.
+func decodePaddedHex(s string, dst []byte) bool {
+	if len(s) == 0 || len(s) > 2*len(dst) {
+		return false
+	}
+	s = strings.Repeat("0", 2*len(dst)-len(s)) + s
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+type reverseTracer struct {
+	provider *ReverseTracerProvider
+
+	name, version string
+}
+
+var _ trace.Tracer = (*reverseTracer)(nil)
+var _ migration.DeferredContextSetupTracerExtension = (*reverseTracer)(nil)
+
+// Start starts an OpenTracing span. The returned context holds the started
+// span both as the OpenTelemetry and the active OpenTracing span.
+func (t *reverseTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+
+	var sso []ot.StartSpanOption
+	if !config.NewRoot() {
+		if parent := t.parent(ctx); parent != nil {
+			sso = append(sso, ot.ChildOf(parent))
+		}
+	}
+	start := config.Timestamp()
+	if start.IsZero() {
+		start = time.Now()
+	}
+	sso = append(sso, ot.StartTime(start))
+
+	tags := ot.Tags{}
+	for _, kv := range config.Attributes() {
+		tags[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	if kind := otelSpanKindToOT(config.SpanKind()); kind != "" {
+		tags[string(otext.SpanKind)] = kind
+	}
+	if t.name != "" {
+		tags[string(semconv.OtelScopeNameKey)] = t.name
+	}
+	if t.version != "" {
+		tags[string(semconv.OtelScopeVersionKey)] = t.version
+	}
+	sso = append(sso, tags)
+
+	var unresolved []trace.Link
+	for _, link := range config.Links() {
+		if !link.SpanContext.IsValid() {
+			continue
+		}
+		if otSC, ok := t.provider.otSpanContext(link.SpanContext); ok {
+			sso = append(sso, ot.FollowsFrom(otSC))
+		} else {
+			unresolved = append(unresolved, link)
+		}
+	}
+
+	s := &reverseSpan{
+		tracer: t,
+		otSpan: t.provider.tracer.StartSpan(name, sso...),
+	}
+	s.sc = t.provider.otelSpanContext(s.otSpan.Context())
+	for _, link := range unresolved {
+		s.logs = append(s.logs, ot.LogRecord{
+			Timestamp: start,
+			Fields: []otlog.Field{
+				otlog.String("event", "link"),
+				otlog.String("trace_id", link.SpanContext.TraceID().String()),
+				otlog.String("span_id", link.SpanContext.SpanID().String()),
+			},
+		})
+	}
+
+	if migration.SkipContextSetup(ctx) {
+		return ctx, s
+	}
+	return t.DeferredContextSetupHook(ctx, s), s
+}
+
+// DeferredContextSetupHook sets span as the OpenTelemetry span of ctx, and
+// its OpenTracing span as the active OpenTracing span.
+//
+// This allows the ReverseTracerProvider to be the OpenTelemetry tracer of a
+// BridgeTracer: OpenTracing instrumentation is then forwarded, through the
+// OpenTelemetry API, back to an OpenTracing tracer.
+func (t *reverseTracer) DeferredContextSetupHook(ctx context.Context, span trace.Span) context.Context {
+	if s, ok := span.(*reverseSpan); ok {
+		// The OpenTracing span is set first, the OpenTracing tracer may set
+		// an OpenTelemetry span in the context (i.e. the BridgeTracer).
+		ctx = ot.ContextWithSpan(ctx, s.otSpan)
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
+
+// parent returns the OpenTracing span context of the parent span held by
+// ctx, or nil if there is none.
+func (t *reverseTracer) parent(ctx context.Context) ot.SpanContext {
+	if s, ok := trace.SpanFromContext(ctx).(*reverseSpan); ok && s.tracer.provider == t.provider {
+		return s.otSpan.Context()
+	}
+	if sc, ok := ctx.Value(remoteSpanContextKey{}).(remoteSpanContext); ok && sc.provider == t.provider {
+		return sc.sc
+	}
+	if s := ot.SpanFromContext(ctx); s != nil {
+		return s.Context()
+	}
+	if sc, ok := t.provider.otSpanContext(trace.SpanContextFromContext(ctx)); ok {
+		return sc
+	}
+	return nil
+}
+
+func otelSpanKindToOT(kind trace.SpanKind) otext.SpanKindEnum {
+	switch kind {
+	case trace.SpanKindClient:
+		return otext.SpanKindRPCClientEnum
+	case trace.SpanKindServer:
+		return otext.SpanKindRPCServerEnum
+	case trace.SpanKindProducer:
+		return otext.SpanKindProducerEnum
+	case trace.SpanKindConsumer:
+		return otext.SpanKindConsumerEnum
+	}
+	return ""
+}
+
+// reverseSpan is an OpenTelemetry span forwarding its operations to an
+// OpenTracing span.
+type reverseSpan struct {
+	tracer *reverseTracer
+	otSpan ot.Span
+	sc     trace.SpanContext
+
+	mu     sync.Mutex
+	ended  bool
+	status codes.Code
+	logs   []ot.LogRecord
+}
+
+var _ trace.Span = (*reverseSpan)(nil)
+
+func (s *reverseSpan) End(options ...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	config := trace.NewSpanEndConfig(options...)
+	s.otSpan.FinishWithOptions(ot.FinishOptions{
+		FinishTime: config.Timestamp(),
+		LogRecords: s.logs,
+	})
+	s.logs = nil
+}
+
+func (s *reverseSpan) AddEvent(name string, options ...trace.EventOption) {
+	config := trace.NewEventConfig(options...)
+	fields := make([]otlog.Field, 0, len(config.Attributes())+1)
+	fields = append(fields, otlog.String("event", name))
+	fields = append(fields, otelAttrsToOTLogFields(config.Attributes())...)
+	s.log(config.Timestamp(), fields)
+}
+
+func (s *reverseSpan) log(ts time.Time, fields []otlog.Field) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.logs = append(s.logs, ot.LogRecord{Timestamp: ts, Fields: fields})
+}
+
+func (s *reverseSpan) IsRecording() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.ended
+}
+
+// RecordError records err as an "error" log record, following the
+// OpenTracing semantic conventions. Like for other OpenTelemetry spans, the
+// status of the span is not changed.
+func (s *reverseSpan) RecordError(err error, options ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+	config := trace.NewEventConfig(options...)
+	fields := []otlog.Field{
+		otlog.String("event", "error"),
+		otlog.String("error.kind", reflect.TypeOf(err).String()),
+		otlog.Error(err),
+		otlog.String("message", err.Error()),
+	}
+	fields = append(fields, otelAttrsToOTLogFields(config.Attributes())...)
+	s.log(config.Timestamp(), fields)
+}
+
+func (s *reverseSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+// SetStatus sets the status of the span with the error, otel.status_code,
+// and otel.status_description tags. Like for other OpenTelemetry spans, an Ok
+// status is final.
+func (s *reverseSpan) SetStatus(code codes.Code, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended || s.status == codes.Ok || code == codes.Unset {
+		return
+	}
+	s.status = code
+	switch code {
+	case codes.Error:
+		otext.Error.Set(s.otSpan, true)
+		s.otSpan.SetTag(statusCodeTag, "ERROR")
+		if description != "" {
+			s.otSpan.SetTag(statusDescriptionTag, description)
+		}
+	case codes.Ok:
+		otext.Error.Set(s.otSpan, false)
+		s.otSpan.SetTag(statusCodeTag, "OK")
+	}
+}
+
+func (s *reverseSpan) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.otSpan.SetOperationName(name)
+	}
+}
+
+func (s *reverseSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	for _, a := range kv {
+		s.otSpan.SetTag(string(a.Key), a.Value.AsInterface())
+	}
+}
+
+func (s *reverseSpan) TracerProvider() trace.TracerProvider {
+	return s.tracer.provider
+}
+
+func otelAttrsToOTLogFields(attrs []attribute.KeyValue) []otlog.Field {
+	fields := make([]otlog.Field, len(attrs))
+	for i, kv := range attrs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			fields[i] = otlog.Bool(key, kv.Value.AsBool())
+		case attribute.INT64:
+			fields[i] = otlog.Int64(key, kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			fields[i] = otlog.Float64(key, kv.Value.AsFloat64())
+		case attribute.STRING:
+			fields[i] = otlog.String(key, kv.Value.AsString())
+		default:
+			fields[i] = otlog.Object(key, kv.Value.AsInterface())
+		}
+	}
+	return fields
+}
+
+type remoteSpanContextKey struct{}
+
+// remoteSpanContext is an OpenTracing span context extracted by the
+// propagator of a ReverseTracerProvider.
+type remoteSpanContext struct {
+	provider *ReverseTracerProvider
+	sc       ot.SpanContext
+}
+
+// reversePropagator propagates span contexts with the TextMap format of the
+// OpenTracing tracer of a ReverseTracerProvider.
+type reversePropagator struct {
+	provider *ReverseTracerProvider
+}
+
+var _ propagation.TextMapPropagator = reversePropagator{}
+
+// Inject injects the OpenTracing span context of the span held by ctx. Only
+// spans started by the ReverseTracerProvider, or OpenTracing spans, are
+// injected.
+func (p reversePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	var sc ot.SpanContext
+	if s, ok := trace.SpanFromContext(ctx).(*reverseSpan); ok {
+		sc = s.otSpan.Context()
+	} else if s := ot.SpanFromContext(ctx); s != nil {
+		sc = s.Context()
+	} else {
+		return
+	}
+	_ = p.provider.tracer.Inject(sc, ot.TextMap, textMapCarrierWriter{carrier})
+}
+
+// Extract returns a copy of ctx holding the extracted span context. The
+// remote OpenTelemetry SpanContext is also set if it can be converted.
+func (p reversePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	sc, err := p.provider.tracer.Extract(ot.TextMap, textMapCarrierReader{carrier})
+	if err != nil || sc == nil {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, remoteSpanContextKey{}, remoteSpanContext{provider: p.provider, sc: sc})
+	if otelSC := p.provider.otelSpanContext(sc); otelSC.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, otelSC)
+	}
+	return ctx
+}
+
+// Fields returns nil, the fields used by the OpenTracing tracer are unknown.
+func (p reversePropagator) Fields() []string {
+	return nil
+}
+
+// textMapCarrierWriter adapts a propagation.TextMapCarrier to the
+// OpenTracing TextMapWriter interface.
+type textMapCarrierWriter struct {
+	propagation.TextMapCarrier
+}
+
+func (c textMapCarrierWriter) Set(key, val string) {
+	c.TextMapCarrier.Set(key, val)
+}
+
+// textMapCarrierReader adapts a propagation.TextMapCarrier to the
+// OpenTracing TextMapReader interface.
+type textMapCarrierReader struct {
+	propagation.TextMapCarrier
+}
+
+func (c textMapCarrierReader) ForeachKey(handler func(key, val string) error) error {
+	for _, k := range c.Keys() {
+		if err := handler(k, c.Get(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
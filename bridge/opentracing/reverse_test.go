@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/propagation"
+	"github.com/middleware-labs/otel/trace"
+)
+
+func mockLogFields(rec mocktracer.MockLogRecord) map[string]string {
+	fields := make(map[string]string, len(rec.Fields))
+	for _, f := range rec.Fields {
+		fields[f.Key] = f.ValueString
+	}
+	return fields
+}
+
+func TestReverseTracerProvider(t *testing.T) {
+	mock := mocktracer.New()
+	tp := NewReverseTracerProvider(mock)
+	tracer := tp.Tracer("scope", trace.WithInstrumentationVersion("v1"))
+	assert.Same(t, tracer, tp.Tracer("scope", trace.WithInstrumentationVersion("v1")))
+
+	start, end := time.Unix(10, 0), time.Unix(20, 0)
+	link := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+
+	ctx, parent := tracer.Start(
+		context.Background(),
+		"parent",
+		trace.WithTimestamp(start),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("k", "v"), attribute.Int("i", 1)),
+		trace.WithLinks(trace.Link{SpanContext: link}),
+	)
+	assert.True(t, parent.IsRecording())
+	assert.Same(t, tp, parent.TracerProvider())
+	// The mock tracer uses neither the W3C nor the Jaeger format.
+	assert.False(t, parent.SpanContext().IsValid())
+	require.NotNil(t, ot.SpanFromContext(ctx), "OpenTracing span not active")
+
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	parent.SetName("renamed")
+	parent.SetAttributes(attribute.Bool("b", true))
+	parent.AddEvent("event", trace.WithTimestamp(start.Add(time.Second)), trace.WithAttributes(attribute.Int64("n", 2)))
+	parent.RecordError(errors.New("failure"))
+	parent.SetStatus(codes.Error, "desc")
+	parent.End(trace.WithTimestamp(end))
+	assert.False(t, parent.IsRecording())
+
+	// Operations after the span ended are dropped.
+	parent.SetAttributes(attribute.Bool("late", true))
+	parent.End()
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 2)
+	c, p := spans[0], spans[1]
+
+	assert.Equal(t, "child", c.OperationName)
+	assert.Equal(t, p.SpanContext.SpanID, c.ParentID)
+	assert.Equal(t, p.SpanContext.TraceID, c.SpanContext.TraceID)
+
+	assert.Equal(t, "renamed", p.OperationName)
+	assert.Equal(t, start, p.StartTime)
+	assert.Equal(t, end, p.FinishTime)
+	assert.Equal(t, map[string]interface{}{
+		"k":                       "v",
+		"i":                       int64(1),
+		"b":                       true,
+		"span.kind":               ext.SpanKindRPCServerEnum,
+		"otel.scope.name":         "scope",
+		"otel.scope.version":      "v1",
+		"error":                   true,
+		"otel.status_code":        "ERROR",
+		"otel.status_description": "desc",
+	}, p.Tags())
+
+	logs := p.Logs()
+	require.Len(t, logs, 3)
+	assert.Equal(t, start, logs[0].Timestamp)
+	assert.Equal(t, map[string]string{
+		"event":    "link",
+		"trace_id": link.TraceID().String(),
+		"span_id":  link.SpanID().String(),
+	}, mockLogFields(logs[0]))
+	assert.Equal(t, start.Add(time.Second), logs[1].Timestamp)
+	assert.Equal(t, map[string]string{"event": "event", "n": "2"}, mockLogFields(logs[1]))
+	assert.Equal(t, map[string]string{
+		"event":        "error",
+		"error.kind":   "*errors.errorString",
+		"error.object": "failure",
+		"message":      "failure",
+	}, mockLogFields(logs[2]))
+}
+
+func TestReverseSpanStatus(t *testing.T) {
+	mock := mocktracer.New()
+	tracer := NewReverseTracerProvider(mock).Tracer("")
+
+	_, s := tracer.Start(context.Background(), "span")
+	s.SetStatus(codes.Unset, "ignored")
+	s.SetStatus(codes.Ok, "")
+	s.SetStatus(codes.Error, "ignored after Ok")
+	s.End()
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, map[string]interface{}{
+		"error":            false,
+		"otel.status_code": "OK",
+	}, spans[0].Tags())
+}
+
+func TestReverseTracerProviderNewRoot(t *testing.T) {
+	mock := mocktracer.New()
+	tracer := NewReverseTracerProvider(mock).Tracer("")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, root := tracer.Start(ctx, "root", trace.WithNewRoot())
+	root.End()
+	parent.End()
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, 0, spans[0].ParentID)
+}
+
+func TestReverseTracerProviderPropagator(t *testing.T) {
+	mock := mocktracer.New()
+	tp := NewReverseTracerProvider(mock)
+	tracer := tp.Tracer("")
+	prop := tp.Propagator()
+
+	ctx, client := tracer.Start(context.Background(), "client")
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+	client.End()
+	assert.NotEmpty(t, carrier.Keys())
+
+	ctx = prop.Extract(context.Background(), carrier)
+	_, server := tracer.Start(ctx, "server")
+	server.End()
+
+	spans := mock.FinishedSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[0].SpanContext.SpanID, spans[1].ParentID)
+	assert.Equal(t, spans[0].SpanContext.TraceID, spans[1].SpanContext.TraceID)
+
+	// Nothing is injected without an active span.
+	carrier = propagation.MapCarrier{}
+	prop.Inject(context.Background(), carrier)
+	assert.Empty(t, carrier.Keys())
+}
+
+func TestUberTraceID(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{15: 1},
+		SpanID:     trace.SpanID{7: 2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	carrier := propagation.MapCarrier{uberTraceIDKey: formatUberTraceID(sc)}
+	assert.Equal(t, sc, parseUberTraceID(carrier))
+
+	for _, v := range []string{
+		"",
+		"1:2:0",
+		"x:2:0:1",
+		"1:2:0:x",
+		"000000000000000000000000000000001:2:0:1",
+	} {
+		carrier := propagation.MapCarrier{uberTraceIDKey: v}
+		assert.False(t, parseUberTraceID(carrier).IsValid(), v)
+	}
+	// Leading zeros can be omitted.
+	carrier = propagation.MapCarrier{uberTraceIDKey: "1:2:0:1"}
+	assert.Equal(t, sc, parseUberTraceID(carrier))
+}
@@ -8,12 +8,16 @@ replace github.com/middleware-labs/otel/bridge/opentracing => ../
 
 replace github.com/middleware-labs/otel/trace => ../../../trace
 
+replace github.com/middleware-labs/otel/sdk => ../../../sdk
+
 require (
 	github.com/opentracing-contrib/go-grpc v0.0.0-20210225150812-73cb765af46e
 	github.com/opentracing/opentracing-go v1.2.0
 	github.com/stretchr/testify v1.8.2
 	github.com/middleware-labs/otel v1.15.0-rc.2
 	github.com/middleware-labs/otel/bridge/opentracing v1.15.0-rc.2
+	github.com/middleware-labs/otel/sdk v1.15.0-rc.2
+	github.com/middleware-labs/otel/trace v1.15.0-rc.2
 	google.golang.org/grpc v1.54.0
 )
 
@@ -24,7 +28,6 @@ require (
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
-	github.com/middleware-labs/otel/trace v1.15.0-rc.2 // indirect
 	golang.org/x/net v0.8.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	ototel "github.com/middleware-labs/otel/bridge/opentracing"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/propagation"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var epoch = time.Unix(1000, 0)
+
+func at(sec int) time.Time { return epoch.Add(time.Duration(sec) * time.Second) }
+
+// otelScenario creates spans with the OpenTelemetry API.
+func otelScenario(tp trace.TracerProvider) map[string]trace.SpanContext {
+	tracer := tp.Tracer("conformance")
+	ctx, parent := tracer.Start(
+		context.Background(),
+		"parent",
+		trace.WithTimestamp(at(0)),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("k", "v"), attribute.Int("i", 1)),
+	)
+	_, linked := tracer.Start(ctx, "linked", trace.WithTimestamp(at(1)), trace.WithSpanKind(trace.SpanKindProducer))
+	linked.End(trace.WithTimestamp(at(2)))
+
+	_, child := tracer.Start(
+		ctx,
+		"child",
+		trace.WithTimestamp(at(3)),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithLinks(trace.Link{SpanContext: linked.SpanContext()}),
+	)
+	child.AddEvent("event", trace.WithTimestamp(at(4)), trace.WithAttributes(attribute.Int64("n", 1)))
+	child.SetStatus(codes.Error, "")
+	child.End(trace.WithTimestamp(at(5)))
+
+	parent.SetName("renamed")
+	parent.SetAttributes(attribute.Bool("b", true))
+	parent.End(trace.WithTimestamp(at(6)))
+
+	return map[string]trace.SpanContext{
+		"renamed": parent.SpanContext(),
+		"linked":  linked.SpanContext(),
+		"child":   child.SpanContext(),
+	}
+}
+
+type otelEvent struct {
+	Name  string
+	Time  time.Time
+	Attrs map[attribute.Key]attribute.Value
+}
+
+type otelSpan struct {
+	Name, Parent string
+	Kind         trace.SpanKind
+	Start, End   time.Time
+	Attrs        map[attribute.Key]attribute.Value
+	Events       []otelEvent
+	Links        []string
+	Status       codes.Code
+}
+
+// bridgeAttrs are attributes added by the bridges to preserve information
+// OpenTracing and OpenTelemetry do not share.
+var bridgeAttrs = map[attribute.Key]bool{
+	"event":                   true,
+	"otel.scope.name":         true,
+	"otel.scope.version":      true,
+	"otel.status_code":        true,
+	"otel.status_description": true,
+	"ot-span-reference-type":  true,
+}
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range attrs {
+		if !bridgeAttrs[kv.Key] {
+			m[kv.Key] = kv.Value
+		}
+	}
+	return m
+}
+
+// normalizeOTel returns the recorded spans, sorted by name, in a form that
+// does not depend on IDs.
+func normalizeOTel(spans []sdktrace.ReadOnlySpan) []otelSpan {
+	names := make(map[trace.SpanID]string)
+	for _, s := range spans {
+		names[s.SpanContext().SpanID()] = s.Name()
+	}
+	out := make([]otelSpan, len(spans))
+	for i, s := range spans {
+		out[i] = otelSpan{
+			Name:   s.Name(),
+			Parent: names[s.Parent().SpanID()],
+			Kind:   s.SpanKind(),
+			Start:  s.StartTime(),
+			End:    s.EndTime(),
+			Attrs:  attrMap(s.Attributes()),
+			Status: s.Status().Code,
+		}
+		for _, e := range s.Events() {
+			out[i].Events = append(out[i].Events, otelEvent{Name: e.Name, Time: e.Time, Attrs: attrMap(e.Attributes)})
+		}
+		for _, l := range s.Links() {
+			out[i].Links = append(out[i].Links, names[l.SpanContext.SpanID()])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// TestReverseConformanceOTel checks that spans created with the OpenTelemetry
+// API are recorded the same way when they are forwarded to OpenTracing by the
+// ReverseTracerProvider, and back to OpenTelemetry by the BridgeTracer.
+func TestReverseConformanceOTel(t *testing.T) {
+	direct := tracetest.NewSpanRecorder()
+	otelScenario(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(direct)))
+
+	bridged := tracetest.NewSpanRecorder()
+	bridge := ototel.NewBridgeTracer()
+	bridge.SetTextMapPropagator(propagation.TraceContext{})
+	bridge.SetOpenTelemetryTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(bridged)).Tracer("bridge"))
+	scs := otelScenario(ototel.NewReverseTracerProvider(bridge))
+
+	want := normalizeOTel(direct.Ended())
+	require.Len(t, want, 3)
+	assert.Equal(t, want, normalizeOTel(bridged.Ended()))
+
+	// The SpanContext of the spans is the one of the OpenTracing spans.
+	for _, s := range bridged.Ended() {
+		assert.Equal(t, s.SpanContext(), scs[s.Name()], s.Name())
+	}
+}
+
+// otScenario creates spans with the OpenTracing API.
+func otScenario(tracer ot.Tracer) {
+	parent := tracer.StartSpan("parent", ot.StartTime(at(0)), ext.SpanKindRPCServer, ot.Tag{Key: "k", Value: "v"})
+	child := tracer.StartSpan("child", ot.ChildOf(parent.Context()), ot.StartTime(at(1)), ot.Tag{Key: "i", Value: 1})
+	ext.Error.Set(child, true)
+	child.FinishWithOptions(ot.FinishOptions{
+		FinishTime: at(3),
+		LogRecords: []ot.LogRecord{{
+			Timestamp: at(2),
+			Fields:    []otlog.Field{otlog.String("event", "event"), otlog.Int64("n", 1)},
+		}},
+	})
+	parent.SetOperationName("renamed")
+	parent.SetTag("b", true)
+	parent.FinishWithOptions(ot.FinishOptions{FinishTime: at(4)})
+}
+
+type otSpan struct {
+	Name, Parent string
+	Start, End   time.Time
+	Tags         map[string]string
+	Logs         []string
+}
+
+var bridgeTags = map[string]bool{
+	"otel.scope.name":    true,
+	"otel.scope.version": true,
+	"otel.status_code":   true,
+}
+
+// normalizeOT returns the finished mock spans, sorted by name, in a form
+// that does not depend on IDs or value types.
+func normalizeOT(spans []*mocktracer.MockSpan) []otSpan {
+	names := make(map[int]string)
+	for _, s := range spans {
+		names[s.SpanContext.SpanID] = s.OperationName
+	}
+	out := make([]otSpan, len(spans))
+	for i, s := range spans {
+		out[i] = otSpan{
+			Name:   s.OperationName,
+			Parent: names[s.ParentID],
+			Start:  s.StartTime,
+			End:    s.FinishTime,
+			Tags:   make(map[string]string),
+		}
+		for k, v := range s.Tags() {
+			if !bridgeTags[k] {
+				out[i].Tags[k] = fmt.Sprint(v)
+			}
+		}
+		for _, l := range s.Logs() {
+			fields := make(map[string]string)
+			for _, f := range l.Fields {
+				fields[f.Key] = f.ValueString
+			}
+			out[i].Logs = append(out[i].Logs, fmt.Sprint(l.Timestamp.Unix(), fields))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// w3cMock propagates MockSpanContext with the W3C traceparent, like an
+// OpenTracing tracer supporting the W3C Trace Context would.
+type w3cMock struct{}
+
+func (w3cMock) Inject(sc mocktracer.MockSpanContext, carrier interface{}) error {
+	w, ok := carrier.(ot.TextMapWriter)
+	if !ok {
+		return ot.ErrInvalidCarrier
+	}
+	scc := trace.SpanContextConfig{TraceFlags: trace.FlagsSampled}
+	binary.BigEndian.PutUint64(scc.TraceID[8:], uint64(sc.TraceID))
+	binary.BigEndian.PutUint64(scc.SpanID[:], uint64(sc.SpanID))
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(scc))
+	c := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, c)
+	for k, v := range c {
+		w.Set(k, v)
+	}
+	return nil
+}
+
+func (w3cMock) Extract(carrier interface{}) (mocktracer.MockSpanContext, error) {
+	r, ok := carrier.(ot.TextMapReader)
+	if !ok {
+		return mocktracer.MockSpanContext{}, ot.ErrInvalidCarrier
+	}
+	c := propagation.MapCarrier{}
+	_ = r.ForeachKey(func(k, v string) error {
+		c.Set(strings.ToLower(k), v)
+		return nil
+	})
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), c))
+	if !sc.IsValid() {
+		return mocktracer.MockSpanContext{}, ot.ErrSpanContextNotFound
+	}
+	tid, sid := sc.TraceID(), sc.SpanID()
+	return mocktracer.MockSpanContext{
+		TraceID: int(binary.BigEndian.Uint64(tid[8:])),
+		SpanID:  int(binary.BigEndian.Uint64(sid[:])),
+		Sampled: sc.IsSampled(),
+	}, nil
+}
+
+// TestReverseConformanceOT checks that spans created with the OpenTracing API
+// are recorded the same way when they are forwarded to OpenTelemetry by the
+// BridgeTracer, and back to OpenTracing by the ReverseTracerProvider.
+func TestReverseConformanceOT(t *testing.T) {
+	direct := mocktracer.New()
+	otScenario(direct)
+
+	mock := mocktracer.New()
+	mock.RegisterInjector(ot.TextMap, w3cMock{})
+	mock.RegisterExtractor(ot.TextMap, w3cMock{})
+	var warnings []string
+	bridge := ototel.NewBridgeTracer()
+	bridge.SetWarningHandler(func(msg string) { warnings = append(warnings, msg) })
+	bridge.SetOpenTelemetryTracer(ototel.NewReverseTracerProvider(mock).Tracer("bridge"))
+	otScenario(bridge)
+
+	want := normalizeOT(direct.FinishedSpans())
+	require.Len(t, want, 2)
+	assert.Equal(t, want, normalizeOT(mock.FinishedSpans()))
+	assert.Empty(t, warnings)
+}
@@ -21,6 +21,7 @@ require (
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.7.0 // indirect
+	github.com/klauspost/compress v1.16.7 // indirect
 	github.com/middleware-labs/otel/exporters/otlp/internal/retry v1.15.0-rc.2 // indirect
 	github.com/middleware-labs/otel/exporters/otlp/otlptrace v1.15.0-rc.2 // indirect
 	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
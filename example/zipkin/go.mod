@@ -16,11 +16,13 @@ require (
 )
 
 require (
+	github.com/cenkalti/backoff/v4 v4.2.0 // indirect
 	github.com/go-logr/logr v1.2.4 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
-	github.com/openzipkin/zipkin-go v0.4.1 // indirect
 	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
+	github.com/openzipkin/zipkin-go v0.4.1 // indirect
 	golang.org/x/sys v0.7.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
 )
 
 replace github.com/middleware-labs/otel/trace => ../../trace
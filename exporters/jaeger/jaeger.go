@@ -213,13 +213,15 @@ func spanToThrift(ss sdktrace.ReadOnlySpan) *gen.Span {
 	tid := ss.SpanContext().TraceID()
 	sid := ss.SpanContext().SpanID()
 	psid := ss.Parent().SpanID()
+	// Only the sampled flag has the same meaning in Jaeger. The W3C random
+	// flag (0x02) is the Jaeger debug flag and must not be forwarded.
 	return &gen.Span{
 		TraceIdHigh:   int64(binary.BigEndian.Uint64(tid[0:8])),
 		TraceIdLow:    int64(binary.BigEndian.Uint64(tid[8:16])),
 		SpanId:        int64(binary.BigEndian.Uint64(sid[:])),
 		ParentSpanId:  int64(binary.BigEndian.Uint64(psid[:])),
 		OperationName: ss.Name(), // TODO: if span kind is added then add prefix "Sent"/"Recv"
-		Flags:         int32(ss.SpanContext().TraceFlags()),
+		Flags:         int32(ss.SpanContext().TraceFlags() & trace.FlagsSampled),
 		StartTime:     ss.StartTime().UnixNano() / 1000,
 		Duration:      ss.EndTime().Sub(ss.StartTime()).Nanoseconds() / 1000,
 		Tags:          tags,
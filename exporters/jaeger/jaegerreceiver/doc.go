@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaegerreceiver converts spans received in the Jaeger Thrift format
+// into OpenTelemetry spans and forwards them to a SpanExporter.
+//
+// It is intended to ease the migration of applications instrumented with a
+// Jaeger client. Use NewHandler to receive batches POSTed to the collector
+// HTTP endpoint (/api/traces) and ListenUDP to receive the emitBatch packets
+// sent to a Jaeger agent.
+//
+// The conversion is the reverse of the one applied by the Jaeger exporter.
+// Slice attributes are sent by the exporter as JSON strings and are received
+// as string attributes.
+//
+// Concurrent requests and packets are exported one at a time with a given
+// exporter, which can be shared by handlers and listeners. Different exporters
+// do not wait for each other.
+package jaegerreceiver // import "github.com/middleware-labs/otel/exporters/jaeger/jaegerreceiver"
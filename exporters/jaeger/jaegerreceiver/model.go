@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver // import "github.com/middleware-labs/otel/exporters/jaeger/jaegerreceiver"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	genAgent "github.com/middleware-labs/otel/exporters/jaeger/internal/gen-go/agent"
+	gen "github.com/middleware-labs/otel/exporters/jaeger/internal/gen-go/jaeger"
+	"github.com/middleware-labs/otel/exporters/jaeger/internal/third_party/thrift/lib/go/thrift"
+	"github.com/middleware-labs/otel/sdk/resource"
+	tracesdk "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+const (
+	keyInstrumentationLibraryName    = "otel.library.name"
+	keyInstrumentationLibraryVersion = "otel.library.version"
+	keyError                         = "error"
+	keySpanKind                      = "span.kind"
+	keyStatusCode                    = "otel.status_code"
+	keyStatusMessage                 = "otel.status_description"
+	keyDroppedAttributeCount         = "otel.event.dropped_attributes_count"
+	keyEventName                     = "event"
+
+	emitBatchMethod = "emitBatch"
+)
+
+var errNotEmitBatch = errors.New("not an emitBatch message")
+
+// ParseBatch decodes a Jaeger Batch encoded with the Thrift binary protocol,
+// as sent to the collector HTTP endpoint, into SpanStubs.
+func ParseBatch(data []byte) (tracetest.SpanStubs, error) {
+	ctx := context.Background()
+	buf := thrift.NewTMemoryBuffer()
+	if _, err := buf.Write(data); err != nil {
+		return nil, err
+	}
+	batch := gen.NewBatch()
+	if err := batch.Read(ctx, thrift.NewTBinaryProtocolConf(buf, &thrift.TConfiguration{})); err != nil {
+		return nil, err
+	}
+	return SpanStubs(batch), nil
+}
+
+// ParseAgentPacket decodes an emitBatch message encoded with the Thrift
+// compact protocol, as sent to the Jaeger agent over UDP, into SpanStubs.
+func ParseAgentPacket(data []byte) (tracetest.SpanStubs, error) {
+	ctx := context.Background()
+	buf := thrift.NewTMemoryBuffer()
+	if _, err := buf.Write(data); err != nil {
+		return nil, err
+	}
+	prot := thrift.NewTCompactProtocolConf(buf, &thrift.TConfiguration{})
+	name, _, _, err := prot.ReadMessageBegin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name != emitBatchMethod {
+		return nil, fmt.Errorf("%w: %q", errNotEmitBatch, name)
+	}
+	args := genAgent.NewAgentEmitBatchArgs()
+	if err := args.Read(ctx, prot); err != nil {
+		return nil, err
+	}
+	if err := prot.ReadMessageEnd(ctx); err != nil {
+		return nil, err
+	}
+	return SpanStubs(args.Batch), nil
+}
+
+// SpanStubs converts the spans of a Jaeger Batch into SpanStubs. The batch
+// Process is used as the Resource of all spans.
+func SpanStubs(batch *gen.Batch) tracetest.SpanStubs {
+	if batch == nil || len(batch.Spans) == 0 {
+		return nil
+	}
+	res := processResource(batch.Process)
+	stubs := make(tracetest.SpanStubs, 0, len(batch.Spans))
+	for _, s := range batch.Spans {
+		if s == nil {
+			continue
+		}
+		stub := SpanStub(s)
+		stub.Resource = res
+		stubs = append(stubs, stub)
+	}
+	return stubs
+}
+
+// SpanStub converts a Jaeger span into a SpanStub. The returned SpanStub has
+// an empty Resource.
+func SpanStub(s *gen.Span) tracetest.SpanStub {
+	tid := traceID(s.TraceIdHigh, s.TraceIdLow)
+	var flags trace.TraceFlags
+	if s.Flags&int32(trace.FlagsSampled) != 0 {
+		flags = trace.FlagsSampled
+	}
+	stub := tracetest.SpanStub{
+		Name: s.OperationName,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			SpanID:     spanID(s.SpanId),
+			TraceFlags: flags,
+		}),
+		StartTime: fromMicros(s.StartTime),
+		EndTime:   fromMicros(s.StartTime + s.Duration),
+		Events:    events(s.Logs),
+		Resource:  resource.Empty(),
+	}
+
+	parentID := s.ParentSpanId
+	for _, ref := range s.References {
+		if ref == nil {
+			continue
+		}
+		refTID := traceID(ref.TraceIdHigh, ref.TraceIdLow)
+		if ref.RefType == gen.SpanRefType_CHILD_OF && parentID == 0 && refTID == tid {
+			parentID = ref.SpanId
+			continue
+		}
+		stub.Links = append(stub.Links, tracesdk.Link{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: refTID,
+				SpanID:  spanID(ref.SpanId),
+			}),
+		})
+	}
+	if parentID != 0 {
+		stub.Parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			SpanID:     spanID(parentID),
+			TraceFlags: flags,
+		})
+	}
+
+	var statusCode, statusDesc string
+	var hasError bool
+	for _, tag := range s.Tags {
+		if tag == nil {
+			continue
+		}
+		switch tag.Key {
+		case keyInstrumentationLibraryName:
+			stub.InstrumentationLibrary.Name = tag.GetVStr()
+		case keyInstrumentationLibraryVersion:
+			stub.InstrumentationLibrary.Version = tag.GetVStr()
+		case keySpanKind:
+			stub.SpanKind = spanKind(tag.GetVStr())
+		case keyStatusCode:
+			statusCode = tag.GetVStr()
+		case keyStatusMessage:
+			statusDesc = tag.GetVStr()
+		case keyError:
+			hasError = tag.GetVBool() || tag.GetVStr() == "true"
+		default:
+			stub.Attributes = append(stub.Attributes, tagToKeyValue(tag))
+		}
+	}
+	stub.Status = status(statusCode, hasError, statusDesc)
+	if stub.SpanKind == trace.SpanKindUnspecified {
+		stub.SpanKind = trace.SpanKindInternal
+	}
+	return stub
+}
+
+func traceID(high, low int64) trace.TraceID {
+	var tid trace.TraceID
+	binary.BigEndian.PutUint64(tid[:8], uint64(high))
+	binary.BigEndian.PutUint64(tid[8:], uint64(low))
+	return tid
+}
+
+func spanID(id int64) trace.SpanID {
+	var sid trace.SpanID
+	binary.BigEndian.PutUint64(sid[:], uint64(id))
+	return sid
+}
+
+func fromMicros(us int64) time.Time {
+	return time.Unix(0, us*int64(time.Microsecond))
+}
+
+func spanKind(kind string) trace.SpanKind {
+	switch kind {
+	case "server":
+		return trace.SpanKindServer
+	case "client":
+		return trace.SpanKindClient
+	case "producer":
+		return trace.SpanKindProducer
+	case "consumer":
+		return trace.SpanKindConsumer
+	}
+	return trace.SpanKindInternal
+}
+
+func status(code string, hasError bool, desc string) tracesdk.Status {
+	switch {
+	case strings.EqualFold(code, "ERROR") || hasError:
+		return tracesdk.Status{Code: codes.Error, Description: desc}
+	case strings.EqualFold(code, "OK"):
+		return tracesdk.Status{Code: codes.Ok}
+	}
+	return tracesdk.Status{}
+}
+
+func events(logs []*gen.Log) []tracesdk.Event {
+	if len(logs) == 0 {
+		return nil
+	}
+	evts := make([]tracesdk.Event, 0, len(logs))
+	for _, l := range logs {
+		if l == nil {
+			continue
+		}
+		evt := tracesdk.Event{Time: fromMicros(l.Timestamp)}
+		for _, f := range l.Fields {
+			if f == nil {
+				continue
+			}
+			switch {
+			case f.Key == keyEventName && f.VType == gen.TagType_STRING && evt.Name == "":
+				evt.Name = f.GetVStr()
+			case f.Key == keyDroppedAttributeCount && f.VType == gen.TagType_LONG:
+				evt.DroppedAttributeCount = int(f.GetVLong())
+			default:
+				evt.Attributes = append(evt.Attributes, tagToKeyValue(f))
+			}
+		}
+		evts = append(evts, evt)
+	}
+	return evts
+}
+
+func tagToKeyValue(tag *gen.Tag) attribute.KeyValue {
+	key := attribute.Key(tag.Key)
+	switch tag.VType {
+	case gen.TagType_BOOL:
+		return key.Bool(tag.GetVBool())
+	case gen.TagType_LONG:
+		return key.Int64(tag.GetVLong())
+	case gen.TagType_DOUBLE:
+		return key.Float64(tag.GetVDouble())
+	case gen.TagType_BINARY:
+		return key.String(string(tag.GetVBinary()))
+	}
+	return key.String(tag.GetVStr())
+}
+
+func processResource(p *gen.Process) *resource.Resource {
+	if p == nil {
+		return resource.Empty()
+	}
+	attrs := make([]attribute.KeyValue, 0, len(p.Tags)+1)
+	for _, tag := range p.Tags {
+		if tag != nil {
+			attrs = append(attrs, tagToKeyValue(tag))
+		}
+	}
+	if p.ServiceName != "" {
+		attrs = append(attrs, semconv.ServiceName(p.ServiceName))
+	}
+	return resource.NewSchemaless(attrs...)
+}
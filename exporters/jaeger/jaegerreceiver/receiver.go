@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver // import "github.com/middleware-labs/otel/exporters/jaeger/jaegerreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/middleware-labs/otel"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+)
+
+const (
+	// maxBodySize is the maximum size of a request body accepted by the
+	// handler.
+	maxBodySize = 16 << 20
+	// maxPacketSize is the maximum size of a UDP packet sent to an agent.
+	maxPacketSize = 65000
+)
+
+// NewHandler returns an http.Handler that receives Jaeger batches POSTed in
+// the Thrift binary encoding (application/x-thrift), the format used by the
+// Jaeger collector /api/traces endpoint, and exports them with exporter. A
+// 202 status is returned once the spans have been exported.
+func NewHandler(exporter sdktrace.SpanExporter) http.Handler {
+	return &handler{exporter: newLockedExporter(exporter)}
+}
+
+type handler struct {
+	exporter lockedExporter
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/x-thrift" {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", mediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	stubs, err := ParseBatch(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.exporter.export(r.Context(), stubs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// UDPListener receives the emitBatch packets, encoded with the Thrift compact
+// protocol, that Jaeger clients send to an agent and exports their spans.
+type UDPListener struct {
+	conn     net.PacketConn
+	exporter lockedExporter
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// ListenUDP listens for Jaeger agent packets on the UDP address addr and
+// exports the received spans with exporter. Errors decoding or exporting
+// spans are sent to the global ErrorHandler.
+//
+// The returned UDPListener needs to be closed to stop receiving spans.
+func ListenUDP(addr string, exporter sdktrace.SpanExporter) (*UDPListener, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	l := &UDPListener{
+		conn:     conn,
+		exporter: newLockedExporter(exporter),
+		done:     make(chan struct{}),
+	}
+	go l.serve()
+	return l, nil
+}
+
+// Addr returns the address the UDPListener is listening on.
+func (l *UDPListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// Close stops the UDPListener. It waits for the spans being exported to be
+// processed.
+func (l *UDPListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		err = l.conn.Close()
+		<-l.done
+	})
+	return err
+}
+
+func (l *UDPListener) serve() {
+	defer close(l.done)
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				otel.Handle(fmt.Errorf("jaeger receiver: %w", err))
+			}
+			return
+		}
+
+		stubs, err := ParseAgentPacket(buf[:n])
+		if err != nil {
+			otel.Handle(fmt.Errorf("jaeger receiver: invalid packet: %w", err))
+			continue
+		}
+		if err := l.exporter.export(context.Background(), stubs); err != nil {
+			otel.Handle(fmt.Errorf("jaeger receiver: %w", err))
+		}
+	}
+}
+
+// exportLocks maps the exporters of handlers and listeners to the mutex
+// serializing their ExportSpans calls, which the SpanExporter interface does
+// not require to be safe for concurrent use. An exporter can be shared by a
+// handler, serving requests concurrently, and a listener.
+var exportLocks sync.Map
+
+// lockedExporter is an exporter with the mutex serializing its exports.
+type lockedExporter struct {
+	exporter sdktrace.SpanExporter
+	mu       *sync.Mutex
+}
+
+func newLockedExporter(exporter sdktrace.SpanExporter) lockedExporter {
+	if t := reflect.TypeOf(exporter); t == nil || !t.Comparable() {
+		return lockedExporter{exporter: exporter, mu: new(sync.Mutex)}
+	}
+	mu, _ := exportLocks.LoadOrStore(exporter, new(sync.Mutex))
+	return lockedExporter{exporter: exporter, mu: mu.(*sync.Mutex)}
+}
+
+// export exports stubs once the previous export of the exporter returned.
+func (e lockedExporter) export(ctx context.Context, stubs tracetest.SpanStubs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exporter.ExportSpans(ctx, stubs.Snapshots())
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/exporters/jaeger"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/resource"
+	tracesdk "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var (
+	testTraceID  = trace.TraceID{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F}
+	testSpanID   = trace.SpanID{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8}
+	testParentID = trace.SpanID{0xDF, 0xDE, 0xDD, 0xDC, 0xDB, 0xDA, 0xD9, 0xD8}
+	testLinkedID = trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	testStart    = time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC)
+)
+
+func testStub() tracetest.SpanStub {
+	return tracetest.SpanStub{
+		Name: "foo",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    testTraceID,
+			SpanID:     testSpanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    testTraceID,
+			SpanID:     testParentID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		SpanKind:  trace.SpanKindServer,
+		StartTime: testStart,
+		EndTime:   testStart.Add(time.Minute),
+		Attributes: []attribute.KeyValue{
+			attribute.String("string", "value"),
+			attribute.Int64("int", 1),
+			attribute.Float64("float", 1.5),
+			attribute.Bool("bool", true),
+		},
+		Events: []tracesdk.Event{
+			{
+				Name:                  "event",
+				Time:                  testStart.Add(time.Second),
+				Attributes:            []attribute.KeyValue{attribute.Int64("count", 2)},
+				DroppedAttributeCount: 3,
+			},
+		},
+		Links: []tracesdk.Link{{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: testTraceID,
+				SpanID:  testLinkedID,
+			}),
+		}},
+		Status: tracesdk.Status{Code: codes.Error, Description: "boom"},
+		Resource: resource.NewSchemaless(
+			semconv.ServiceName("svc"),
+			attribute.String("host", "h1"),
+		),
+		InstrumentationLibrary: instrumentation.Library{Name: "lib", Version: "v1"},
+	}
+}
+
+func assertStub(t *testing.T, want tracetest.SpanStub, got tracetest.SpanStub) {
+	t.Helper()
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.SpanContext, got.SpanContext)
+	assert.Equal(t, want.Parent, got.Parent)
+	assert.Equal(t, want.SpanKind, got.SpanKind)
+	assert.True(t, want.StartTime.Equal(got.StartTime))
+	assert.True(t, want.EndTime.Equal(got.EndTime))
+	assert.ElementsMatch(t, want.Attributes, got.Attributes)
+	require.Len(t, got.Events, len(want.Events))
+	for i := range want.Events {
+		assert.Equal(t, want.Events[i].Name, got.Events[i].Name)
+		assert.True(t, want.Events[i].Time.Equal(got.Events[i].Time))
+		assert.Equal(t, want.Events[i].Attributes, got.Events[i].Attributes)
+		assert.Equal(t, want.Events[i].DroppedAttributeCount, got.Events[i].DroppedAttributeCount)
+	}
+	assert.Equal(t, want.Links, got.Links)
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Resource, got.Resource)
+	assert.Equal(t, want.InstrumentationLibrary, got.InstrumentationLibrary)
+}
+
+func TestHandler(t *testing.T) {
+	recv := tracetest.NewInMemoryExporter()
+	srv := httptest.NewServer(NewHandler(recv))
+	defer srv.Close()
+
+	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(srv.URL)))
+	require.NoError(t, err)
+	want := testStub()
+	require.NoError(t, exp.ExportSpans(context.Background(), tracetest.SpanStubs{want}.Snapshots()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	got := tracetest.SpanStubsFromReadOnlySpans(recv.GetSpans().Snapshots())
+	require.Len(t, got, 1)
+	assertStub(t, want, got[0])
+}
+
+func TestHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "MethodNotAllowed", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "UnsupportedContentType", method: http.MethodPost, contentType: "application/json", body: "{}", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "InvalidBody", method: http.MethodPost, contentType: "application/x-thrift", body: "invalid", wantStatus: http.StatusBadRequest},
+		{name: "TooLarge", method: http.MethodPost, contentType: "application/x-thrift", body: strings.Repeat("x", maxBodySize+1), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			exp := tracetest.NewInMemoryExporter()
+			req := httptest.NewRequest(tc.method, "/api/traces", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+
+			NewHandler(exp).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Empty(t, exp.GetSpans())
+		})
+	}
+}
+
+func TestUDPListener(t *testing.T) {
+	recv := tracetest.NewInMemoryExporter()
+	l, err := ListenUDP("127.0.0.1:0", recv)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, l.Close()) }()
+
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	exp, err := jaeger.New(jaeger.WithAgentEndpoint(
+		jaeger.WithAgentHost(host),
+		jaeger.WithAgentPort(port),
+	))
+	require.NoError(t, err)
+	want := testStub()
+	require.NoError(t, exp.ExportSpans(context.Background(), tracetest.SpanStubs{want}.Snapshots()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	require.Eventually(t, func() bool {
+		return len(recv.GetSpans()) == 1
+	}, time.Second, 10*time.Millisecond)
+	got := tracetest.SpanStubsFromReadOnlySpans(recv.GetSpans().Snapshots())
+	assertStub(t, want, got[0])
+}
+
+func TestParseAgentPacketInvalid(t *testing.T) {
+	_, err := ParseAgentPacket([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestUDPListenerCloseIdempotent(t *testing.T) {
+	l, err := ListenUDP("127.0.0.1:0", tracetest.NewInMemoryExporter())
+	require.NoError(t, err)
+	assert.NoError(t, l.Close())
+	assert.NoError(t, l.Close())
+}
+
+// serialExporter is an exporter recording if ExportSpans is called
+// concurrently.
+type serialExporter struct {
+	*tracetest.InMemoryExporter
+
+	active     atomic.Int32
+	concurrent atomic.Bool
+}
+
+func (e *serialExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	if e.active.Add(1) > 1 {
+		e.concurrent.Store(true)
+	}
+	defer e.active.Add(-1)
+	time.Sleep(time.Millisecond)
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func TestExportSerialized(t *testing.T) {
+	recv := &serialExporter{InMemoryExporter: tracetest.NewInMemoryExporter()}
+	l, err := ListenUDP("127.0.0.1:0", recv)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, l.Close()) }()
+	srv := httptest.NewServer(NewHandler(recv))
+	defer srv.Close()
+
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	newExporters := []func() (*jaeger.Exporter, error){
+		func() (*jaeger.Exporter, error) {
+			return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(srv.URL)))
+		},
+		func() (*jaeger.Exporter, error) {
+			return jaeger.New(jaeger.WithAgentEndpoint(jaeger.WithAgentHost(host), jaeger.WithAgentPort(port)))
+		},
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		for _, newExporter := range newExporters {
+			exp, err := newExporter()
+			require.NoError(t, err)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx := context.Background()
+				assert.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{testStub()}.Snapshots()))
+				assert.NoError(t, exp.Shutdown(ctx))
+			}()
+		}
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		return len(recv.GetSpans()) == 2*n
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, recv.concurrent.Load(), "ExportSpans called concurrently")
+}
+
+// blockingExporter is an exporter whose exports wait for release to be
+// closed.
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExporter) ExportSpans(context.Context, []tracesdk.ReadOnlySpan) error {
+	e.started <- struct{}{}
+	<-e.release
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestExportersNotSerialized(t *testing.T) {
+	blocked := &blockingExporter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	l, err := ListenUDP("127.0.0.1:0", blocked)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, l.Close()) }()
+	defer close(blocked.release)
+	recv := tracetest.NewInMemoryExporter()
+	srv := httptest.NewServer(NewHandler(recv))
+	defer srv.Close()
+
+	ctx := context.Background()
+	spans := tracetest.SpanStubs{testStub()}.Snapshots()
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	agentExp, err := jaeger.New(jaeger.WithAgentEndpoint(jaeger.WithAgentHost(host), jaeger.WithAgentPort(port)))
+	require.NoError(t, err)
+	require.NoError(t, agentExp.ExportSpans(ctx, spans))
+	require.NoError(t, agentExp.Shutdown(ctx))
+	<-blocked.started
+
+	// The export of another exporter does not wait for the blocked one.
+	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(srv.URL)))
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Len(t, recv.GetSpans(), 1)
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/middleware-labs/otel"
+)
+
+// Authenticator provides the credentials of export requests.
+type Authenticator interface {
+	// Headers returns the headers, or gRPC metadata, authenticating an
+	// export request. It is called before each request is sent.
+	Headers(ctx context.Context) (map[string]string, error)
+
+	// Refresh obtains new credentials after a request has been rejected
+	// as unauthenticated. The request is sent again once if it returns
+	// nil.
+	Refresh(ctx context.Context) error
+}
+
+// BasicAuth returns an Authenticator using the HTTP Basic authentication
+// scheme with username and password.
+func BasicAuth(username, password string) Authenticator {
+	creds := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return basicAuth{"Authorization": "Basic " + creds}
+}
+
+type basicAuth map[string]string
+
+func (a basicAuth) Headers(context.Context) (map[string]string, error) {
+	return a, nil
+}
+
+func (a basicAuth) Refresh(context.Context) error {
+	return errors.New("basic authentication credentials cannot be refreshed")
+}
+
+// BearerTokenFile returns an Authenticator using the token read from path
+// with the Bearer authentication scheme. The file is read again when its
+// modification time changes, and when the token is refreshed, allowing the
+// token to be rotated without recreating the exporter.
+func BearerTokenFile(path string) Authenticator {
+	return &bearerTokenFile{path: path}
+}
+
+type bearerTokenFile struct {
+	path string
+
+	mu    sync.Mutex
+	token string
+	mod   time.Time
+}
+
+// Headers returns the token read from the file. The previous token is used
+// if the modified file cannot be read.
+func (a *bearerTokenFile) Headers(context.Context) (map[string]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.reload(false); err != nil {
+		if a.token == "" {
+			return nil, err
+		}
+		otel.Handle(err)
+	}
+	return map[string]string{"Authorization": "Bearer " + a.token}, nil
+}
+
+func (a *bearerTokenFile) Refresh(context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.reload(true)
+}
+
+// reload reads the token file if it was modified, or if force is true. It
+// needs to be called with mu held.
+func (a *bearerTokenFile) reload(force bool) error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return fmt.Errorf("read bearer token: %w", err)
+	}
+	if !force && a.token != "" && info.ModTime().Equal(a.mod) {
+		return nil
+	}
+
+	b, err := os.ReadFile(a.path)
+	if err != nil {
+		return fmt.Errorf("read bearer token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return fmt.Errorf("read bearer token: empty file %s", a.path)
+	}
+	a.token, a.mod = token, info.ModTime()
+	return nil
+}
+
+// ClientCredentialsConfig configures the OAuth 2.0 client credentials grant
+// used to obtain access tokens.
+type ClientCredentialsConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization
+	// server.
+	TokenURL string
+	// ClientID is the client identifier.
+	ClientID string
+	// ClientSecret is the client secret.
+	ClientSecret string
+	// Scopes is the scope of the requested access tokens. No scope is
+	// requested if it is empty.
+	Scopes []string
+	// EndpointParams are additional parameters sent to the token endpoint,
+	// like an audience.
+	EndpointParams url.Values
+	// HTTPClient is the client used to request tokens. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// expiryDelta is the time before their expiration access tokens are
+// considered expired, to account for clock skew and request latency.
+const expiryDelta = 10 * time.Second
+
+// ClientCredentials returns an Authenticator using access tokens obtained
+// with the OAuth 2.0 client credentials grant. Access tokens are cached until
+// they expire or are refreshed.
+func ClientCredentials(cfg ClientCredentialsConfig) Authenticator {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &clientCredentials{cfg: cfg}
+}
+
+type clientCredentials struct {
+	cfg ClientCredentialsConfig
+
+	mu     sync.Mutex
+	header string
+	expiry time.Time
+}
+
+func (a *clientCredentials) Headers(ctx context.Context) (map[string]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.header == "" || (!a.expiry.IsZero() && !time.Now().Before(a.expiry)) {
+		if err := a.fetch(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]string{"Authorization": a.header}, nil
+}
+
+func (a *clientCredentials) Refresh(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fetch(ctx)
+}
+
+// tokenResponse is the successful response of a token endpoint, defined in
+// RFC 6749 section 5.1.
+type tokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	TokenType   string      `json:"token_type"`
+	ExpiresIn   json.Number `json:"expires_in"`
+}
+
+// fetch requests a new access token. It needs to be called with mu held.
+func (a *clientCredentials) fetch(ctx context.Context) error {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(a.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.cfg.Scopes, " "))
+	}
+	for k, v := range a.cfg.EndpointParams {
+		form[k] = v
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("request access token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Client credentials are form-encoded before being used as the basic
+	// authentication credentials (RFC 6749 section 2.3.1).
+	req.SetBasicAuth(url.QueryEscape(a.cfg.ClientID), url.QueryEscape(a.cfg.ClientSecret))
+
+	resp, err := a.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("request access token: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("request access token: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var tok tokenResponse
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return fmt.Errorf("request access token: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return errors.New("request access token: no access token in response")
+	}
+
+	var expiry time.Time
+	if tok.ExpiresIn != "" {
+		secs, err := tok.ExpiresIn.Int64()
+		if err != nil {
+			return fmt.Errorf("request access token: invalid expires_in: %w", err)
+		}
+		if secs > 0 {
+			expiry = time.Now().Add(time.Duration(secs)*time.Second - expiryDelta)
+		}
+	}
+
+	tokenType := tok.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	a.header, a.expiry = tokenType+" "+tok.AccessToken, expiry
+	return nil
+}
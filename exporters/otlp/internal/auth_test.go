@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicAuth(t *testing.T) {
+	a := BasicAuth("user", "pass")
+	h, err := a.Headers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, h)
+	assert.Error(t, a.Refresh(context.Background()))
+}
+
+func TestBearerTokenFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token")
+	a := BearerTokenFile(path)
+
+	_, err := a.Headers(ctx)
+	assert.Error(t, err, "missing file")
+
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	h, err := a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer first"}, h)
+
+	// Make sure the modification time changes.
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer second", h["Authorization"])
+
+	// Refresh reads the file even if its modification time is unchanged.
+	require.NoError(t, os.WriteFile(path, []byte("third"), 0o600))
+	require.NoError(t, os.Chtimes(path, later, later))
+	require.NoError(t, a.Refresh(ctx))
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer third", h["Authorization"])
+
+	// The previous token is kept while the file is invalid.
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	evenLater := later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, evenLater, evenLater))
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer third", h["Authorization"])
+	assert.Error(t, a.Refresh(ctx))
+}
+
+// tokenServer is a stand-in for the token endpoint of an authorization
+// server. It issues access tokens numbered by request.
+type tokenServer struct {
+	*httptest.Server
+
+	requests  int64
+	expiresIn int
+	form      url.Values
+}
+
+func newTokenServer(t *testing.T, expiresIn int) *tokenServer {
+	s := &tokenServer{expiresIn: expiresIn}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The client credentials are form-encoded.
+		id, secret, ok := r.BasicAuth()
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		if !ok || id != "client id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.form = r.PostForm
+		n := atomic.AddInt64(&s.requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, s.expiresIn)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClientCredentials(t *testing.T) {
+	ctx := context.Background()
+	srv := newTokenServer(t, 3600)
+	a := ClientCredentials(ClientCredentialsConfig{
+		TokenURL:       srv.URL,
+		ClientID:       "client id",
+		ClientSecret:   "secret",
+		Scopes:         []string{"write", "read"},
+		EndpointParams: url.Values{"audience": {"otlp"}},
+	})
+
+	h, err := a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token-1"}, h)
+	assert.Equal(t, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {"write read"},
+		"audience":   {"otlp"},
+	}, srv.form)
+
+	// The token is cached until it expires.
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", h["Authorization"])
+
+	require.NoError(t, a.Refresh(ctx))
+	h, err = a.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", h["Authorization"])
+	assert.Equal(t, int64(2), atomic.LoadInt64(&srv.requests))
+}
+
+func TestClientCredentialsExpiry(t *testing.T) {
+	ctx := context.Background()
+	// Tokens expiring sooner than expiryDelta are requested again for every
+	// request.
+	srv := newTokenServer(t, 1)
+	a := ClientCredentials(ClientCredentialsConfig{
+		TokenURL:     srv.URL,
+		ClientID:     "client id",
+		ClientSecret: "secret",
+	})
+
+	for i := 1; i <= 3; i++ {
+		h, err := a.Headers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("Bearer token-%d", i), h["Authorization"])
+	}
+	assert.NotContains(t, srv.form, "scope")
+}
+
+func TestClientCredentialsErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := newTokenServer(t, 3600)
+	a := ClientCredentials(ClientCredentialsConfig{
+		TokenURL:     srv.URL,
+		ClientID:     "client id",
+		ClientSecret: "wrong",
+	})
+	_, err := a.Headers(ctx)
+	assert.ErrorContains(t, err, "401")
+	assert.ErrorContains(t, err, "invalid_client")
+
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"token_type":"bearer"}`))
+	}))
+	defer empty.Close()
+	a = ClientCredentials(ClientCredentialsConfig{TokenURL: empty.URL})
+	_, err = a.Headers(ctx)
+	assert.ErrorContains(t, err, "no access token")
+}
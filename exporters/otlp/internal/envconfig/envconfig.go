@@ -154,6 +154,17 @@ func WithClientCert(nc, nk string, fn func(tls.Certificate)) ConfigFn {
 	}
 }
 
+// WithClientCertFiles returns a ConfigFn that reads the environment variable nc and nk as filepaths to a client certificate and key pair. If both are set, the filepaths are passed to fn.
+func WithClientCertFiles(nc, nk string, fn func(certFile, keyFile string)) ConfigFn {
+	return func(e *EnvOptionsReader) {
+		vc, okc := e.GetEnvValue(nc)
+		vk, okk := e.GetEnvValue(nk)
+		if okc && okk {
+			fn(vc, vk)
+		}
+	}
+}
+
 func keyWithNamespace(ns, key string) string {
 	if ns == "" {
 		return key
@@ -407,6 +407,43 @@ func TestWithClientCert(t *testing.T) {
 	assert.Nil(t, option.TestTLS)
 }
 
+func TestWithClientCertFiles(t *testing.T) {
+	reader := EnvOptionsReader{
+		GetEnv: func(n string) string {
+			switch n {
+			case "CLIENT_CERTIFICATE":
+				return "/path/tls.crt"
+			case "CLIENT_KEY":
+				return "/path/tls.key"
+			}
+			return ""
+		},
+	}
+
+	var certFile, keyFile string
+	reader.Apply(
+		WithClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c, k string) {
+			certFile, keyFile = c, k
+		}),
+	)
+	assert.Equal(t, "/path/tls.crt", certFile)
+	assert.Equal(t, "/path/tls.key", keyFile)
+
+	reader.GetEnv = func(n string) string {
+		if n == "CLIENT_CERTIFICATE" {
+			return "/path/tls.crt"
+		}
+		return ""
+	}
+	called := false
+	reader.Apply(
+		WithClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", func(string, string) {
+			called = true
+		}),
+	)
+	assert.False(t, called, "key file not set")
+}
+
 func TestStringToHeader(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+import (
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/metric/instrument"
+	"github.com/middleware-labs/otel/metric/noop"
+)
+
+// CircuitBreakerStateCounter returns the up-down counter of the state of the
+// circuit breaker of OTLP exporters. Its value is 1 for the attributes of the
+// current state, and 0 for the other states. It is created with the Meter
+// named scope of mp, or of the global MeterProvider if mp is nil.
+func CircuitBreakerStateCounter(mp metric.MeterProvider, scope string) instrument.Int64UpDownCounter {
+	return upDownCounter(
+		mp, scope,
+		"otlp.exporter.circuit_breaker.state",
+		"1",
+		"The state of the circuit breaker of the OTLP exporter",
+	)
+}
+
+// ConcurrencyLimitCounter returns the up-down counter of the limit of
+// concurrent requests of OTLP exporters. It is created with the Meter named
+// scope of mp, or of the global MeterProvider if mp is nil.
+func ConcurrencyLimitCounter(mp metric.MeterProvider, scope string) instrument.Int64UpDownCounter {
+	return upDownCounter(
+		mp, scope,
+		"otlp.exporter.concurrency.limit",
+		"{request}",
+		"The limit of concurrent requests of the OTLP exporter",
+	)
+}
+
+func upDownCounter(mp metric.MeterProvider, scope, name, unit, desc string) instrument.Int64UpDownCounter {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(scope, metric.WithInstrumentationVersion(otel.Version()))
+	counter, err := meter.Int64UpDownCounter(name, instrument.WithUnit(unit), instrument.WithDescription(desc))
+	if err != nil {
+		otel.Handle(err)
+		return noop.Int64UpDownCounter{}
+	}
+	return counter
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unixPrefix is the prefix of the endpoints of Unix domain sockets.
+const unixPrefix = "unix://"
+
+// unixHost is the host of the requests sent to a Unix domain socket.
+const unixHost = "localhost"
+
+// UnixSocketEndpoint returns the endpoint of the Unix domain socket at path,
+// in the unix:///path/to/socket form.
+func UnixSocketEndpoint(path string) string {
+	return unixPrefix + path
+}
+
+// UnixSocketPath returns the path of the Unix domain socket of endpoint and
+// true if endpoint is in the unix:///path/to/socket form. Otherwise, it
+// returns false.
+func UnixSocketPath(endpoint string) (string, bool) {
+	if len(endpoint) < len(unixPrefix) || !strings.EqualFold(endpoint[:len(unixPrefix)], unixPrefix) {
+		return "", false
+	}
+	return endpoint[len(unixPrefix):], true
+}
+
+// HTTPTransportConfig holds the settings of the transport used by the
+// OTLP/HTTP clients.
+type HTTPTransportConfig struct {
+	// Endpoint is the host and port, or the Unix domain socket, requests
+	// are sent to.
+	Endpoint string
+	// TLSCfg is used for TLS connections if it is set.
+	TLSCfg *tls.Config
+	// Proxy returns the proxy of a request if it is set.
+	Proxy func(*http.Request) (*url.URL, error)
+	// Dialer establishes the network connections if it is set.
+	Dialer func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// HTTPTransport returns base if c does not change any of its settings.
+// Otherwise, it returns a clone of base using the settings of c. Requests to
+// a Unix domain socket endpoint are never sent through a proxy.
+func HTTPTransport(base *http.Transport, c HTTPTransportConfig) *http.Transport {
+	socket, unix := UnixSocketPath(c.Endpoint)
+	if c.TLSCfg == nil && c.Proxy == nil && c.Dialer == nil && !unix {
+		return base
+	}
+
+	transport := base.Clone()
+	if c.TLSCfg != nil {
+		transport.TLSClientConfig = c.TLSCfg
+	}
+	if c.Proxy != nil {
+		transport.Proxy = c.Proxy
+	}
+	if c.Dialer != nil {
+		transport.DialContext = c.Dialer
+	}
+	if unix {
+		dial := transport.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{}).DialContext
+		}
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dial(ctx, "unix", socket)
+		}
+	}
+	return transport
+}
+
+// HTTPRequestURL returns the URL of the requests sent to endpoint at
+// urlPath. Requests to a Unix domain socket endpoint always use plain HTTP.
+func HTTPRequestURL(endpoint, urlPath string, insecure bool) *url.URL {
+	u := &url.URL{Scheme: "https", Host: endpoint, Path: urlPath}
+	if _, unix := UnixSocketPath(endpoint); unix {
+		u.Host = unixHost
+		insecure = true
+	}
+	if insecure {
+		u.Scheme = "http"
+	}
+	return u
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	path, ok := UnixSocketPath(UnixSocketEndpoint("/var/run/otel.sock"))
+	assert.True(t, ok)
+	assert.Equal(t, "/var/run/otel.sock", path)
+
+	path, ok = UnixSocketPath("UNIX:///var/run/otel.sock")
+	assert.True(t, ok)
+	assert.Equal(t, "/var/run/otel.sock", path)
+
+	_, ok = UnixSocketPath("localhost:4318")
+	assert.False(t, ok)
+}
+
+func TestHTTPRequestURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		insecure bool
+		want     string
+	}{
+		{endpoint: "localhost:4318", want: "https://localhost:4318/v1/traces"},
+		{endpoint: "localhost:4318", insecure: true, want: "http://localhost:4318/v1/traces"},
+		{endpoint: "unix:///var/run/otel.sock", want: "http://localhost/v1/traces"},
+	}
+	for _, tt := range tests {
+		got := HTTPRequestURL(tt.endpoint, "/v1/traces", tt.insecure)
+		assert.Equal(t, tt.want, got.String())
+	}
+}
+
+func TestHTTPTransport(t *testing.T) {
+	base := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	assert.Same(t, base, HTTPTransport(base, HTTPTransportConfig{Endpoint: "localhost:4318"}))
+
+	tlsCfg := &tls.Config{ServerName: "collector"}
+	got := HTTPTransport(base, HTTPTransportConfig{Endpoint: "localhost:4318", TLSCfg: tlsCfg})
+	assert.NotSame(t, base, got)
+	assert.Same(t, tlsCfg, got.TLSClientConfig)
+}
+
+func TestHTTPTransportUnixSocket(t *testing.T) {
+	var dialed []string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, network+" "+addr)
+		return nil, net.ErrClosed
+	}
+	base := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	got := HTTPTransport(base, HTTPTransportConfig{
+		Endpoint: UnixSocketEndpoint("/var/run/otel.sock"),
+		Dialer:   dial,
+	})
+	assert.Nil(t, got.Proxy, "requests to a Unix domain socket must not use a proxy")
+
+	_, err := got.DialContext(context.Background(), "tcp", "localhost:80")
+	require.ErrorIs(t, err, net.ErrClosed)
+	assert.Equal(t, []string{"unix /var/run/otel.sock"}, dialed)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// idKeys are the OTLP/JSON fields of spans, links, and exemplars holding a
+// trace or span ID.
+var idKeys = map[string]bool{
+	"traceId":      true,
+	"spanId":       true,
+	"parentSpanId": true,
+}
+
+// HexEncodeIDs converts data, an OTLP message encoded with the Protobuf JSON
+// mapping, to the OTLP/JSON encoding. The Protobuf JSON mapping encodes the
+// trace and span IDs in base64, OTLP/JSON requires them to be hex-encoded.
+// All other bytes fields are left base64-encoded.
+func HexEncodeIDs(data []byte) ([]byte, error) {
+	return transformIDs(data, func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(b), nil
+	})
+}
+
+// Base64EncodeIDs converts data, an OTLP/JSON encoded message, to the
+// Protobuf JSON mapping by converting its hex-encoded trace and span IDs to
+// base64.
+func Base64EncodeIDs(data []byte) ([]byte, error) {
+	return transformIDs(data, func(s string) (string, error) {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	})
+}
+
+func transformIDs(data []byte, fn func(string) (string, error)) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Keep the representation of numbers, 64 bit integers lose precision
+	// when decoded to float64.
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := walkIDs(v, fn); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func walkIDs(v interface{}, fn func(string) (string, error)) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if s, ok := val.(string); ok && idKeys[k] {
+				id, err := fn(s)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", k, s, err)
+				}
+				v[k] = id
+				continue
+			}
+			if err := walkIDs(val, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if err := walkIDs(val, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
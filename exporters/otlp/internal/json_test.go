@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexEncodeIDs(t *testing.T) {
+	// Trace ID 0102030405060708090a0b0c0d0e0f10 and span IDs
+	// 0102030405060708 and 0807060504030201 in base64.
+	in := `{"resourceSpans": [{"scopeSpans": [{"spans": [{
+		"traceId": "AQIDBAUGBwgJCgsMDQ4PEA==",
+		"spanId": "AQIDBAUGBwg=",
+		"parentSpanId": "CAcGBQQDAgE=",
+		"startTimeUnixNano": "1684000000000000001",
+		"kind": 2,
+		"attributes": [{"key": "b", "value": {"bytesValue": "AQI="}}],
+		"links": [{"traceId": "AQIDBAUGBwgJCgsMDQ4PEA==", "spanId": "CAcGBQQDAgE="}]
+	}]}]}]}`
+	want := `{"resourceSpans":[{"scopeSpans":[{"spans":[{` +
+		`"attributes":[{"key":"b","value":{"bytesValue":"AQI="}}],` +
+		`"kind":2,` +
+		`"links":[{"spanId":"0807060504030201","traceId":"0102030405060708090a0b0c0d0e0f10"}],` +
+		`"parentSpanId":"0807060504030201",` +
+		`"spanId":"0102030405060708",` +
+		`"startTimeUnixNano":"1684000000000000001",` +
+		`"traceId":"0102030405060708090a0b0c0d0e0f10"` +
+		`}]}]}]}`
+
+	got, err := HexEncodeIDs([]byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	back, err := Base64EncodeIDs(got)
+	require.NoError(t, err)
+	again, err := HexEncodeIDs(back)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(again))
+}
+
+func TestHexEncodeIDsErrors(t *testing.T) {
+	_, err := HexEncodeIDs([]byte(`{"traceId": "not base64!"}`))
+	assert.Error(t, err)
+
+	_, err = Base64EncodeIDs([]byte(`{"spanId": "xyz"}`))
+	assert.Error(t, err)
+
+	_, err = HexEncodeIDs([]byte(`{`))
+	assert.Error(t, err)
+}
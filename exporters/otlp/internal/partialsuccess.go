@@ -14,12 +14,20 @@
 
 package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/metric/instrument"
+	"github.com/middleware-labs/otel/metric/noop"
+)
 
 // PartialSuccess represents the underlying error for all handling
 // OTLP partial success messages.  Use `errors.Is(err,
 // PartialSuccess{})` to test whether an error passed to the OTel
-// error handler belongs to this category.
+// error handler belongs to this category, or `errors.As` to read the
+// number of rejected items.
 type PartialSuccess struct {
 	ErrorMessage  string
 	RejectedItems int64
@@ -62,3 +70,40 @@ func MetricPartialSuccessError(itemsRejected int64, errorMessage string) error {
 		RejectedKind:  "metric data points",
 	}
 }
+
+// RejectedSpansCounter returns the counter of the spans rejected by OTLP
+// receivers in partial success responses. It is created with the Meter named
+// scope of mp, or of the global MeterProvider if mp is nil.
+func RejectedSpansCounter(mp metric.MeterProvider, scope string) instrument.Int64Counter {
+	return rejectedCounter(
+		mp, scope,
+		"otlp.exporter.spans.rejected",
+		"{span}",
+		"The number of spans rejected by the OTLP receiver in partial success responses",
+	)
+}
+
+// RejectedDataPointsCounter returns the counter of the metric data points
+// rejected by OTLP receivers in partial success responses. It is created with
+// the Meter named scope of mp, or of the global MeterProvider if mp is nil.
+func RejectedDataPointsCounter(mp metric.MeterProvider, scope string) instrument.Int64Counter {
+	return rejectedCounter(
+		mp, scope,
+		"otlp.exporter.data_points.rejected",
+		"{data_point}",
+		"The number of metric data points rejected by the OTLP receiver in partial success responses",
+	)
+}
+
+func rejectedCounter(mp metric.MeterProvider, scope, name, unit, desc string) instrument.Int64Counter {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(scope, metric.WithInstrumentationVersion(otel.Version()))
+	counter, err := meter.Int64Counter(name, instrument.WithUnit(unit), instrument.WithDescription(desc))
+	if err != nil {
+		otel.Handle(err)
+		return noop.Int64Counter{}
+	}
+	return counter
+}
@@ -16,10 +16,17 @@ package internal // import "github.com/middleware-labs/otel/exporters/otlp/inter
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/metric/instrument"
+	"github.com/middleware-labs/otel/metric/noop"
 )
 
 func requireErrorString(t *testing.T, expect string, err error) {
@@ -41,3 +48,59 @@ func TestPartialSuccessFormat(t *testing.T) {
 	requireErrorString(t, "what happened (10 metric data points rejected)", MetricPartialSuccessError(10, "what happened"))
 	requireErrorString(t, "what happened (15 spans rejected)", TracePartialSuccessError(15, "what happened"))
 }
+
+func TestPartialSuccessAs(t *testing.T) {
+	err := fmt.Errorf("export: %w", TracePartialSuccessError(3, "bad spans"))
+
+	var ps PartialSuccess
+	require.True(t, errors.As(err, &ps))
+	assert.Equal(t, int64(3), ps.RejectedItems)
+	assert.Equal(t, "spans", ps.RejectedKind)
+	assert.Equal(t, "bad spans", ps.ErrorMessage)
+}
+
+type counterMeterProvider struct {
+	noop.MeterProvider
+
+	scope   string
+	version string
+	meter   *counterMeter
+}
+
+func (mp *counterMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	mp.scope = name
+	mp.version = metric.NewMeterConfig(opts...).InstrumentationVersion()
+	mp.meter = &counterMeter{}
+	return mp.meter
+}
+
+type counterMeter struct {
+	noop.Meter
+
+	name string
+	unit string
+}
+
+func (m *counterMeter) Int64Counter(name string, opts ...instrument.Int64CounterOption) (instrument.Int64Counter, error) {
+	m.name = name
+	m.unit = instrument.NewInt64CounterConfig(opts...).Unit()
+	return noop.Int64Counter{}, nil
+}
+
+func TestRejectedCounters(t *testing.T) {
+	mp := &counterMeterProvider{}
+
+	require.NotNil(t, RejectedSpansCounter(mp, "spans scope"))
+	assert.Equal(t, "spans scope", mp.scope)
+	assert.Equal(t, otel.Version(), mp.version)
+	assert.Equal(t, "otlp.exporter.spans.rejected", mp.meter.name)
+	assert.Equal(t, "{span}", mp.meter.unit)
+
+	require.NotNil(t, RejectedDataPointsCounter(mp, "metrics scope"))
+	assert.Equal(t, "metrics scope", mp.scope)
+	assert.Equal(t, "otlp.exporter.data_points.rejected", mp.meter.name)
+	assert.Equal(t, "{data_point}", mp.meter.unit)
+
+	// The global MeterProvider is used by default.
+	assert.NotNil(t, RejectedSpansCounter(nil, "scope"))
+}
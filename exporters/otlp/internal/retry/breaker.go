@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry // import "github.com/middleware-labs/otel/exporters/otlp/internal/retry"
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultBreakerConfig are the recommended defaults of a circuit breaker.
+var DefaultBreakerConfig = BreakerConfig{
+	Enabled:          true,
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+// BreakerConfig defines the configuration of a circuit breaker shared by all
+// the requests of an exporter.
+type BreakerConfig struct {
+	// Enabled indicates whether to use a circuit breaker.
+	Enabled bool
+	// FailureThreshold is the number of consecutive request attempts failed
+	// with a retryable or transport error opening the circuit breaker.
+	// Attempts rejected with a permanent error are not counted. If zero, the
+	// default of 5 is used.
+	FailureThreshold int
+	// OpenTimeout is the time the circuit breaker stays open, failing
+	// requests without attempting them, before letting a trial request
+	// through. If zero, the default of 30 seconds is used.
+	OpenTimeout time.Duration
+}
+
+// ErrCircuitOpen is returned for request attempts not made because the
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed is the state of a circuit breaker letting all requests
+	// through.
+	StateClosed State = iota
+	// StateOpen is the state of a circuit breaker failing all requests
+	// without attempting them.
+	StateOpen
+	// StateHalfOpen is the state of a circuit breaker letting a single trial
+	// request through. The breaker is closed if it succeeds, and opened
+	// again otherwise.
+	StateHalfOpen
+)
+
+// String returns the name of s.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	}
+	return "unknown"
+}
+
+// Breaker is a circuit breaker. It fails requests fast after consecutive
+// failures instead of letting every request exhaust its retries, and probes
+// the endpoint with a single request before letting all requests through
+// again.
+type Breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	onChange    func(from, to State, cause error)
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewBreaker returns a Breaker using the configuration c, or nil if c is not
+// enabled. If onChange is not nil, it is called with the previous and new
+// states, and the error of the failed attempt opening the breaker, if any,
+// when the state of the breaker changes.
+func NewBreaker(c BreakerConfig, onChange func(from, to State, cause error)) *Breaker {
+	if !c.Enabled {
+		return nil
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultBreakerConfig.FailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = DefaultBreakerConfig.OpenTimeout
+	}
+	return &Breaker{
+		threshold:   c.FailureThreshold,
+		openTimeout: c.OpenTimeout,
+		onChange:    onChange,
+		now:         time.Now,
+	}
+}
+
+// State returns the current state of b.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow returns whether a request attempt can be made. If it returns true,
+// done needs to be called with the result of the attempt.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			b.mu.Unlock()
+			return false
+		}
+		b.state, b.trial = StateHalfOpen, true
+		b.mu.Unlock()
+		b.notify(StateOpen, StateHalfOpen, nil)
+		return true
+	case StateHalfOpen:
+		// Only a single trial request is let through.
+		if b.trial {
+			b.mu.Unlock()
+			return false
+		}
+		b.trial = true
+	}
+	b.mu.Unlock()
+	return true
+}
+
+// done records the result of an attempt allowed by allow. A failed attempt,
+// with a non-nil err, counts as a failure only if failed is true. Otherwise
+// it does not change the consecutive failures and, if it was the trial
+// request, lets another trial request through.
+func (b *Breaker) done(err error, failed bool) {
+	b.mu.Lock()
+	prev := b.state
+	switch {
+	case err == nil:
+		b.state, b.failures, b.trial = StateClosed, 0, false
+	case !failed:
+		b.trial = false
+	default:
+		b.failures++
+		if prev == StateHalfOpen || (prev == StateClosed && b.failures >= b.threshold) {
+			b.state, b.openedAt, b.trial = StateOpen, b.now(), false
+		}
+	}
+	state := b.state
+	b.mu.Unlock()
+
+	if state != prev {
+		b.notify(prev, state, err)
+	}
+}
+
+func (b *Breaker) notify(from, to State, cause error) {
+	if b.onChange != nil {
+		b.onChange(from, to, cause)
+	}
+}
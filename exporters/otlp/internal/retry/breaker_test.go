@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transition struct {
+	from, to State
+	cause    error
+}
+
+func newTestBreaker(t *testing.T, c BreakerConfig) (*Breaker, *time.Time, *[]transition) {
+	t.Helper()
+	var (
+		mu          sync.Mutex
+		transitions []transition
+	)
+	b := NewBreaker(c, func(from, to State, cause error) {
+		mu.Lock()
+		defer mu.Unlock()
+		transitions = append(transitions, transition{from, to, cause})
+	})
+	require.NotNil(t, b)
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	return b, &now, &transitions
+}
+
+// retryableErr returns an EvaluateFunc for which only target is retryable.
+func retryableErr(target error) EvaluateFunc {
+	return func(err error) (bool, time.Duration) { return errors.Is(err, target), 0 }
+}
+
+func TestNewBreaker(t *testing.T) {
+	assert.Nil(t, NewBreaker(BreakerConfig{}, nil))
+
+	b := NewBreaker(BreakerConfig{Enabled: true}, nil)
+	require.NotNil(t, b)
+	assert.Equal(t, DefaultBreakerConfig.FailureThreshold, b.threshold)
+	assert.Equal(t, DefaultBreakerConfig.OpenTimeout, b.openTimeout)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreakerStates(t *testing.T) {
+	errFail := errors.New("unavailable")
+	b, now, transitions := newTestBreaker(t, BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+	})
+	req := Guard(Config{}.RequestFunc(nil), retryableErr(errFail), b, nil)
+	ctx := context.Background()
+
+	var calls int
+	fail := func(context.Context) error {
+		calls++
+		return errFail
+	}
+	succeed := func(context.Context) error {
+		calls++
+		return nil
+	}
+
+	// A success resets the consecutive failures.
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.NoError(t, req(ctx, succeed))
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, 6, calls)
+
+	// Requests are not attempted while the breaker is open.
+	*now = now.Add(time.Minute - time.Nanosecond)
+	assert.ErrorIs(t, req(ctx, succeed), ErrCircuitOpen)
+	assert.Equal(t, 6, calls)
+
+	// A failed trial request opens the breaker again.
+	*now = now.Add(time.Nanosecond)
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, req(ctx, succeed), ErrCircuitOpen)
+
+	// A successful trial request closes the breaker.
+	*now = now.Add(time.Minute)
+	assert.NoError(t, req(ctx, succeed))
+	assert.Equal(t, StateClosed, b.State())
+	assert.Equal(t, 8, calls)
+
+	assert.Equal(t, []transition{
+		{StateClosed, StateOpen, errFail},
+		{StateOpen, StateHalfOpen, nil},
+		{StateHalfOpen, StateOpen, errFail},
+		{StateOpen, StateHalfOpen, nil},
+		{StateHalfOpen, StateClosed, nil},
+	}, *transitions)
+}
+
+func TestBreakerSingleTrialRequest(t *testing.T) {
+	b, now, _ := newTestBreaker(t, BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+	})
+	errFail := errors.New("unavailable")
+	req := Guard(Config{}.RequestFunc(nil), retryableErr(errFail), b, nil)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, req(ctx, func(context.Context) error { return errFail }), errFail)
+	*now = now.Add(time.Minute)
+
+	// Other requests fail while the trial request is in flight.
+	assert.NoError(t, req(ctx, func(context.Context) error {
+		assert.Equal(t, StateHalfOpen, b.State())
+		assert.ErrorIs(t, req(ctx, func(context.Context) error { return nil }), ErrCircuitOpen)
+		return nil
+	}))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreakerFailsFast(t *testing.T) {
+	errFail := errors.New("unavailable")
+	ev := retryableErr(errFail)
+	b := NewBreaker(BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 3,
+		OpenTimeout:      time.Hour,
+	}, nil)
+	reqFunc := Guard(Config{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxInterval:     time.Nanosecond,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev), ev, b, nil)
+
+	var calls int
+	err := reqFunc(context.Background(), func(context.Context) error {
+		calls++
+		return errFail
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, calls)
+}
+
+func TestBreakerPermanentErrors(t *testing.T) {
+	errFail := errors.New("unavailable")
+	errBadRequest := errors.New("400 Bad Request")
+	b, now, _ := newTestBreaker(t, BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+	})
+	req := Guard(Config{}.RequestFunc(nil), retryableErr(errFail), b, nil)
+	ctx := context.Background()
+
+	badRequest := func(context.Context) error { return errBadRequest }
+	fail := func(context.Context) error { return errFail }
+
+	// Permanent errors do not open the breaker, nor reset the consecutive
+	// failures.
+	for i := 0; i < 5; i++ {
+		assert.ErrorIs(t, req(ctx, badRequest), errBadRequest)
+	}
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.ErrorIs(t, req(ctx, badRequest), errBadRequest)
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, req(ctx, fail), errFail)
+	assert.Equal(t, StateOpen, b.State())
+
+	// A permanent error of the trial request lets another trial through.
+	*now = now.Add(time.Minute)
+	assert.ErrorIs(t, req(ctx, badRequest), errBadRequest)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.NoError(t, req(ctx, func(context.Context) error { return nil }))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreakerTransportErrors(t *testing.T) {
+	b, _, _ := newTestBreaker(t, BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+	})
+	// Transport errors are failures even if they are not retryable.
+	never := func(error) (bool, time.Duration) { return false, 0 }
+	req := Guard(Config{}.RequestFunc(nil), never, b, nil)
+
+	errConn := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	err := req(context.Background(), func(context.Context) error {
+		return fmt.Errorf("failed to send: %w", errConn)
+	})
+	assert.ErrorIs(t, err, errConn)
+	assert.Equal(t, StateOpen, b.State())
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry // import "github.com/middleware-labs/otel/exporters/otlp/internal/retry"
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultLimiterConfig are the recommended defaults of a concurrency limiter.
+var DefaultLimiterConfig = LimiterConfig{
+	Enabled:        true,
+	InitialLimit:   8,
+	MinLimit:       1,
+	MaxLimit:       64,
+	DecreaseFactor: 0.5,
+}
+
+// LimiterConfig defines the configuration of an adaptive concurrency limiter
+// shared by all the requests of an exporter. The limit is increased
+// additively, by one for every limit successful request attempts, and
+// decreased multiplicatively when an attempt fails with a retryable error
+// (AIMD).
+type LimiterConfig struct {
+	// Enabled indicates whether to limit the concurrency of requests.
+	Enabled bool
+	// InitialLimit is the limit of concurrent request attempts used until
+	// it is adapted. If zero, the default of 8 is used.
+	InitialLimit int
+	// MinLimit is the lower bound of the limit. If zero, the default of 1 is
+	// used.
+	MinLimit int
+	// MaxLimit is the upper bound of the limit. If zero, the default of 64 is
+	// used.
+	MaxLimit int
+	// DecreaseFactor is the factor the limit is multiplied by after a
+	// retryable failure, between 0 and 1 exclusive. If zero or invalid, the
+	// default of 0.5 is used.
+	DecreaseFactor float64
+}
+
+// Limiter limits the number of concurrent request attempts with an AIMD
+// algorithm.
+type Limiter struct {
+	min, max float64
+	factor   float64
+	onChange func(from, to int)
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// released is closed, and replaced, when an attempt completes.
+	released chan struct{}
+}
+
+// NewLimiter returns a Limiter using the configuration c, or nil if c is not
+// enabled. If onChange is not nil, it is called with the previous and new
+// limits when the integer limit changes.
+func NewLimiter(c LimiterConfig, onChange func(from, to int)) *Limiter {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MinLimit <= 0 {
+		c.MinLimit = DefaultLimiterConfig.MinLimit
+	}
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = DefaultLimiterConfig.MaxLimit
+	}
+	if c.MaxLimit < c.MinLimit {
+		c.MaxLimit = c.MinLimit
+	}
+	if c.InitialLimit <= 0 {
+		c.InitialLimit = DefaultLimiterConfig.InitialLimit
+	}
+	if c.InitialLimit < c.MinLimit {
+		c.InitialLimit = c.MinLimit
+	} else if c.InitialLimit > c.MaxLimit {
+		c.InitialLimit = c.MaxLimit
+	}
+	if c.DecreaseFactor <= 0 || c.DecreaseFactor >= 1 {
+		c.DecreaseFactor = DefaultLimiterConfig.DecreaseFactor
+	}
+	return &Limiter{
+		min:      float64(c.MinLimit),
+		max:      float64(c.MaxLimit),
+		factor:   c.DecreaseFactor,
+		onChange: onChange,
+		limit:    float64(c.InitialLimit),
+		released: make(chan struct{}),
+	}
+}
+
+// Limit returns the current limit of concurrent request attempts.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// acquire waits until a request attempt can be made or ctx is done. If it
+// returns nil, release needs to be called when the attempt completes.
+func (l *Limiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.inFlight < int(l.limit) {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release records the completion of an attempt. The limit is increased if it
+// succeeded, and decreased if it failed with a retryable error.
+func (l *Limiter) release(err error, retryable bool) {
+	l.mu.Lock()
+	prev := int(l.limit)
+	switch {
+	case err == nil:
+		l.limit += 1 / l.limit
+		if l.limit > l.max {
+			l.limit = l.max
+		}
+	case retryable:
+		l.limit *= l.factor
+		if l.limit < l.min {
+			l.limit = l.min
+		}
+	}
+	l.inFlight--
+	close(l.released)
+	l.released = make(chan struct{})
+	limit := int(l.limit)
+	l.mu.Unlock()
+
+	if limit != prev && l.onChange != nil {
+		l.onChange(prev, limit)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimiter(t *testing.T) {
+	assert.Nil(t, NewLimiter(LimiterConfig{}, nil))
+
+	l := NewLimiter(LimiterConfig{Enabled: true}, nil)
+	require.NotNil(t, l)
+	assert.Equal(t, DefaultLimiterConfig.InitialLimit, l.Limit())
+	assert.Equal(t, float64(DefaultLimiterConfig.MinLimit), l.min)
+	assert.Equal(t, float64(DefaultLimiterConfig.MaxLimit), l.max)
+	assert.Equal(t, DefaultLimiterConfig.DecreaseFactor, l.factor)
+
+	l = NewLimiter(LimiterConfig{Enabled: true, InitialLimit: 100, MaxLimit: 10}, nil)
+	assert.Equal(t, 10, l.Limit())
+	l = NewLimiter(LimiterConfig{Enabled: true, InitialLimit: 1, MinLimit: 2, MaxLimit: 1}, nil)
+	assert.Equal(t, 2, l.Limit())
+}
+
+func TestLimiterAIMD(t *testing.T) {
+	var changes [][2]int
+	l := NewLimiter(LimiterConfig{
+		Enabled:        true,
+		InitialLimit:   4,
+		MinLimit:       2,
+		MaxLimit:       5,
+		DecreaseFactor: 0.5,
+	}, func(from, to int) { changes = append(changes, [2]int{from, to}) })
+	require.NotNil(t, l)
+	ctx := context.Background()
+	attempt := func(err error, retryable bool) {
+		require.NoError(t, l.acquire(ctx))
+		l.release(err, retryable)
+	}
+
+	// The limit increases by one after about limit successes.
+	for i := 0; i < 4; i++ {
+		attempt(nil, false)
+	}
+	assert.Equal(t, 4, l.Limit())
+	attempt(nil, false)
+	assert.Equal(t, 5, l.Limit())
+	for i := 0; i < 10; i++ {
+		attempt(nil, false)
+	}
+	assert.Equal(t, 5, l.Limit(), "max limit")
+
+	// Errors that are not retryable do not change the limit.
+	attempt(errors.New("bad request"), false)
+	assert.Equal(t, 5, l.Limit())
+
+	attempt(errors.New("unavailable"), true)
+	assert.Equal(t, 2, l.Limit())
+	attempt(errors.New("unavailable"), true)
+	assert.Equal(t, 2, l.Limit(), "min limit")
+
+	assert.Equal(t, [][2]int{{4, 5}, {5, 2}}, changes)
+}
+
+func TestLimiterWaits(t *testing.T) {
+	l := NewLimiter(LimiterConfig{Enabled: true, InitialLimit: 1}, nil)
+	require.NotNil(t, l)
+	require.NoError(t, l.acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.acquire(ctx), context.DeadlineExceeded)
+
+	acquired := make(chan error)
+	go func() { acquired <- l.acquire(context.Background()) }()
+	l.release(nil, false)
+	assert.NoError(t, <-acquired)
+}
+
+func TestGuardLimitsConcurrency(t *testing.T) {
+	const limit = 3
+	l := NewLimiter(LimiterConfig{
+		Enabled:      true,
+		InitialLimit: limit,
+		MinLimit:     limit,
+		MaxLimit:     limit,
+	}, nil)
+	reqFunc := Guard(Config{}.RequestFunc(nil), nil, nil, l)
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		wg          sync.WaitGroup
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, reqFunc(context.Background(), func(context.Context) error {
+				mu.Lock()
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, maxInFlight, limit)
+}
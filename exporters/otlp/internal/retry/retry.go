@@ -14,12 +14,15 @@
 
 // Package retry provides request retry functionality that can perform
 // configurable exponential backoff for transient errors and honor any
-// explicit throttle responses received.
+// explicit throttle responses received. Requests can also be guarded by a
+// circuit breaker and an adaptive concurrency limiter shared across requests.
 package retry // import "github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -126,6 +129,54 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 	}
 }
 
+// Guard returns a RequestFunc making each request attempt of rf through the
+// circuit breaker b and the concurrency limiter l, which are not used if nil.
+// Attempts wait for l to allow them, and fail with ErrCircuitOpen without
+// being made while b is open. The evaluate function determines which failed
+// attempts count as failures of b, along with transport errors, and make l
+// decrease its limit. Other failed attempts, like requests rejected as
+// invalid, are neutral to b.
+func Guard(rf RequestFunc, evaluate EvaluateFunc, b *Breaker, l *Limiter) RequestFunc {
+	if b == nil && l == nil {
+		return rf
+	}
+	return func(ctx context.Context, fn func(context.Context) error) error {
+		return rf(ctx, func(ctx context.Context) error {
+			if l != nil {
+				if err := l.acquire(ctx); err != nil {
+					return err
+				}
+			}
+			if b != nil && !b.allow() {
+				if l != nil {
+					l.release(ErrCircuitOpen, false)
+				}
+				return ErrCircuitOpen
+			}
+
+			err := fn(ctx)
+			var retryable bool
+			if err != nil {
+				retryable, _ = evaluate(err)
+			}
+			if b != nil {
+				b.done(err, retryable || isTransportError(err))
+			}
+			if l != nil {
+				l.release(err, retryable)
+			}
+			return err
+		})
+	}
+}
+
+// isTransportError returns whether err is a network error of the transport
+// of a request, like a refused connection or a timeout.
+func isTransportError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // Allow override for testing.
 var waitFunc = wait
 
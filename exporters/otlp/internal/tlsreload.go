@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/middleware-labs/otel"
+)
+
+// CertificateReloader provides the client certificate of TLS connections
+// from a pair of PEM encoded certificate and key files. The files are loaded
+// again when their modification time changes, allowing certificates to be
+// rotated without recreating the exporter.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod time.Time
+	keyMod  time.Time
+}
+
+// NewCertificateReloader returns a CertificateReloader loading the client
+// certificate from certFile and keyFile. The files are loaded once, and any
+// error is sent to the global error handler.
+func NewCertificateReloader(certFile, keyFile string) *CertificateReloader {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		otel.Handle(err)
+	}
+	return r
+}
+
+// TLSConfig returns a copy of c, or of an empty configuration if c is nil,
+// using the certificate of r as client certificate.
+func (r *CertificateReloader) TLSConfig(c *tls.Config) *tls.Config {
+	if c == nil {
+		c = &tls.Config{}
+	} else {
+		c = c.Clone()
+	}
+	c.Certificates = nil
+	c.GetClientCertificate = r.GetClientCertificate
+	return c
+}
+
+// GetClientCertificate returns the client certificate, loading the files
+// again if they were modified since the last time they were loaded. The
+// previous certificate is returned if the modified files cannot be loaded,
+// for example if only one of them has been replaced yet.
+func (r *CertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.reload(); err != nil {
+		if r.cert == nil {
+			return nil, err
+		}
+		otel.Handle(err)
+	}
+	return r.cert, nil
+}
+
+// reload loads the certificate files if they were modified. It needs to be
+// called with mu held, or before r is shared.
+func (r *CertificateReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("read TLS client certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("read TLS client key: %w", err)
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS client certificate: %w", err)
+	}
+	r.cert, r.certMod, r.keyMod = &cert, certInfo.ModTime(), keyInfo.ModTime()
+	return nil
+}
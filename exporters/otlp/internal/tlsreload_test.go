@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix" // nolint:depguard  // This is for testing.
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a self-signed certificate with the common name cn and
+// its key to certFile and keyFile.
+func writeKeyPair(t *testing.T, cn, certFile, keyFile string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+}
+
+func commonName(t *testing.T, c *tls.Certificate) string {
+	t.Helper()
+	require.NotNil(t, c)
+	require.NotEmpty(t, c.Certificate)
+	leaf, err := x509.ParseCertificate(c.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writeKeyPair(t, "first", certFile, keyFile)
+
+	r := NewCertificateReloader(certFile, keyFile)
+	c, err := r.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName(t, c))
+
+	// Make sure the modification time changes.
+	writeKeyPair(t, "second", certFile, keyFile)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	c, err = r.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", commonName(t, c))
+
+	// The previous certificate is kept while the files are invalid.
+	require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0o600))
+	evenLater := later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, evenLater, evenLater))
+
+	c, err = r.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", commonName(t, c))
+}
+
+func TestCertificateReloaderMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	r := NewCertificateReloader(certFile, keyFile)
+	_, err := r.GetClientCertificate(nil)
+	assert.Error(t, err)
+
+	// The files are loaded once created.
+	writeKeyPair(t, "created", certFile, keyFile)
+	c, err := r.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "created", commonName(t, c))
+}
+
+func TestCertificateReloaderTLSConfig(t *testing.T) {
+	r := &CertificateReloader{}
+
+	c := r.TLSConfig(nil)
+	assert.NotNil(t, c.GetClientCertificate)
+
+	base := &tls.Config{
+		ServerName:   "collector",
+		Certificates: []tls.Certificate{{}},
+	}
+	c = r.TLSConfig(base)
+	assert.Equal(t, "collector", c.ServerName)
+	assert.Nil(t, c.Certificates)
+	assert.NotNil(t, c.GetClientCertificate)
+	assert.Len(t, base.Certificates, 1, "base configuration modified")
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oconf_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
+	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"github.com/middleware-labs/otel/sdk/metric"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConfigRequestFunc(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+	reader := metric.NewManualReader()
+	cfg := oconf.NewHTTPConfig(asHTTPOptions([]oconf.GenericOption{
+		oconf.WithMeterProvider(metric.NewMeterProvider(metric.WithReader(reader))),
+		oconf.WithRetry(retry.Config{Enabled: false}),
+		oconf.WithCircuitBreaker(retry.BreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 2,
+			OpenTimeout:      time.Hour,
+		}),
+		oconf.WithConcurrencyLimit(retry.LimiterConfig{
+			Enabled:      true,
+			InitialLimit: 4,
+		}),
+	})...)
+
+	errUnavailable := errors.New("unavailable")
+	evaluate := func(err error) (bool, time.Duration) {
+		return errors.Is(err, errUnavailable), 0
+	}
+	requestFunc := cfg.RequestFunc(evaluate, "scope")
+	ctx := context.Background()
+	var calls int
+	fail := func(context.Context) error {
+		calls++
+		return errUnavailable
+	}
+	assert.ErrorIs(t, requestFunc(ctx, fail), errUnavailable)
+	assert.ErrorIs(t, requestFunc(ctx, fail), errUnavailable)
+	assert.ErrorIs(t, requestFunc(ctx, fail), retry.ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], errUnavailable)
+	assert.ErrorContains(t, errs[0], "circuit breaker opened")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, "scope", rm.ScopeMetrics[0].Scope.Name)
+	want := []metricdata.Metrics{
+		{
+			Name:        "otlp.exporter.circuit_breaker.state",
+			Description: "The state of the circuit breaker of the OTLP exporter",
+			Unit:        "1",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("state", "closed")), Value: 0},
+					{Attributes: attribute.NewSet(attribute.String("state", "open")), Value: 1},
+				},
+			},
+		},
+		{
+			Name:        "otlp.exporter.concurrency.limit",
+			Description: "The limit of concurrent requests of the OTLP exporter",
+			Unit:        "{request}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints:  []metricdata.DataPoint[int64]{{Value: 1}},
+			},
+		},
+	}
+	require.Len(t, rm.ScopeMetrics[0].Metrics, len(want))
+	for i, m := range want {
+		metricdatatest.AssertEqual(t, m, rm.ScopeMetrics[0].Metrics[i], metricdatatest.IgnoreTimestamp())
+	}
+}
@@ -15,14 +15,22 @@
 package oconf_test
 
 import (
+	"crypto/tls"
 	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/envconfig"
+	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"github.com/middleware-labs/otel/metric/noop"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/aggregation"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
@@ -70,6 +78,26 @@ func TestConfigs(t *testing.T) {
 	tlsCert, err := oconf.CreateTLSConfig([]byte(WeakCertificate))
 	assert.NoError(t, err)
 
+	dir := t.TempDir()
+	clientCertFile := filepath.Join(dir, "client.crt")
+	clientKeyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(clientCertFile, []byte(WeakCertificate), 0o600))
+	assert.NoError(t, os.WriteFile(clientKeyFile, []byte(WeakPrivateKey), 0o600))
+	clientCert, err := tls.X509KeyPair([]byte(WeakCertificate), []byte(WeakPrivateKey))
+	assert.NoError(t, err)
+	assertClientCert := func(t *testing.T, c *oconf.Config, grpcOption bool) {
+		if grpcOption {
+			assert.NotNil(t, c.Metrics.GRPCCredentials)
+			assert.NotNil(t, c.Metrics.CertReloader)
+			return
+		}
+		if assert.NotNil(t, c.Metrics.TLSCfg) && assert.NotNil(t, c.Metrics.TLSCfg.GetClientCertificate) {
+			got, err := c.Metrics.TLSCfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+			assert.NoError(t, err)
+			assert.Equal(t, clientCert.Certificate, got.Certificate)
+		}
+	}
+
 	tests := []struct {
 		name       string
 		opts       []oconf.GenericOption
@@ -183,6 +211,33 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, true, c.Metrics.Insecure)
 			},
 		},
+		{
+			name: "Test Environment Endpoint with Unix scheme",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.True(t, c.Metrics.Insecure)
+				if !grpcOption {
+					assert.Equal(t, "unix:///var/run/otel.sock", c.Metrics.Endpoint)
+					assert.Equal(t, "/v1/metrics", c.Metrics.URLPath)
+				}
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Endpoint with Unix scheme",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":         "https://overrode.by.signal.specific/env/var",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.True(t, c.Metrics.Insecure)
+				if !grpcOption {
+					assert.Equal(t, "unix:///var/run/otel.sock", c.Metrics.Endpoint)
+					assert.Equal(t, "/v1/metrics", c.Metrics.URLPath)
+				}
+			},
+		},
 
 		// Certificate tests
 		{
@@ -265,6 +320,50 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test With Client Certificate Files",
+			opts: []oconf.GenericOption{
+				oconf.WithClientCertificateFiles(clientCertFile, clientKeyFile),
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         clientKeyFile,
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Signal Specific Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE":         "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":                 "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY":         clientKeyFile,
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Certificate and Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         clientKeyFile,
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assertClientCert(t, c, grpcOption)
+				if !grpcOption {
+					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
+					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Metrics.TLSCfg.RootCAs.Subjects())
+				}
+			},
+		},
+
 		// Headers tests
 		{
 			name: "Test With Headers",
@@ -344,6 +443,133 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.ZstdCompression, c.Metrics.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.SnappyCompression, c.Metrics.Compression)
+			},
+		},
+
+		// Max Request Size Tests
+		{
+			name: "Test With MaxRequestSize",
+			opts: []oconf.GenericOption{
+				oconf.WithMaxRequestSize(1024),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, 1024, c.Metrics.MaxRequestSize)
+			},
+		},
+
+		// MeterProvider Tests
+		{
+			name: "Test With MeterProvider",
+			opts: []oconf.GenericOption{
+				oconf.WithMeterProvider(noop.NewMeterProvider()),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, noop.NewMeterProvider(), c.MeterProvider)
+			},
+		},
+		{
+			name: "Test With Circuit Breaker and Concurrency Limit",
+			opts: []oconf.GenericOption{
+				oconf.WithCircuitBreaker(retry.BreakerConfig{Enabled: true, FailureThreshold: 3}),
+				oconf.WithConcurrencyLimit(retry.LimiterConfig{Enabled: true, MaxLimit: 10}),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, retry.BreakerConfig{Enabled: true, FailureThreshold: 3}, c.CircuitBreaker)
+				assert.Equal(t, retry.LimiterConfig{Enabled: true, MaxLimit: 10}, c.ConcurrencyLimit)
+			},
+		},
+		{
+			name: "Test With Authenticator",
+			opts: []oconf.GenericOption{
+				oconf.WithAuthenticator(internal.BasicAuth("user", "pass")),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, internal.BasicAuth("user", "pass"), c.Metrics.Authenticator)
+			},
+		},
+
+		// HTTP transport tests
+		{
+			name: "Test With HTTP Transport Options",
+			opts: []oconf.GenericOption{
+				oconf.WithHTTPClient(http.DefaultClient),
+				oconf.WithProxy(http.ProxyFromEnvironment),
+				oconf.WithDialer((&net.Dialer{}).DialContext),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Same(t, http.DefaultClient, c.Metrics.HTTPClient)
+				assert.NotNil(t, c.Metrics.Proxy)
+				assert.NotNil(t, c.Metrics.Dialer)
+			},
+		},
+
+		// Protocol Tests
+		{
+			name: "Test With Marshaler",
+			opts: []oconf.GenericOption{
+				oconf.WithMarshaler(oconf.MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/json",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment gRPC Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []oconf.GenericOption{
+				oconf.WithMarshaler(oconf.MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
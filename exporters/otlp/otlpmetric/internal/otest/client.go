@@ -26,6 +26,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/middleware-labs/otel"
+	ointernal "github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal"
 	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
 	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
@@ -271,6 +272,11 @@ func RunClientTests(f ClientFactory) func(*testing.T) {
 			require.Equal(t, 1, len(errs))
 			want := fmt.Sprintf("%s (%d metric data points rejected)", msg, n)
 			assert.ErrorContains(t, errs[0], want)
+
+			var psErr ointernal.PartialSuccess
+			require.ErrorAs(t, errs[0], &psErr)
+			assert.Equal(t, int64(n), psErr.RejectedItems)
+			assert.Equal(t, msg, psErr.ErrorMessage)
 		})
 	}
 }
@@ -35,10 +35,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
 	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
@@ -209,7 +213,9 @@ type HTTPCollector struct {
 // default OTLP metric endpoint path ("/v1/metrics"). If the endpoint contains
 // a prefix of "https" the server will generate weak self-signed TLS
 // certificates and use them to server data. If the endpoint contains a path,
-// that path will be used instead of the default OTLP metric endpoint path.
+// that path will be used instead of the default OTLP metric endpoint path. If
+// the endpoint is a unix URL, the server will listen on the Unix domain socket
+// at its path, and at the default OTLP metric endpoint path.
 //
 // If errCh is not nil, the collector will respond to HTTP requests with errors
 // sent on that channel. This means that if errCh is not nil Export calls will
@@ -219,8 +225,12 @@ func NewHTTPCollector(endpoint string, resultCh <-chan ExportResult) (*HTTPColle
 	if err != nil {
 		return nil, err
 	}
-	if u.Host == "" {
-		u.Host = "localhost:0"
+	network, addr := "tcp", u.Host
+	if u.Scheme == "unix" {
+		network, addr = "unix", u.Path
+		u.Path = ""
+	} else if addr == "" {
+		addr = "localhost:0"
 	}
 	if u.Path == "" {
 		u.Path = oconf.DefaultMetricsPath
@@ -232,7 +242,7 @@ func NewHTTPCollector(endpoint string, resultCh <-chan ExportResult) (*HTTPColle
 		resultCh: resultCh,
 	}
 
-	c.listener, err = net.Listen("tcp", u.Host)
+	c.listener, err = net.Listen(network, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -280,13 +290,14 @@ func (c *HTTPCollector) Headers() map[string][]string {
 }
 
 func (c *HTTPCollector) handler(w http.ResponseWriter, r *http.Request) {
-	c.respond(w, c.record(r))
+	c.respond(w, r.Header.Get("Content-Type"), c.record(r))
 }
 
 func (c *HTTPCollector) record(r *http.Request) ExportResult {
-	// Currently only supports protobuf.
-	if v := r.Header.Get("Content-Type"); v != "application/x-protobuf" {
-		err := fmt.Errorf("content-type not supported: %s", v)
+	// Supports protobuf and OTLP/JSON.
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/x-protobuf" && contentType != "application/json" {
+		err := fmt.Errorf("content-type not supported: %s", contentType)
 		return ExportResult{Err: err}
 	}
 
@@ -295,7 +306,14 @@ func (c *HTTPCollector) record(r *http.Request) ExportResult {
 		return ExportResult{Err: err}
 	}
 	pbRequest := &collpb.ExportMetricsServiceRequest{}
-	err = proto.Unmarshal(body, pbRequest)
+	if contentType == "application/json" {
+		body, err = internal.Base64EncodeIDs(body)
+		if err == nil {
+			err = protojson.Unmarshal(body, pbRequest)
+		}
+	} else {
+		err = proto.Unmarshal(body, pbRequest)
+	}
 	if err != nil {
 		return ExportResult{
 			Err: &HTTPResponseError{
@@ -332,6 +350,17 @@ func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 				Status: http.StatusInternalServerError,
 			}
 		}
+	case "zstd":
+		decoder, err := zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, &HTTPResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = decoder.IOReadCloser()
+	case "snappy":
+		reader = io.NopCloser(snappy.NewReader(r.Body))
 	default:
 		reader = r.Body
 	}
@@ -355,7 +384,7 @@ func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 	return body, err
 }
 
-func (c *HTTPCollector) respond(w http.ResponseWriter, resp ExportResult) {
+func (c *HTTPCollector) respond(w http.ResponseWriter, contentType string, resp ExportResult) {
 	if resp.Err != nil {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -375,6 +404,21 @@ func (c *HTTPCollector) respond(w http.ResponseWriter, resp ExportResult) {
 		return
 	}
 
+	if contentType == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		response := resp.Response
+		if response == nil {
+			response = &collpb.ExportMetricsServiceResponse{}
+		}
+		r, err := protojson.Marshal(response)
+		if err != nil {
+			panic(err)
+		}
+		_, _ = w.Write(r)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/x-protobuf")
 	w.WriteHeader(http.StatusOK)
 	if resp.Response == nil {
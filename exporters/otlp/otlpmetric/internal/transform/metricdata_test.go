@@ -201,6 +201,46 @@ var (
 		DataPoints:             pbDPtsFloat64,
 	}
 
+	otelSummary = metricdata.Summary{
+		DataPoints: []metricdata.SummaryDataPoint{{
+			Attributes: alice,
+			StartTime:  start,
+			Time:       end,
+			Count:      30,
+			Sum:        sumA,
+			QuantileValues: []metricdata.QuantileValue{
+				{Quantile: 0.5, Value: 3},
+				{Quantile: 0.99, Value: 4},
+			},
+		}, {
+			Attributes: bob,
+			StartTime:  start,
+			Time:       end,
+			Count:      3,
+			Sum:        sumB,
+		}},
+	}
+
+	pbSummary = &mpb.Summary{
+		DataPoints: []*mpb.SummaryDataPoint{{
+			Attributes:        []*cpb.KeyValue{pbAlice},
+			StartTimeUnixNano: uint64(start.UnixNano()),
+			TimeUnixNano:      uint64(end.UnixNano()),
+			Count:             30,
+			Sum:               sumA,
+			QuantileValues: []*mpb.SummaryDataPoint_ValueAtQuantile{
+				{Quantile: 0.5, Value: 3},
+				{Quantile: 0.99, Value: 4},
+			},
+		}, {
+			Attributes:        []*cpb.KeyValue{pbBob},
+			StartTimeUnixNano: uint64(start.UnixNano()),
+			TimeUnixNano:      uint64(end.UnixNano()),
+			Count:             3,
+			Sum:               sumB,
+		}},
+	}
+
 	otelGaugeInt64   = metricdata.Gauge[int64]{DataPoints: otelDPtsInt64}
 	otelGaugeFloat64 = metricdata.Gauge[float64]{DataPoints: otelDPtsFloat64}
 
@@ -257,6 +297,12 @@ var (
 			Unit:        "1",
 			Data:        otelHistInvalid,
 		},
+		{
+			Name:        "summary",
+			Description: "Summary",
+			Unit:        "1",
+			Data:        otelSummary,
+		},
 		{
 			Name:        "unknown",
 			Description: "Unknown aggregation",
@@ -302,6 +348,12 @@ var (
 			Unit:        "1",
 			Data:        &mpb.Metric_Histogram{Histogram: pbHist},
 		},
+		{
+			Name:        "summary",
+			Description: "Summary",
+			Unit:        "1",
+			Data:        &mpb.Metric_Summary{Summary: pbSummary},
+		},
 	}
 
 	otelScopeMetrics = []metricdata.ScopeMetrics{{
@@ -390,6 +442,7 @@ func TestTransformations(t *testing.T) {
 	assert.ErrorIs(t, err, errUnknownTemporality)
 	assert.Nil(t, s)
 
+	assert.Equal(t, &mpb.Metric_Summary{Summary: pbSummary}, Summary(otelSummary))
 	assert.Equal(t, &mpb.Metric_Gauge{Gauge: pbGaugeInt64}, Gauge[int64](otelGaugeInt64))
 	require.Equal(t, &mpb.Metric_Gauge{Gauge: pbGaugeFloat64}, Gauge[float64](otelGaugeFloat64))
 
@@ -411,3 +464,40 @@ func TestTransformations(t *testing.T) {
 	assert.ErrorIs(t, err, errUnknownAggregation)
 	require.Equal(t, pbResourceMetrics, rm)
 }
+
+func TestExemplars(t *testing.T) {
+	spanID := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	traceID := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	otelExemplars := []metricdata.Exemplar[float64]{{
+		FilteredAttributes: []attribute.KeyValue{attribute.String("user", "alice")},
+		Time:               end,
+		Value:              2.5,
+		SpanID:             spanID,
+		TraceID:            traceID,
+	}}
+	pbExemplars := []*mpb.Exemplar{{
+		FilteredAttributes: []*cpb.KeyValue{pbAlice},
+		TimeUnixNano:       uint64(end.UnixNano()),
+		Value:              &mpb.Exemplar_AsDouble{AsDouble: 2.5},
+		SpanId:             spanID,
+		TraceId:            traceID,
+	}}
+	assert.Equal(t, pbExemplars, Exemplars(otelExemplars))
+	assert.Equal(t, []*mpb.Exemplar{{
+		TimeUnixNano: uint64(end.UnixNano()),
+		Value:        &mpb.Exemplar_AsInt{AsInt: 3},
+	}}, Exemplars([]metricdata.Exemplar[int64]{{Time: end, Value: 3}}))
+	assert.Nil(t, Exemplars[int64](nil))
+
+	hdp := otelHDPFloat64[0]
+	hdp.Exemplars = otelExemplars
+	got := HistogramDataPoints([]metricdata.HistogramDataPoint[float64]{hdp})
+	require.Len(t, got, 1)
+	assert.Equal(t, pbExemplars, got[0].Exemplars)
+
+	dp := otelDPtsFloat64[0]
+	dp.Exemplars = otelExemplars
+	gotDP := DataPoints([]metricdata.DataPoint[float64]{dp})
+	require.Len(t, gotDP, 1)
+	assert.Equal(t, pbExemplars, gotDP[0].Exemplars)
+}
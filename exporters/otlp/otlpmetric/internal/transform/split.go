@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform // import "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/transform"
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// ErrDataPointTooLarge is returned by Split for data points that exceed the
+// maximum request size on their own.
+var ErrDataPointTooLarge = errors.New("data point exceeds the maximum request size")
+
+// pointIndex locates a data point in a ResourceMetrics. A point of -1
+// represents a metric without data points.
+type pointIndex struct {
+	scope, metric, point int
+}
+
+// Split splits rm into ResourceMetrics that each serialize to an
+// ExportMetricsServiceRequest of at most maxSize bytes, as measured by size
+// with the encoding of the requests (proto.Size for Protobuf). rm is returned
+// as the only ResourceMetrics if maxSize is not positive or the request is
+// small enough.
+//
+// Data points that exceed maxSize on their own are dropped and an error
+// wrapping ErrDataPointTooLarge is returned with the other data points.
+func Split(rm *mpb.ResourceMetrics, maxSize int, size func(proto.Message) int) ([]*mpb.ResourceMetrics, error) {
+	if maxSize <= 0 || requestSize(rm, size) <= maxSize {
+		return []*mpb.ResourceMetrics{rm}, nil
+	}
+
+	var idx []pointIndex
+	for s, sm := range rm.ScopeMetrics {
+		for m, metric := range sm.Metrics {
+			n := dataPointsLen(metric)
+			if n == 0 {
+				idx = append(idx, pointIndex{scope: s, metric: m, point: -1})
+			}
+			for p := 0; p < n; p++ {
+				idx = append(idx, pointIndex{scope: s, metric: m, point: p})
+			}
+		}
+	}
+
+	var (
+		out     []*mpb.ResourceMetrics
+		dropped int
+	)
+	var split func([]pointIndex)
+	split = func(idx []pointIndex) {
+		if len(idx) == 0 {
+			return
+		}
+		sub := subset(rm, idx)
+		if requestSize(sub, size) <= maxSize {
+			out = append(out, sub)
+			return
+		}
+		if len(idx) == 1 {
+			dropped++
+			return
+		}
+		split(idx[:len(idx)/2])
+		split(idx[len(idx)/2:])
+	}
+	split(idx)
+
+	if dropped > 0 {
+		return out, fmt.Errorf("%w: %d data points dropped (max %d bytes)", ErrDataPointTooLarge, dropped, maxSize)
+	}
+	return out, nil
+}
+
+func requestSize(rm *mpb.ResourceMetrics, size func(proto.Message) int) int {
+	return size(&cmpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{rm},
+	})
+}
+
+func dataPointsLen(m *mpb.Metric) int {
+	switch d := m.Data.(type) {
+	case *mpb.Metric_Gauge:
+		return len(d.Gauge.GetDataPoints())
+	case *mpb.Metric_Sum:
+		return len(d.Sum.GetDataPoints())
+	case *mpb.Metric_Histogram:
+		return len(d.Histogram.GetDataPoints())
+	case *mpb.Metric_ExponentialHistogram:
+		return len(d.ExponentialHistogram.GetDataPoints())
+	case *mpb.Metric_Summary:
+		return len(d.Summary.GetDataPoints())
+	}
+	return 0
+}
+
+// subset returns a ResourceMetrics containing only the data points of rm
+// located by idx. idx must be ordered.
+func subset(rm *mpb.ResourceMetrics, idx []pointIndex) *mpb.ResourceMetrics {
+	out := &mpb.ResourceMetrics{
+		Resource:  rm.Resource,
+		SchemaUrl: rm.SchemaUrl,
+	}
+	var (
+		sm     *mpb.ScopeMetrics
+		points []int
+	)
+	last := pointIndex{scope: -1, metric: -1}
+	flush := func() {
+		if last.metric < 0 {
+			return
+		}
+		src := rm.ScopeMetrics[last.scope].Metrics[last.metric]
+		sm.Metrics = append(sm.Metrics, withDataPoints(src, points))
+		points = nil
+	}
+	for _, i := range idx {
+		if i.scope != last.scope || i.metric != last.metric {
+			flush()
+		}
+		if i.scope != last.scope {
+			src := rm.ScopeMetrics[i.scope]
+			sm = &mpb.ScopeMetrics{
+				Scope:     src.Scope,
+				SchemaUrl: src.SchemaUrl,
+			}
+			out.ScopeMetrics = append(out.ScopeMetrics, sm)
+		}
+		if i.point >= 0 {
+			points = append(points, i.point)
+		}
+		last = i
+	}
+	flush()
+	return out
+}
+
+// withDataPoints returns a copy of m containing only the data points at
+// the indexes in points.
+func withDataPoints(m *mpb.Metric, points []int) *mpb.Metric {
+	out := &mpb.Metric{
+		Name:        m.Name,
+		Description: m.Description,
+		Unit:        m.Unit,
+	}
+	switch d := m.Data.(type) {
+	case *mpb.Metric_Gauge:
+		out.Data = &mpb.Metric_Gauge{Gauge: &mpb.Gauge{
+			DataPoints: pick(d.Gauge.DataPoints, points),
+		}}
+	case *mpb.Metric_Sum:
+		out.Data = &mpb.Metric_Sum{Sum: &mpb.Sum{
+			DataPoints:             pick(d.Sum.DataPoints, points),
+			AggregationTemporality: d.Sum.AggregationTemporality,
+			IsMonotonic:            d.Sum.IsMonotonic,
+		}}
+	case *mpb.Metric_Histogram:
+		out.Data = &mpb.Metric_Histogram{Histogram: &mpb.Histogram{
+			DataPoints:             pick(d.Histogram.DataPoints, points),
+			AggregationTemporality: d.Histogram.AggregationTemporality,
+		}}
+	case *mpb.Metric_ExponentialHistogram:
+		out.Data = &mpb.Metric_ExponentialHistogram{ExponentialHistogram: &mpb.ExponentialHistogram{
+			DataPoints:             pick(d.ExponentialHistogram.DataPoints, points),
+			AggregationTemporality: d.ExponentialHistogram.AggregationTemporality,
+		}}
+	case *mpb.Metric_Summary:
+		out.Data = &mpb.Metric_Summary{Summary: &mpb.Summary{
+			DataPoints: pick(d.Summary.DataPoints, points),
+		}}
+	default:
+		out.Data = m.Data
+	}
+	return out
+}
+
+func pick[T any](s []T, idx []int) []T {
+	out := make([]T, len(idx))
+	for i, j := range idx {
+		out[i] = s[j]
+	}
+	return out
+}
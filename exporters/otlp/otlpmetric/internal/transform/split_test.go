@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+func splitTestData() *mpb.ResourceMetrics {
+	point := func(v string, size int) *mpb.NumberDataPoint {
+		return &mpb.NumberDataPoint{
+			Attributes: []*cpb.KeyValue{{
+				Key:   v,
+				Value: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: strings.Repeat("x", size)}},
+			}},
+			Value: &mpb.NumberDataPoint_AsInt{AsInt: 1},
+		}
+	}
+	return &mpb.ResourceMetrics{
+		Resource:  &rpb.Resource{Attributes: []*cpb.KeyValue{{Key: "r", Value: &cpb.AnyValue{Value: &cpb.AnyValue_IntValue{IntValue: 1}}}}},
+		SchemaUrl: "resource",
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{
+				Scope: &cpb.InstrumentationScope{Name: "a"},
+				Metrics: []*mpb.Metric{
+					{
+						Name: "sum",
+						Data: &mpb.Metric_Sum{Sum: &mpb.Sum{
+							AggregationTemporality: mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+							IsMonotonic:            true,
+							DataPoints:             []*mpb.NumberDataPoint{point("s1", 100), point("s2", 100), point("s3", 100)},
+						}},
+					},
+					{Name: "empty", Data: &mpb.Metric_Gauge{Gauge: &mpb.Gauge{}}},
+				},
+			},
+			{
+				Scope: &cpb.InstrumentationScope{Name: "b"},
+				Metrics: []*mpb.Metric{{
+					Name: "gauge",
+					Data: &mpb.Metric_Gauge{Gauge: &mpb.Gauge{
+						DataPoints: []*mpb.NumberDataPoint{point("g1", 100), point("g2", 1000), point("g3", 100)},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+// pointNames returns the attribute keys of the data points in rms, prefixed
+// with the scope and metric names.
+func pointNames(t *testing.T, rms []*mpb.ResourceMetrics) []string {
+	var names []string
+	for _, rm := range rms {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				var pts []*mpb.NumberDataPoint
+				switch d := m.Data.(type) {
+				case *mpb.Metric_Sum:
+					assert.True(t, d.Sum.IsMonotonic)
+					assert.Equal(t, mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, d.Sum.AggregationTemporality)
+					pts = d.Sum.DataPoints
+				case *mpb.Metric_Gauge:
+					pts = d.Gauge.DataPoints
+				}
+				if len(pts) == 0 {
+					names = append(names, sm.Scope.Name+":"+m.Name)
+				}
+				for _, p := range pts {
+					names = append(names, sm.Scope.Name+":"+m.Name+":"+p.Attributes[0].Key)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestSplitNoLimit(t *testing.T) {
+	rm := splitTestData()
+	for _, max := range []int{0, -1, requestSize(rm, proto.Size)} {
+		out, err := Split(rm, max, proto.Size)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		assert.Same(t, rm, out[0])
+	}
+}
+
+func TestSplit(t *testing.T) {
+	rm := splitTestData()
+	const max = 400
+	out, err := Split(rm, max, proto.Size)
+	assert.ErrorIs(t, err, ErrDataPointTooLarge)
+
+	assert.Greater(t, len(out), 1)
+	for _, sub := range out {
+		assert.LessOrEqual(t, requestSize(sub, proto.Size), max)
+		assert.Same(t, rm.Resource, sub.Resource)
+		assert.Equal(t, rm.SchemaUrl, sub.SchemaUrl)
+	}
+	// All data points but the one too large are kept, in order.
+	assert.Equal(t, []string{
+		"a:sum:s1",
+		"a:sum:s2",
+		"a:sum:s3",
+		"a:empty",
+		"b:gauge:g1",
+		"b:gauge:g3",
+	}, pointNames(t, out))
+}
@@ -16,6 +16,7 @@ package otlpmetricgrpc // import "github.com/middleware-labs/otel/exporters/otlp
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -23,12 +24,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	ominternal "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/transform"
+	"github.com/middleware-labs/otel/metric/instrument"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/aggregation"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
@@ -52,10 +56,23 @@ func New(ctx context.Context, options ...Option) (metric.Exporter, error) {
 	return ominternal.New(c), nil
 }
 
+// instrumentationName is the name of the Meter reporting the data points
+// rejected by the receiver and the state of the circuit breaker and the
+// concurrency limiter.
+const instrumentationName = "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+
+// PartialSuccessError is the error passed to the global error handler when
+// the receiver only accepts part of the exported data points. Use errors.As to
+// read the number of rejected data points and the message of the receiver.
+type PartialSuccessError = internal.PartialSuccess
+
 type client struct {
 	metadata      metadata.MD
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
+	maxSize       int
+	auth          internal.Authenticator
+	rejected      instrument.Int64Counter
 
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
@@ -75,7 +92,10 @@ func newClient(ctx context.Context, options ...Option) (ominternal.Client, error
 
 	c := &client{
 		exportTimeout: cfg.Metrics.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
+		requestFunc:   cfg.RequestFunc(retryable, instrumentationName),
+		maxSize:       cfg.Metrics.MaxRequestSize,
+		auth:          cfg.Metrics.Authenticator,
+		rejected:      internal.RejectedDataPointsCounter(cfg.MeterProvider, instrumentationName),
 		conn:          cfg.GRPCConn,
 
 		temporalitySelector: cfg.Metrics.TemporalitySelector,
@@ -163,13 +183,26 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
+	groups, splitErr := transform.Split(protoMetrics, c.maxSize, proto.Size)
+	for _, rm := range groups {
+		if err := c.uploadMetrics(ctx, rm); err != nil {
+			return err
+		}
+	}
+	return splitErr
+}
+
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		resp, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
+		resp, err := c.export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
 			ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 		})
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedDataPoints()
+			if n > 0 {
+				c.rejected.Add(iCtx, n)
+			}
 			if n != 0 || msg != "" {
 				err := internal.MetricPartialSuccessError(n, msg)
 				otel.Handle(err)
@@ -184,6 +217,45 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	})
 }
 
+// export sends req with the credentials of the Authenticator, if any. A
+// request rejected as unauthenticated is sent again once after the
+// credentials are refreshed.
+func (c *client) export(ctx context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	if c.auth == nil {
+		return c.msc.Export(ctx, req)
+	}
+	authCtx, err := c.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.msc.Export(authCtx, req)
+	if status.Code(err) != codes.Unauthenticated {
+		return resp, err
+	}
+
+	if rErr := c.auth.Refresh(ctx); rErr != nil {
+		return resp, fmt.Errorf("%w: refresh credentials: %v", err, rErr)
+	}
+	if authCtx, err = c.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return c.msc.Export(authCtx, req)
+}
+
+// authenticate returns a copy of ctx with the headers of the Authenticator
+// appended to its outgoing metadata.
+func (c *client) authenticate(ctx context.Context) (context.Context, error) {
+	headers, err := c.auth.Headers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+	kv := make([]string, 0, 2*len(headers))
+	for k, v := range headers {
+		kv = append(kv, k, v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
+
 // exportContext returns a copy of parent with an appropriate deadline and
 // cancellation function based on the clients configured export timeout.
 //
@@ -16,6 +16,8 @@ package otlpmetricgrpc
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,10 +29,13 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
 	ominternal "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/otest"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 )
 
 func TestThrottleDuration(t *testing.T) {
@@ -191,6 +196,118 @@ func TestConfig(t *testing.T) {
 		assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
 	})
 
+	t.Run("WithCompressorZstd", func(t *testing.T) {
+		exp, coll := factoryFunc(nil, WithCompressor("zstd"))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	t.Run("WithMaxRequestSize", func(t *testing.T) {
+		exp, coll := factoryFunc(nil, WithMaxRequestSize(64))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "sum",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.Int("n", 1)), Value: 1},
+						{Attributes: attribute.NewSet(attribute.Int("n", 2)), Value: 2},
+						{Attributes: attribute.NewSet(attribute.Int("n", 3)), Value: 3},
+					},
+				},
+			}},
+		}}}
+		assert.NoError(t, exp.Export(ctx, rm))
+		// Each data point is sent in its own request.
+		assert.Len(t, coll.Collect().Dump(), 3)
+	})
+
+	t.Run("WithMeterProvider", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 1)
+		rCh <- otest.ExportResult{Response: &collpb.ExportMetricsServiceResponse{
+			PartialSuccess: &collpb.ExportMetricsPartialSuccess{RejectedDataPoints: 2},
+		}}
+		var errs []error
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+		reader := metric.NewManualReader()
+		mp := metric.NewMeterProvider(metric.WithReader(reader))
+		exp, coll := factoryFunc(rCh, WithMeterProvider(mp))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+
+		require.Len(t, errs, 1)
+		var psErr PartialSuccessError
+		require.ErrorAs(t, errs[0], &psErr)
+		assert.Equal(t, int64(2), psErr.RejectedItems)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, instrumentationName, rm.ScopeMetrics[0].Scope.Name)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		got := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "otlp.exporter.data_points.rejected", got.Name)
+		require.IsType(t, metricdata.Sum[int64]{}, got.Data)
+		sum := got.Data.(metricdata.Sum[int64])
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+	})
+
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		rCh <- otest.ExportResult{Err: status.Error(codes.Unauthenticated, "token expired")}
+		rCh <- otest.ExportResult{}
+		exp, coll := factoryFunc(rCh, WithAuthenticator(&refreshingAuthenticator{}))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+
+		got := coll.Headers()["authorization"]
+		assert.Equal(t, []string{"Bearer token-0", "Bearer token-1"}, got)
+	})
+
+	t.Run("WithCircuitBreaker", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		for i := 0; i < 2; i++ {
+			rCh <- otest.ExportResult{Err: status.Error(codes.Unavailable, "unavailable")}
+		}
+		var errs []error
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+		exp, coll := factoryFunc(rCh,
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				// Never stop retrying.
+				MaxElapsedTime: 0,
+			}),
+			WithCircuitBreaker(CircuitBreakerConfig{
+				Enabled:          true,
+				FailureThreshold: 2,
+				OpenTimeout:      time.Hour,
+			}),
+			WithConcurrencyLimit(ConcurrencyLimitConfig{Enabled: true}),
+		)
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		err := exp.Export(ctx, &metricdata.ResourceMetrics{})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "circuit breaker opened")
+		assert.Len(t, rCh, 0, "failed responses did not occur")
+	})
+
 	t.Run("WithCustomUserAgent", func(t *testing.T) {
 		key := "user-agent"
 		customerUserAgent := "custom-user-agent"
@@ -205,3 +322,18 @@ func TestConfig(t *testing.T) {
 		assert.Contains(t, got[key][0], customerUserAgent)
 	})
 }
+
+// refreshingAuthenticator sends a numbered token, incremented when it is
+// refreshed.
+type refreshingAuthenticator struct {
+	n int64
+}
+
+func (a *refreshingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", atomic.LoadInt64(&a.n))}, nil
+}
+
+func (a *refreshingAuthenticator) Refresh(context.Context) error {
+	atomic.AddInt64(&a.n, 1)
+	return nil
+}
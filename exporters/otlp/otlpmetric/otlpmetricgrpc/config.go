@@ -24,6 +24,7 @@ import (
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	otelmetric "github.com/middleware-labs/otel/metric"
 	"github.com/middleware-labs/otel/sdk/metric"
 )
 
@@ -47,6 +48,24 @@ func asGRPCOptions(opts []Option) []oconf.GRPCOption {
 // entirely handled by the gRPC ClientConn.
 type RetryConfig retry.Config
 
+// CircuitBreakerConfig defines the configuration of a circuit breaker shared
+// by the export requests of the exporter. After FailureThreshold consecutive
+// failed request attempts, the breaker opens and exports fail without being
+// attempted, instead of exhausting their retries, for OpenTimeout. A single
+// trial request is then let through, closing the breaker if it succeeds.
+type CircuitBreakerConfig retry.BreakerConfig
+
+// ConcurrencyLimitConfig defines the configuration of an adaptive limit of
+// the concurrent export requests of the exporter. The limit is increased by
+// one after about limit successful requests, and multiplied by
+// DecreaseFactor after a request fails with a retryable error, between
+// MinLimit and MaxLimit.
+type ConcurrencyLimitConfig retry.LimiterConfig
+
+// ErrCircuitOpen is returned by exports failed without being attempted
+// because the circuit breaker set with WithCircuitBreaker is open.
+var ErrCircuitOpen = retry.ErrCircuitOpen
+
 type wrappedOption struct {
 	oconf.GRPCOption
 }
@@ -100,8 +119,13 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) oconf.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return oconf.GzipCompression
+	case oconf.ZstdName:
+		return oconf.ZstdCompression
+	case oconf.SnappyName:
+		return oconf.SnappyCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -117,10 +141,12 @@ func compressorToCompression(compressor string) oconf.Compression {
 //
 //	import _ "google.golang.org/grpc/encoding/gzip"
 //
+// The "zstd" and "snappy" compressors are registered by this package.
+//
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -146,6 +172,18 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{oconf.WithHeaders(headers)}
 }
 
+// WithAuthenticator sets the Authenticator providing the credentials of each
+// export request. Its headers are sent as metadata of the gRPC requests, in
+// addition to the headers of WithHeaders, and it is asked to refresh the
+// credentials when a request is rejected with an Unauthenticated status.
+// BasicAuth, BearerTokenFile, and ClientCredentials return the provided
+// implementations.
+//
+// This option also applies if WithGRPCConn is used.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{oconf.WithAuthenticator(a)}
+}
+
 // WithTLSCredentials sets the gRPC connection to use creds.
 //
 // If the OTEL_EXPORTER_OTLP_CERTIFICATE or
@@ -161,10 +199,33 @@ func WithHeaders(headers map[string]string) Option {
 func WithTLSCredentials(creds credentials.TransportCredentials) Option {
 	return wrappedOption{oconf.NewGRPCOption(func(cfg oconf.Config) oconf.Config {
 		cfg.Metrics.GRPCCredentials = creds
+		cfg.Metrics.CertReloader = nil
 		return cfg
 	})}
 }
 
+// WithClientCertificateFiles sets the files of the PEM encoded client
+// certificate and key used for mutual TLS. The files are loaded again on new
+// TLS handshakes when they have been modified, so rotated certificates are
+// used without recreating the exporter. The client certificates of the TLS
+// configuration are replaced.
+//
+// If the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_CLIENT_KEY, or the
+// OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY environment variables are set, and
+// this option is not passed, those files will be used. If both are set, the
+// METRICS variables will take precedence.
+//
+// By default, if the environment variables are not set, and this option is
+// not passed, no client certificate is used.
+//
+// Passing WithTLSCredentials after this option disables it. This option has
+// no effect if WithGRPCConn is used.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{oconf.WithClientCertificateFiles(certFile, keyFile)}
+}
+
 // WithServiceConfig defines the default gRPC service config used.
 //
 // This option has no effect if WithGRPCConn is used.
@@ -221,6 +282,16 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{oconf.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size in bytes of the Protobuf
+// serialization of an export request, before compression. Metrics exceeding
+// it are split into several requests, down to individual data points. Data
+// points exceeding it on their own are dropped and reported as an error.
+//
+// By default, the request size is not limited.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that are
 // returned by the target endpoint.
 //
@@ -238,6 +309,33 @@ func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{oconf.WithRetry(retry.Config(settings))}
 }
 
+// WithCircuitBreaker sets the circuit breaker shared by the export requests,
+// failing exports with ErrCircuitOpen while the receiver is unavailable. The
+// opening of the breaker is reported to the global error handler, and its
+// state with the otlp.exporter.circuit_breaker.state up-down counter. If
+// unset, no circuit breaker is used.
+func WithCircuitBreaker(c CircuitBreakerConfig) Option {
+	return wrappedOption{oconf.WithCircuitBreaker(retry.BreakerConfig(c))}
+}
+
+// WithConcurrencyLimit sets the adaptive limit of concurrent export requests.
+// Requests exceeding the limit wait for other requests to complete. The limit
+// is reported with the otlp.exporter.concurrency.limit up-down counter. If
+// unset, the concurrency of export requests is not limited.
+func WithConcurrencyLimit(c ConcurrencyLimitConfig) Option {
+	return wrappedOption{oconf.WithConcurrencyLimit(retry.LimiterConfig(c))}
+}
+
+// WithMeterProvider sets the MeterProvider used to report the number of data
+// points rejected by the receiver in partial success responses, with the
+// otlp.exporter.data_points.rejected counter, and the state of the circuit
+// breaker and the concurrency limit.
+//
+// By default, the global MeterProvider is used.
+func WithMeterProvider(mp otelmetric.MeterProvider) Option {
+	return wrappedOption{oconf.WithMeterProvider(mp)}
+}
+
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
 // determine the Temporality of an instrument based on its kind. If this option
 // is not used, the client will use the DefaultTemporalitySelector from the
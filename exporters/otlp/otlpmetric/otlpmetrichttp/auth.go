@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetrichttp // import "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+
+import "github.com/middleware-labs/otel/exporters/otlp/internal"
+
+// Authenticator provides the credentials of export requests. Headers is
+// called before each request is sent, and the returned headers are added to
+// the request. Refresh is called when a request is rejected as
+// unauthenticated, and the request is sent again once if it returns nil.
+type Authenticator = internal.Authenticator
+
+// BasicAuth returns an Authenticator using the HTTP Basic authentication
+// scheme with username and password.
+func BasicAuth(username, password string) Authenticator {
+	return internal.BasicAuth(username, password)
+}
+
+// BearerTokenFile returns an Authenticator sending the token read from path
+// with the Bearer authentication scheme. The file is read again when it is
+// modified, and when the token is refreshed, so rotated tokens are used
+// without recreating the exporter.
+func BearerTokenFile(path string) Authenticator {
+	return internal.BearerTokenFile(path)
+}
+
+// ClientCredentialsConfig configures the OAuth 2.0 client credentials grant.
+type ClientCredentialsConfig = internal.ClientCredentialsConfig
+
+// ClientCredentials returns an Authenticator sending access tokens obtained
+// from the token endpoint of cfg with the OAuth 2.0 client credentials grant.
+// Access tokens are cached until they expire, or until the receiver rejects
+// them.
+func ClientCredentials(cfg ClientCredentialsConfig) Authenticator {
+	return internal.ClientCredentials(cfg)
+}
@@ -22,11 +22,11 @@ import (
 	"io"
 	"net"
 	"net/http"
-	"net/url"
 	"strconv"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/middleware-labs/otel"
@@ -34,6 +34,8 @@ import (
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	ominternal "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/transform"
+	"github.com/middleware-labs/otel/metric/instrument"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/aggregation"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
@@ -52,12 +54,26 @@ func New(_ context.Context, opts ...Option) (metric.Exporter, error) {
 	return ominternal.New(c), nil
 }
 
+// instrumentationName is the name of the Meter reporting the data points
+// rejected by the receiver and the state of the circuit breaker and the
+// concurrency limiter.
+const instrumentationName = "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+
+// PartialSuccessError is the error passed to the global error handler when
+// the receiver only accepts part of the exported data points. Use errors.As to
+// read the number of rejected data points and the message of the receiver.
+type PartialSuccessError = internal.PartialSuccess
+
 type client struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
 	compression Compression
+	encoding    Encoding
 	requestFunc retry.RequestFunc
 	httpClient  *http.Client
+	maxSize     int
+	auth        internal.Authenticator
+	rejected    instrument.Int64Counter
 
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
@@ -84,24 +100,20 @@ var ourTransport = &http.Transport{
 func newClient(opts ...Option) (ominternal.Client, error) {
 	cfg := oconf.NewHTTPConfig(asHTTPOptions(opts)...)
 
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Metrics.Timeout,
-	}
-	if cfg.Metrics.TLSCfg != nil {
-		transport := ourTransport.Clone()
-		transport.TLSClientConfig = cfg.Metrics.TLSCfg
-		httpClient.Transport = transport
+	httpClient := cfg.Metrics.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Transport: internal.HTTPTransport(ourTransport, internal.HTTPTransportConfig{
+				Endpoint: cfg.Metrics.Endpoint,
+				TLSCfg:   cfg.Metrics.TLSCfg,
+				Proxy:    cfg.Metrics.Proxy,
+				Dialer:   cfg.Metrics.Dialer,
+			}),
+			Timeout: cfg.Metrics.Timeout,
+		}
 	}
 
-	u := &url.URL{
-		Scheme: "https",
-		Host:   cfg.Metrics.Endpoint,
-		Path:   cfg.Metrics.URLPath,
-	}
-	if cfg.Metrics.Insecure {
-		u.Scheme = "http"
-	}
+	u := internal.HTTPRequestURL(cfg.Metrics.Endpoint, cfg.Metrics.URLPath, cfg.Metrics.Insecure)
 	// Body is set when this is cloned during upload.
 	req, err := http.NewRequest(http.MethodPost, u.String(), http.NoBody)
 	if err != nil {
@@ -115,13 +127,22 @@ func newClient(opts ...Option) (ominternal.Client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	encoding := Encoding(cfg.Metrics.Marshaler)
+	if encoding == JSONEncoding {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-protobuf")
+	}
 
 	return &client{
 		compression: Compression(cfg.Metrics.Compression),
+		encoding:    encoding,
 		req:         req,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
+		requestFunc: cfg.RequestFunc(evaluate, instrumentationName),
 		httpClient:  httpClient,
+		maxSize:     cfg.Metrics.MaxRequestSize,
+		auth:        cfg.Metrics.Authenticator,
+		rejected:    internal.RejectedDataPointsCounter(cfg.MeterProvider, instrumentationName),
 
 		temporalitySelector: cfg.Metrics.TemporalitySelector,
 		aggregationSelector: cfg.Metrics.AggregationSelector,
@@ -161,10 +182,20 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
 
+	groups, splitErr := transform.Split(protoMetrics, c.maxSize, c.size)
+	for _, rm := range groups {
+		if err := c.uploadMetrics(ctx, rm); err != nil {
+			return err
+		}
+	}
+	return splitErr
+}
+
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 	}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -180,8 +211,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		default:
 		}
 
-		request.reset(iCtx)
-		resp, err := c.httpClient.Do(request.Request)
+		resp, err := c.do(iCtx, request)
 		if err != nil {
 			return err
 		}
@@ -199,13 +229,16 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 
 			if respData.Len() != 0 {
 				var respProto colmetricpb.ExportMetricsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
+				if err := c.unmarshal(respData.Bytes(), &respProto); err != nil {
 					return err
 				}
 
 				if respProto.PartialSuccess != nil {
 					msg := respProto.PartialSuccess.GetErrorMessage()
 					n := respProto.PartialSuccess.GetRejectedDataPoints()
+					if n > 0 {
+						c.rejected.Add(iCtx, n)
+					}
 					if n != 0 || msg != "" {
 						err := internal.MetricPartialSuccessError(n, msg)
 						otel.Handle(err)
@@ -234,6 +267,90 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	})
 }
 
+var (
+	// jsonMarshal encodes enum values as integers as required by OTLP/JSON.
+	jsonMarshal   = protojson.MarshalOptions{UseEnumNumbers: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// do sends request with the credentials of the Authenticator, if any. A
+// request rejected as unauthenticated is sent again once after the
+// credentials are refreshed.
+func (c *client) do(ctx context.Context, request request) (*http.Response, error) {
+	if err := c.authenticate(ctx, &request); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(request.Request)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.auth == nil {
+		return resp, err
+	}
+
+	// Drain the body to reuse the connection.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		otel.Handle(err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		otel.Handle(err)
+	}
+	if err := c.auth.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send metrics to %s: %s: refresh credentials: %w", request.URL, resp.Status, err)
+	}
+	if err := c.authenticate(ctx, &request); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(request.Request)
+}
+
+// authenticate resets request and sets the headers of the Authenticator, if
+// any.
+func (c *client) authenticate(ctx context.Context, request *request) error {
+	request.reset(ctx)
+	if c.auth == nil {
+		return nil
+	}
+	headers, err := c.auth.Headers(ctx)
+	if err != nil {
+		return fmt.Errorf("authenticate request: %w", err)
+	}
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	return nil
+}
+
+// marshal encodes msg with the encoding of the client.
+func (c *client) marshal(msg proto.Message) ([]byte, error) {
+	if c.encoding != JSONEncoding {
+		return proto.Marshal(msg)
+	}
+	b, err := jsonMarshal.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return internal.HexEncodeIDs(b)
+}
+
+// size returns the size of msg encoded by marshal, or zero if it cannot be
+// encoded.
+func (c *client) size(msg proto.Message) int {
+	if c.encoding != JSONEncoding {
+		return proto.Size(msg)
+	}
+	b, err := c.marshal(msg)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// unmarshal decodes a response with the encoding of the client.
+func (c *client) unmarshal(b []byte, msg proto.Message) error {
+	if c.encoding != JSONEncoding {
+		return proto.Unmarshal(b, msg)
+	}
+	return jsonUnmarshal.Unmarshal(b, msg)
+}
+
 var gzPool = sync.Pool{
 	New: func() interface{} {
 		w := gzip.NewWriter(io.Discard)
@@ -269,6 +386,18 @@ func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+	case ZstdCompression, SnappyCompression:
+		compress, encoding := oconf.ZstdCompress, oconf.ZstdName
+		if c.compression == SnappyCompression {
+			compress, encoding = oconf.SnappyCompress, oconf.SnappyName
+		}
+		b, err := compress(body)
+		if err != nil {
+			return req, err
+		}
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", encoding)
+		req.bodyReader = bodyReader(b)
 	}
 
 	return req, nil
@@ -19,18 +19,29 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
 	ominternal "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/otest"
+	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/transform"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
 func TestClient(t *testing.T) {
@@ -45,6 +56,18 @@ func TestClient(t *testing.T) {
 	}
 
 	t.Run("Integration", otest.RunClientTests(factory))
+
+	jsonFactory := func(rCh <-chan otest.ExportResult) (ominternal.Client, otest.Collector) {
+		coll, err := otest.NewHTTPCollector("", rCh)
+		require.NoError(t, err)
+
+		addr := coll.Addr().String()
+		client, err := newClient(WithEndpoint(addr), WithInsecure(), WithEncoding(JSONEncoding))
+		require.NoError(t, err)
+		return client, coll
+	}
+
+	t.Run("IntegrationJSON", otest.RunClientTests(jsonFactory))
 }
 
 func TestConfig(t *testing.T) {
@@ -107,6 +130,139 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, coll.Collect().Dump(), 1)
 	})
 
+	t.Run("WithCompressionZstd", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(ZstdCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	t.Run("WithCompressionSnappy", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(SnappyCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	t.Run("WithMaxRequestSize", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithMaxRequestSize(64))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "sum",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.Int("n", 1)), Value: 1},
+						{Attributes: attribute.NewSet(attribute.Int("n", 2)), Value: 2},
+						{Attributes: attribute.NewSet(attribute.Int("n", 3)), Value: 3},
+					},
+				},
+			}},
+		}}}
+		assert.NoError(t, exp.Export(ctx, rm))
+		// Each data point is sent in its own request.
+		assert.Len(t, coll.Collect().Dump(), 3)
+	})
+
+	t.Run("WithMaxRequestSizeJSON", func(t *testing.T) {
+		rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "sum",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.Int("n", 1)), Value: 1},
+						{Attributes: attribute.NewSet(attribute.Int("n", 2)), Value: 2},
+					},
+				},
+			}},
+		}}}
+		pbMetrics, err := transform.ResourceMetrics(rm)
+		require.NoError(t, err)
+		req := &collpb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*mpb.ResourceMetrics{pbMetrics},
+		}
+		// The Protobuf request fits, the larger JSON one does not.
+		max := (&client{encoding: JSONEncoding}).size(req) - 1
+		require.LessOrEqual(t, proto.Size(req), max)
+
+		exp, coll := factoryFunc("", nil, WithEncoding(JSONEncoding), WithMaxRequestSize(max))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, rm))
+		assert.Len(t, coll.Collect().Dump(), 2)
+	})
+
+	t.Run("WithMeterProvider", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 1)
+		rCh <- otest.ExportResult{Response: &collpb.ExportMetricsServiceResponse{
+			PartialSuccess: &collpb.ExportMetricsPartialSuccess{RejectedDataPoints: 2},
+		}}
+		var errs []error
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+		reader := metric.NewManualReader()
+		mp := metric.NewMeterProvider(metric.WithReader(reader))
+		exp, coll := factoryFunc("", rCh, WithMeterProvider(mp))
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(context.Background())) })
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+
+		require.Len(t, errs, 1)
+		var psErr PartialSuccessError
+		require.ErrorAs(t, errs[0], &psErr)
+		assert.Equal(t, int64(2), psErr.RejectedItems)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, instrumentationName, rm.ScopeMetrics[0].Scope.Name)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		got := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "otlp.exporter.data_points.rejected", got.Name)
+		require.IsType(t, metricdata.Sum[int64]{}, got.Data)
+		sum := got.Data.(metricdata.Sum[int64])
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+	})
+
+	t.Run("WithEncodingJSON", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithEncoding(JSONEncoding), WithCompression(GzipCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+		assert.Equal(t, []string{"application/json"}, coll.Headers()["Content-Type"])
+	})
+
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusUnauthorized,
+			Err:    errors.New("token expired"),
+		}}
+		rCh <- otest.ExportResult{}
+		exp, coll := factoryFunc("", rCh, WithAuthenticator(&refreshingAuthenticator{}))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+
+		got := coll.Headers()["Authorization"]
+		assert.Equal(t, []string{"Bearer token-0", "Bearer token-1"}, got)
+	})
+
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
 		rCh := make(chan otest.ExportResult, 3)
@@ -137,6 +293,44 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, rCh, 0, "failed HTTP responses did not occur")
 	})
 
+	t.Run("WithCircuitBreaker", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		for i := 0; i < 2; i++ {
+			rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+				Status: http.StatusServiceUnavailable,
+				Err:    errors.New("unavailable"),
+			}}
+		}
+		var errs []error
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+		exp, coll := factoryFunc("", rCh,
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				// Never stop retrying.
+				MaxElapsedTime: 0,
+			}),
+			WithCircuitBreaker(CircuitBreakerConfig{
+				Enabled:          true,
+				FailureThreshold: 2,
+				OpenTimeout:      time.Hour,
+			}),
+			WithConcurrencyLimit(ConcurrencyLimitConfig{Enabled: true}),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		err := exp.Export(ctx, &metricdata.ResourceMetrics{})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "circuit breaker opened")
+		assert.Len(t, rCh, 0, "failed HTTP responses did not occur")
+	})
+
 	t.Run("WithURLPath", func(t *testing.T) {
 		path := "/prefix/v2/metrics"
 		ePt := fmt.Sprintf("http://localhost:0%s", path)
@@ -170,6 +364,72 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, coll.Collect().Dump(), 1)
 	})
 
+	t.Run("WithUnixSocket", func(t *testing.T) {
+		// Socket paths are limited to about 100 bytes, which the directories
+		// of t.TempDir may exceed.
+		dir, err := os.MkdirTemp("", "otlp")
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+
+		socket := filepath.Join(dir, "collector.sock")
+		exp, coll := factoryFunc("unix://"+socket, nil, WithEndpoint("unix://"+socket))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	// The endpoint is never resolved, the requests reach the collector
+	// through the proxy, the dialer, or the HTTP client.
+	const unresolved = "collector.invalid:4318"
+
+	t.Run("WithProxy", func(t *testing.T) {
+		coll, err := otest.NewHTTPCollector("", nil)
+		require.NoError(t, err)
+		proxy := &url.URL{Scheme: "http", Host: coll.Addr().String()}
+		ctx := context.Background()
+		exp, err := New(ctx, WithEndpoint(unresolved), WithInsecure(), WithProxy(http.ProxyURL(proxy)))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	t.Run("WithDialer", func(t *testing.T) {
+		coll, err := otest.NewHTTPCollector("", nil)
+		require.NoError(t, err)
+		dial := func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, coll.Addr().String())
+		}
+		ctx := context.Background()
+		exp, err := New(ctx, WithEndpoint(unresolved), WithInsecure(), WithDialer(dial))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
+	t.Run("WithHTTPClient", func(t *testing.T) {
+		coll, err := otest.NewHTTPCollector("", nil)
+		require.NoError(t, err)
+		proxy := &url.URL{Scheme: "http", Host: coll.Addr().String()}
+		c := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxy)}}
+		ctx := context.Background()
+		// The proxy of WithProxy is ignored in favor of the one of the client.
+		exp, err := New(ctx, WithEndpoint(unresolved), WithInsecure(), WithHTTPClient(c), WithProxy(func(*http.Request) (*url.URL, error) {
+			return nil, errors.New("proxy of the client not used")
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+	})
+
 	t.Run("WithCustomUserAgent", func(t *testing.T) {
 		key := http.CanonicalHeaderKey("user-agent")
 		headers := map[string]string{key: "custom-user-agent"}
@@ -185,3 +445,18 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 }
+
+// refreshingAuthenticator sends a numbered token, incremented when it is
+// refreshed.
+type refreshingAuthenticator struct {
+	n int64
+}
+
+func (a *refreshingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", atomic.LoadInt64(&a.n))}, nil
+}
+
+func (a *refreshingAuthenticator) Refresh(context.Context) error {
+	atomic.AddInt64(&a.n, 1)
+	return nil
+}
@@ -15,11 +15,16 @@
 package otlpmetrichttp // import "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlpmetric/internal/oconf"
+	otelmetric "github.com/middleware-labs/otel/metric"
 	"github.com/middleware-labs/otel/sdk/metric"
 )
 
@@ -34,6 +39,24 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(oconf.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(oconf.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with the snappy framing format.
+	SnappyCompression = Compression(oconf.SnappyCompression)
+)
+
+// Encoding describes the encoding of the payloads sent to the collector.
+type Encoding oconf.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads encoded in the
+	// binary Protobuf format (application/x-protobuf).
+	ProtobufEncoding = Encoding(oconf.MarshalProto)
+	// JSONEncoding tells the driver to send payloads encoded in the
+	// OTLP/JSON format (application/json).
+	JSONEncoding = Encoding(oconf.MarshalJSON)
 )
 
 // Option applies an option to the Exporter.
@@ -53,6 +76,24 @@ func asHTTPOptions(opts []Option) []oconf.HTTPOption {
 // that failed.
 type RetryConfig retry.Config
 
+// CircuitBreakerConfig defines the configuration of a circuit breaker shared
+// by the export requests of the exporter. After FailureThreshold consecutive
+// failed request attempts, the breaker opens and exports fail without being
+// attempted, instead of exhausting their retries, for OpenTimeout. A single
+// trial request is then let through, closing the breaker if it succeeds.
+type CircuitBreakerConfig retry.BreakerConfig
+
+// ConcurrencyLimitConfig defines the configuration of an adaptive limit of
+// the concurrent export requests of the exporter. The limit is increased by
+// one after about limit successful requests, and multiplied by
+// DecreaseFactor after a request fails with a retryable error, between
+// MinLimit and MaxLimit.
+type ConcurrencyLimitConfig retry.LimiterConfig
+
+// ErrCircuitOpen is returned by exports failed without being attempted
+// because the circuit breaker set with WithCircuitBreaker is open.
+var ErrCircuitOpen = retry.ErrCircuitOpen
+
 type wrappedOption struct {
 	oconf.HTTPOption
 }
@@ -70,6 +111,12 @@ func (w wrappedOption) applyHTTPOption(cfg oconf.Config) oconf.Config {
 // value will be used. If both are set, OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
 // will take precedence.
 //
+// The endpoint can also be a Unix domain socket, given in the
+// unix:///path/to/socket form, as can the OTEL_EXPORTER_OTLP_ENDPOINT and
+// OTEL_EXPORTER_OTLP_METRICS_ENDPOINT environment variables. The requests are
+// then sent over plain HTTP to the default URL path, or the one of
+// WithURLPath.
+//
 // By default, if an environment variable is not set, and this option is not
 // passed, "localhost:4318" will be used.
 func WithEndpoint(endpoint string) Option {
@@ -82,7 +129,7 @@ func WithEndpoint(endpoint string) Option {
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -91,6 +138,22 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{oconf.WithCompression(oconf.Compression(compression))}
 }
 
+// WithEncoding sets the encoding of the HTTP body. JSONEncoding sends the
+// OTLP/JSON encoding, with hex-encoded trace and span IDs and lowerCamelCase
+// field names.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_METRICS_PROTOCOL
+// environment variable is set, and this option is not passed, that variable
+// value will be used. That value can be either "http/protobuf" or
+// "http/json". If both are set, OTEL_EXPORTER_OTLP_METRICS_PROTOCOL will take
+// precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{oconf.WithMarshaler(oconf.Marshaler(encoding))}
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
@@ -119,6 +182,58 @@ func WithTLSClientConfig(tlsCfg *tls.Config) Option {
 	return wrappedOption{oconf.WithTLSClientConfig(tlsCfg)}
 }
 
+// WithClientCertificateFiles sets the files of the PEM encoded client
+// certificate and key used for mutual TLS. The files are loaded again on new
+// TLS handshakes when they have been modified, so rotated certificates are
+// used without recreating the exporter. The client certificates of the TLS
+// configuration are replaced.
+//
+// If the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_CLIENT_KEY, or the
+// OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY environment variables are set, and
+// this option is not passed, those files will be used. If both are set, the
+// METRICS variables will take precedence.
+//
+// By default, if the environment variables are not set, and this option is
+// not passed, no client certificate is used.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{oconf.WithClientCertificateFiles(certFile, keyFile)}
+}
+
+// HTTPTransportProxyFunc is a function that resolves which URL to use as
+// proxy for a given request. It has the signature of the Proxy field of
+// http.Transport.
+type HTTPTransportProxyFunc func(*http.Request) (*url.URL, error)
+
+// WithProxy sets the function resolving the proxy the requests are sent
+// through. Returning a nil URL sends a request without proxy. If this option
+// is not passed, http.ProxyFromEnvironment is used, which reads the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. Requests to a
+// Unix domain socket are never sent through a proxy.
+func WithProxy(pf HTTPTransportProxyFunc) Option {
+	return wrappedOption{oconf.WithProxy(oconf.HTTPTransportProxyFunc(pf))}
+}
+
+// WithDialer sets the function establishing the network connections to the
+// endpoint, instead of a net.Dialer. For Unix domain socket endpoints, it is
+// called with the "unix" network and the path of the socket.
+func WithDialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return wrappedOption{oconf.WithDialer(dial)}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests. It takes
+// precedence over WithTLSClientConfig, WithClientCertificateFiles,
+// WithProxy, WithDialer, and WithTimeout, as well as the
+// OTEL_EXPORTER_OTLP_CERTIFICATE, OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE, and
+// OTEL_EXPORTER_OTLP_TIMEOUT
+// environment variables: these settings must be configured on the client,
+// which is used as is. The endpoint, with the URL path, only determines the
+// URL of the requests.
+func WithHTTPClient(c *http.Client) Option {
+	return wrappedOption{oconf.WithHTTPClient(c)}
+}
+
 // WithInsecure disables client transport security for the Exporter's HTTP
 // connection.
 //
@@ -149,6 +264,15 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{oconf.WithHeaders(headers)}
 }
 
+// WithAuthenticator sets the Authenticator providing the credentials of each
+// export request. Its headers are sent with the HTTP requests, overriding
+// the headers of WithHeaders, and it is asked to refresh the credentials when
+// a request is rejected with a 401 Unauthorized status. BasicAuth,
+// BearerTokenFile, and ClientCredentials return the provided implementations.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{oconf.WithAuthenticator(a)}
+}
+
 // WithTimeout sets the max amount of time an Exporter will attempt an export.
 //
 // This takes precedence over any retry settings defined by WithRetry. Once
@@ -167,6 +291,17 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{oconf.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size in bytes of the serialization of
+// an export request with the configured encoding, before compression.
+// Metrics exceeding it are split into several requests, down to individual
+// data points. Data points exceeding it on their own are dropped and reported
+// as an error.
+//
+// By default, the request size is not limited.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that are
 // returned by the target endpoint.
 //
@@ -181,6 +316,33 @@ func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{oconf.WithRetry(retry.Config(rc))}
 }
 
+// WithCircuitBreaker sets the circuit breaker shared by the export requests,
+// failing exports with ErrCircuitOpen while the receiver is unavailable. The
+// opening of the breaker is reported to the global error handler, and its
+// state with the otlp.exporter.circuit_breaker.state up-down counter. If
+// unset, no circuit breaker is used.
+func WithCircuitBreaker(c CircuitBreakerConfig) Option {
+	return wrappedOption{oconf.WithCircuitBreaker(retry.BreakerConfig(c))}
+}
+
+// WithConcurrencyLimit sets the adaptive limit of concurrent export requests.
+// Requests exceeding the limit wait for other requests to complete. The limit
+// is reported with the otlp.exporter.concurrency.limit up-down counter. If
+// unset, the concurrency of export requests is not limited.
+func WithConcurrencyLimit(c ConcurrencyLimitConfig) Option {
+	return wrappedOption{oconf.WithConcurrencyLimit(retry.LimiterConfig(c))}
+}
+
+// WithMeterProvider sets the MeterProvider used to report the number of data
+// points rejected by the receiver in partial success responses, with the
+// otlp.exporter.data_points.rejected counter, and the state of the circuit
+// breaker and the concurrency limit.
+//
+// By default, the global MeterProvider is used.
+func WithMeterProvider(mp otelmetric.MeterProvider) Option {
+	return wrappedOption{oconf.WithMeterProvider(mp)}
+}
+
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
 // determine the Temporality of an instrument based on its kind. If this option
 // is not used, the client will use the DefaultTemporalitySelector from the
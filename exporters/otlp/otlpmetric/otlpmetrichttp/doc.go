@@ -14,5 +14,6 @@
 
 // Package otlpmetrichttp provides an otlpmetric.Exporter that communicates
 // with an OTLP receiving endpoint using protobuf encoded metric data over
-// HTTP.
+// HTTP. The OTLP/JSON encoding can be used instead with the WithEncoding
+// option.
 package otlpmetrichttp // import "github.com/middleware-labs/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
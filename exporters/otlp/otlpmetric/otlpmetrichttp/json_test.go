@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetrichttp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+var jsonRequest = &colmetricpb.ExportMetricsServiceRequest{
+	ResourceMetrics: []*metricpb.ResourceMetrics{{
+		Resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{{
+				Key:   "service.name",
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "my.service"}},
+			}},
+		},
+		ScopeMetrics: []*metricpb.ScopeMetrics{{
+			Scope: &commonpb.InstrumentationScope{Name: "my.library", Version: "1.0.0"},
+			Metrics: []*metricpb.Metric{
+				{
+					Name:        "my.counter",
+					Unit:        "1",
+					Description: "I am a Counter",
+					Data: &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+						AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+						IsMonotonic:            true,
+						DataPoints: []*metricpb.NumberDataPoint{{
+							StartTimeUnixNano: 1544712660300000000,
+							TimeUnixNano:      1544712660300000000,
+							Value:             &metricpb.NumberDataPoint_AsInt{AsInt: 5},
+							Attributes: []*commonpb.KeyValue{{
+								Key:   "my.counter.attr",
+								Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "some value"}},
+							}},
+						}},
+					}},
+				},
+				{
+					Name: "my.histogram",
+					Unit: "ms",
+					Data: &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{
+						AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+						DataPoints: []*metricpb.HistogramDataPoint{{
+							StartTimeUnixNano: 1544712660300000000,
+							TimeUnixNano:      1544712660300000000,
+							Count:             2,
+							Sum:               func(v float64) *float64 { return &v }(3),
+							BucketCounts:      []uint64{1, 1},
+							ExplicitBounds:    []float64{1},
+							Exemplars: []*metricpb.Exemplar{{
+								TimeUnixNano: 1544712660300000000,
+								Value:        &metricpb.Exemplar_AsDouble{AsDouble: 2},
+								TraceId:      []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x3, 0x81, 0x3, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0xc},
+								SpanId:       []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74},
+							}},
+						}},
+					}},
+				},
+			},
+		}},
+	}},
+}
+
+func TestMarshalJSON(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", "metrics.json"))
+	require.NoError(t, err)
+
+	c := &client{encoding: JSONEncoding}
+	got, err := c.marshal(jsonRequest)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+}
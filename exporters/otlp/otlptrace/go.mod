@@ -4,9 +4,11 @@ go 1.19
 
 require (
 	github.com/google/go-cmp v0.5.9
+	github.com/klauspost/compress v1.16.7
 	github.com/stretchr/testify v1.8.2
 	github.com/middleware-labs/otel v1.15.0-rc.2
 	github.com/middleware-labs/otel/exporters/otlp/internal/retry v1.15.0-rc.2
+	github.com/middleware-labs/otel/metric v1.15.0-rc.2
 	github.com/middleware-labs/otel/sdk v1.15.0-rc.2
 	github.com/middleware-labs/otel/trace v1.15.0-rc.2
 	go.opentelemetry.io/proto/otlp v0.19.0
@@ -22,7 +24,6 @@ require (
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.7.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
 	golang.org/x/net v0.8.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpconfig // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// ZstdName is the name of the zstd compressor registered with gRPC and
+	// the HTTP Content-Encoding of zstd compressed payloads.
+	ZstdName = "zstd"
+	// SnappyName is the name of the snappy compressor registered with gRPC
+	// and the HTTP Content-Encoding of snappy compressed payloads.
+	SnappyName = "snappy"
+)
+
+func init() {
+	encoding.RegisterCompressor(zstdCompressor{})
+	encoding.RegisterCompressor(snappyCompressor{})
+}
+
+var (
+	zstdEncoderOnce sync.Once
+	zstdEncoder     *zstd.Encoder
+	zstdEncoderErr  error
+
+	zstdWriterPool sync.Pool
+)
+
+// ZstdCompress returns b compressed with zstd.
+func ZstdCompress(b []byte) ([]byte, error) {
+	zstdEncoderOnce.Do(func() {
+		// EncodeAll can be called concurrently.
+		zstdEncoder, zstdEncoderErr = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	})
+	if zstdEncoderErr != nil {
+		return nil, zstdEncoderErr
+	}
+	return zstdEncoder.EncodeAll(b, nil), nil
+}
+
+// SnappyCompress returns b compressed with the snappy framing format.
+func SnappyCompress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// zstdCompressor is a gRPC compressor using zstd.
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string { return ZstdName }
+
+func (zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if enc, ok := zstdWriterPool.Get().(*zstd.Encoder); ok {
+		enc.Reset(w)
+		return &zstdWriter{Encoder: enc}, nil
+	}
+	enc, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdWriter{Encoder: enc}, nil
+}
+
+func (zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec}, nil
+}
+
+// zstdWriter returns its encoder to the pool when closed.
+type zstdWriter struct {
+	*zstd.Encoder
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	zstdWriterPool.Put(w.Encoder)
+	return err
+}
+
+// zstdReader releases the resources of its decoder once all data is read.
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.Decoder.Close()
+	}
+	return n, err
+}
+
+// snappyCompressor is a gRPC compressor using the snappy framing format.
+type snappyCompressor struct{}
+
+func (snappyCompressor) Name() string { return SnappyName }
+
+func (snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
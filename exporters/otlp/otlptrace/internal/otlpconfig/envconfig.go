@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/envconfig"
 )
 
@@ -64,7 +65,7 @@ func getOptionsFromEnv() []GenericOption {
 				// configuration, the passed endpoint is used as a base URL
 				// and the signals are sent to these paths relative to that.
 				cfg.Traces.URLPath = path.Join(u.Path, DefaultTracesPath)
-				return cfg
+				return withHTTPUnixSocket(cfg, u)
 			}, withEndpointForGRPC(u)))
 		}),
 		envconfig.WithURL("TRACES_ENDPOINT", func(u *url.URL) {
@@ -80,13 +81,13 @@ func getOptionsFromEnv() []GenericOption {
 					path = "/"
 				}
 				cfg.Traces.URLPath = path
-				return cfg
+				return withHTTPUnixSocket(cfg, u)
 			}, withEndpointForGRPC(u)))
 		}),
 		envconfig.WithCertPool("CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
 		envconfig.WithCertPool("TRACES_CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithClientCert("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		envconfig.WithClientCert("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
+		envconfig.WithClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c, k string) { opts = append(opts, WithClientCertificateFiles(c, k)) }),
+		envconfig.WithClientCertFiles("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", func(c, k string) { opts = append(opts, WithClientCertificateFiles(c, k)) }),
 		withTLSConfig(tlsConf, func(c *tls.Config) { opts = append(opts, WithTLSClientConfig(c)) }),
 		envconfig.WithBool("INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithBool("TRACES_INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
@@ -94,6 +95,8 @@ func getOptionsFromEnv() []GenericOption {
 		envconfig.WithHeaders("TRACES_HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		WithEnvCompression("COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
 		WithEnvCompression("TRACES_COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
+		WithEnvProtocol("PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
+		WithEnvProtocol("TRACES_PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshaler(m)) }),
 		envconfig.WithDuration("TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 		envconfig.WithDuration("TRACES_TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 	)
@@ -110,6 +113,17 @@ func withEndpointScheme(u *url.URL) GenericOption {
 	}
 }
 
+// withHTTPUnixSocket sends the OTLP/HTTP requests to the Unix domain socket
+// of u if it is a unix URL. The path of such URLs is the path of the socket,
+// and the requests are sent to the default path.
+func withHTTPUnixSocket(cfg Config, u *url.URL) Config {
+	if strings.EqualFold(u.Scheme, "unix") {
+		cfg.Traces.Endpoint = internal.UnixSocketEndpoint(u.Path)
+		cfg.Traces.URLPath = DefaultTracesPath
+	}
+	return cfg
+}
+
 func withEndpointForGRPC(u *url.URL) func(cfg Config) Config {
 	return func(cfg Config) Config {
 		// For OTLP/gRPC endpoints, this is the target to which the
@@ -124,8 +138,13 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	return func(e *envconfig.EnvOptionsReader) {
 		if v, ok := e.GetEnvValue(n); ok {
 			cp := NoCompression
-			if v == "gzip" {
+			switch v {
+			case "gzip":
 				cp = GzipCompression
+			case ZstdName:
+				cp = ZstdCompression
+			case SnappyName:
+				cp = SnappyCompression
 			}
 
 			fn(cp)
@@ -133,6 +152,22 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	}
 }
 
+// WithEnvProtocol retrieves the specified config and passes it to ConfigFn as
+// the Marshaler of the protocol. Only the "http/protobuf" and "http/json"
+// protocols are passed, the "grpc" protocol is chosen by the exporter used.
+func WithEnvProtocol(n string, fn func(Marshaler)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if v, ok := e.GetEnvValue(n); ok {
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "http/protobuf":
+				fn(MarshalProto)
+			case "http/json":
+				fn(MarshalJSON)
+			}
+		}
+	}
+}
+
 // revive:disable-next-line:flag-parameter
 func withInsecure(b bool) GenericOption {
 	if b {
@@ -143,7 +178,7 @@ func withInsecure(b bool) GenericOption {
 
 func withTLSConfig(c *tls.Config, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
 	return func(e *envconfig.EnvOptionsReader) {
-		if c.RootCAs != nil || len(c.Certificates) > 0 {
+		if c.RootCAs != nil {
 			fn(c)
 		}
 	}
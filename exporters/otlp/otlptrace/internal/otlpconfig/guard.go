@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpconfig // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
+	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
+)
+
+// RequestFunc returns the RequestFunc retrying export requests as configured
+// by c.RetryConfig, with each attempt guarded by the circuit breaker and the
+// concurrency limiter configured by c. Their state is reported with the
+// instruments of the Meter named scope, and the opening of the circuit breaker
+// to the global error handler.
+func (c Config) RequestFunc(evaluate retry.EvaluateFunc, scope string) retry.RequestFunc {
+	ctx := context.Background()
+
+	var b *retry.Breaker
+	if c.CircuitBreaker.Enabled {
+		state := internal.CircuitBreakerStateCounter(c.MeterProvider, scope)
+		state.Add(ctx, 1, stateAttr(retry.StateClosed))
+		b = retry.NewBreaker(c.CircuitBreaker, func(from, to retry.State, cause error) {
+			state.Add(ctx, -1, stateAttr(from))
+			state.Add(ctx, 1, stateAttr(to))
+			if to == retry.StateOpen {
+				otel.Handle(fmt.Errorf("circuit breaker opened, exports fail until a trial request succeeds: %w", cause))
+			}
+		})
+	}
+
+	var l *retry.Limiter
+	if c.ConcurrencyLimit.Enabled {
+		limit := internal.ConcurrencyLimitCounter(c.MeterProvider, scope)
+		l = retry.NewLimiter(c.ConcurrencyLimit, func(from, to int) {
+			limit.Add(ctx, int64(to-from))
+		})
+		limit.Add(ctx, int64(l.Limit()))
+	}
+
+	return retry.Guard(c.RetryConfig.RequestFunc(evaluate), evaluate, b, l)
+}
+
+func stateAttr(s retry.State) attribute.KeyValue {
+	return attribute.String("state", s.String())
+}
@@ -15,8 +15,12 @@
 package otlpconfig // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"time"
 
 	"google.golang.org/grpc"
@@ -27,6 +31,7 @@ import (
 
 	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
+	"github.com/middleware-labs/otel/metric"
 )
 
 const (
@@ -39,14 +44,37 @@ const (
 )
 
 type (
+	// HTTPTransportProxyFunc is a function that resolves which URL to use
+	// as proxy for a given request.
+	HTTPTransportProxyFunc func(*http.Request) (*url.URL, error)
+
 	SignalConfig struct {
 		Endpoint    string
 		Insecure    bool
 		TLSCfg      *tls.Config
 		Headers     map[string]string
 		Compression Compression
-		Timeout     time.Duration
-		URLPath     string
+		Marshaler   Marshaler
+		// MaxRequestSize is the maximum serialized size of an export
+		// request, larger requests are split. Zero means no limit.
+		MaxRequestSize int
+		Timeout        time.Duration
+		URLPath        string
+
+		// CertReloader provides the client certificate of TLS connections
+		// if it is set, overriding the certificates of TLSCfg.
+		CertReloader *internal.CertificateReloader
+
+		// Authenticator provides the credentials of export requests if it
+		// is set.
+		Authenticator internal.Authenticator
+
+		// HTTP configurations
+		// HTTPClient sends the requests if it is set, ignoring the
+		// other transport settings.
+		HTTPClient *http.Client
+		Proxy      HTTPTransportProxyFunc
+		Dialer     func(ctx context.Context, network, addr string) (net.Conn, error)
 
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
@@ -58,6 +86,17 @@ type (
 
 		RetryConfig retry.Config
 
+		// CircuitBreaker configures the circuit breaker shared by the
+		// export requests.
+		CircuitBreaker retry.BreakerConfig
+		// ConcurrencyLimit configures the adaptive limit of concurrent
+		// export requests.
+		ConcurrencyLimit retry.LimiterConfig
+
+		// MeterProvider is used to report the items rejected by the
+		// receiver. The global MeterProvider is used if it is nil.
+		MeterProvider metric.MeterProvider
+
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
@@ -82,6 +121,9 @@ func NewHTTPConfig(opts ...HTTPOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyHTTPOption(cfg)
 	}
+	if r := cfg.Traces.CertReloader; r != nil {
+		cfg.Traces.TLSCfg = r.TLSConfig(cfg.Traces.TLSCfg)
+	}
 	cfg.Traces.URLPath = internal.CleanPath(cfg.Traces.URLPath, DefaultTracesPath)
 	return cfg
 }
@@ -103,6 +145,9 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyGRPCOption(cfg)
 	}
+	if r := cfg.Traces.CertReloader; r != nil {
+		cfg.Traces.GRPCCredentials = credentials.NewTLS(r.TLSConfig(cfg.Traces.TLSCfg))
+	}
 
 	if cfg.ServiceConfig != "" {
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultServiceConfig(cfg.ServiceConfig))
@@ -118,8 +163,13 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Traces.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Traces.Compression == GzipCompression {
+	switch cfg.Traces.Compression {
+	case GzipCompression:
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(ZstdName)))
+	case SnappyCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(SnappyName)))
 	}
 	if len(cfg.DialOptions) != 0 {
 		cfg.DialOptions = append(cfg.DialOptions, cfg.DialOptions...)
@@ -255,6 +305,20 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshaler(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Marshaler = m
+		return cfg
+	})
+}
+
+func WithMaxRequestSize(size int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.MaxRequestSize = size
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.URLPath = urlPath
@@ -269,16 +333,73 @@ func WithRetry(rc retry.Config) GenericOption {
 	})
 }
 
+func WithCircuitBreaker(c retry.BreakerConfig) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.CircuitBreaker = c
+		return cfg
+	})
+}
+
+func WithConcurrencyLimit(c retry.LimiterConfig) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.ConcurrencyLimit = c
+		return cfg
+	})
+}
+
+func WithMeterProvider(mp metric.MeterProvider) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.MeterProvider = mp
+		return cfg
+	})
+}
+
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Traces.TLSCfg = tlsCfg.Clone()
 		return cfg
 	}, func(cfg Config) Config {
+		cfg.Traces.TLSCfg = tlsCfg.Clone()
 		cfg.Traces.GRPCCredentials = credentials.NewTLS(tlsCfg)
 		return cfg
 	})
 }
 
+func WithClientCertificateFiles(certFile, keyFile string) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.CertReloader = internal.NewCertificateReloader(certFile, keyFile)
+		return cfg
+	})
+}
+
+func WithAuthenticator(a internal.Authenticator) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Authenticator = a
+		return cfg
+	})
+}
+
+func WithHTTPClient(c *http.Client) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.HTTPClient = c
+		return cfg
+	})
+}
+
+func WithProxy(pf HTTPTransportProxyFunc) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Proxy = pf
+		return cfg
+	})
+}
+
+func WithDialer(d func(ctx context.Context, network, addr string) (net.Conn, error)) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Dialer = d
+		return cfg
+	})
+}
+
 func WithInsecure() GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Insecure = true
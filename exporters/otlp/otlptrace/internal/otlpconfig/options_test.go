@@ -15,14 +15,22 @@
 package otlpconfig_test
 
 import (
+	"crypto/tls"
 	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/envconfig"
+	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"github.com/middleware-labs/otel/metric/noop"
 )
 
 const (
@@ -67,6 +75,26 @@ func TestConfigs(t *testing.T) {
 	tlsCert, err := otlpconfig.CreateTLSConfig([]byte(WeakCertificate))
 	assert.NoError(t, err)
 
+	dir := t.TempDir()
+	clientCertFile := filepath.Join(dir, "client.crt")
+	clientKeyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(clientCertFile, []byte(WeakCertificate), 0o600))
+	assert.NoError(t, os.WriteFile(clientKeyFile, []byte(WeakPrivateKey), 0o600))
+	clientCert, err := tls.X509KeyPair([]byte(WeakCertificate), []byte(WeakPrivateKey))
+	assert.NoError(t, err)
+	assertClientCert := func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+		if grpcOption {
+			assert.NotNil(t, c.Traces.GRPCCredentials)
+			assert.NotNil(t, c.Traces.CertReloader)
+			return
+		}
+		if assert.NotNil(t, c.Traces.TLSCfg) && assert.NotNil(t, c.Traces.TLSCfg.GetClientCertificate) {
+			got, err := c.Traces.TLSCfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+			assert.NoError(t, err)
+			assert.Equal(t, clientCert.Certificate, got.Certificate)
+		}
+	}
+
 	tests := []struct {
 		name       string
 		opts       []otlpconfig.GenericOption
@@ -180,6 +208,33 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, true, c.Traces.Insecure)
 			},
 		},
+		{
+			name: "Test Environment Endpoint with Unix scheme",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.True(t, c.Traces.Insecure)
+				if !grpcOption {
+					assert.Equal(t, "unix:///var/run/otel.sock", c.Traces.Endpoint)
+					assert.Equal(t, "/v1/traces", c.Traces.URLPath)
+				}
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Endpoint with Unix scheme",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":        "https://overrode.by.signal.specific/env/var",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "unix:///var/run/otel.sock",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.True(t, c.Traces.Insecure)
+				if !grpcOption {
+					assert.Equal(t, "unix:///var/run/otel.sock", c.Traces.Endpoint)
+					assert.Equal(t, "/v1/traces", c.Traces.URLPath)
+				}
+			},
+		},
 
 		// Certificate tests
 		{
@@ -262,6 +317,50 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test With Client Certificate Files",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithClientCertificateFiles(clientCertFile, clientKeyFile),
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         clientKeyFile,
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Signal Specific Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE":        "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":                "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY":         clientKeyFile,
+			},
+			asserts: assertClientCert,
+		},
+		{
+			name: "Test Environment Certificate and Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": clientCertFile,
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         clientKeyFile,
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assertClientCert(t, c, grpcOption)
+				if !grpcOption {
+					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
+					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Traces.TLSCfg.RootCAs.Subjects())
+				}
+			},
+		},
+
 		// Headers tests
 		{
 			name: "Test With Headers",
@@ -339,6 +438,133 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.ZstdCompression, c.Traces.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.SnappyCompression, c.Traces.Compression)
+			},
+		},
+
+		// Max Request Size Tests
+		{
+			name: "Test With MaxRequestSize",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMaxRequestSize(1024),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, 1024, c.Traces.MaxRequestSize)
+			},
+		},
+
+		// MeterProvider Tests
+		{
+			name: "Test With MeterProvider",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMeterProvider(noop.NewMeterProvider()),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, noop.NewMeterProvider(), c.MeterProvider)
+			},
+		},
+		{
+			name: "Test With Circuit Breaker and Concurrency Limit",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithCircuitBreaker(retry.BreakerConfig{Enabled: true, FailureThreshold: 3}),
+				otlpconfig.WithConcurrencyLimit(retry.LimiterConfig{Enabled: true, MaxLimit: 10}),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, retry.BreakerConfig{Enabled: true, FailureThreshold: 3}, c.CircuitBreaker)
+				assert.Equal(t, retry.LimiterConfig{Enabled: true, MaxLimit: 10}, c.ConcurrencyLimit)
+			},
+		},
+		{
+			name: "Test With Authenticator",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithAuthenticator(internal.BasicAuth("user", "pass")),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, internal.BasicAuth("user", "pass"), c.Traces.Authenticator)
+			},
+		},
+
+		// HTTP transport tests
+		{
+			name: "Test With HTTP Transport Options",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithHTTPClient(http.DefaultClient),
+				otlpconfig.WithProxy(http.ProxyFromEnvironment),
+				otlpconfig.WithDialer((&net.Dialer{}).DialContext),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Same(t, http.DefaultClient, c.Traces.HTTPClient)
+				assert.NotNil(t, c.Traces.Proxy)
+				assert.NotNil(t, c.Traces.Dialer)
+			},
+		},
+
+		// Protocol Tests
+		{
+			name: "Test With Marshaler",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMarshaler(otlpconfig.MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment gRPC Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMarshaler(otlpconfig.MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
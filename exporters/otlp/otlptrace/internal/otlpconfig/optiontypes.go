@@ -35,6 +35,12 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with the snappy framing format.
+	SnappyCompression
 )
 
 // Marshaler describes the kind of message format sent to the collector.
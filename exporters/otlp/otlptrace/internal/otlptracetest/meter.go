@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracetest // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlptracetest"
+
+import (
+	"context"
+	"sync"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/metric/instrument"
+	"github.com/middleware-labs/otel/metric/noop"
+)
+
+// CounterMeterProvider is a MeterProvider summing the measurements of all the
+// Int64Counter and Int64UpDownCounter instruments it creates. It is used to
+// test the counters reported by the exporters.
+type CounterMeterProvider struct {
+	noop.MeterProvider
+
+	mu    sync.Mutex
+	names map[string]int64
+}
+
+// Meter returns a Meter creating Int64Counter and Int64UpDownCounter
+// instruments that record to mp.
+func (mp *CounterMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return counterMeter{mp: mp}
+}
+
+// Sum returns the sum of the measurements of the counter named name.
+func (mp *CounterMeterProvider) Sum(name string) int64 {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return mp.names[name]
+}
+
+func (mp *CounterMeterProvider) add(name string, incr int64) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	if mp.names == nil {
+		mp.names = make(map[string]int64)
+	}
+	mp.names[name] += incr
+}
+
+type counterMeter struct {
+	noop.Meter
+
+	mp *CounterMeterProvider
+}
+
+func (m counterMeter) Int64Counter(name string, _ ...instrument.Int64CounterOption) (instrument.Int64Counter, error) {
+	return counter{mp: m.mp, name: name}, nil
+}
+
+type counter struct {
+	noop.Int64Counter
+
+	mp   *CounterMeterProvider
+	name string
+}
+
+func (c counter) Add(_ context.Context, incr int64, _ ...attribute.KeyValue) {
+	c.mp.add(c.name, incr)
+}
+
+func (m counterMeter) Int64UpDownCounter(name string, _ ...instrument.Int64UpDownCounterOption) (instrument.Int64UpDownCounter, error) {
+	return upDownCounter{mp: m.mp, name: name}, nil
+}
+
+type upDownCounter struct {
+	noop.Int64UpDownCounter
+
+	mp   *CounterMeterProvider
+	name string
+}
+
+func (c upDownCounter) Add(_ context.Context, incr int64, _ ...attribute.KeyValue) {
+	c.mp.add(c.name, incr)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetransform // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/tracetransform"
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// ErrSpanTooLarge is returned by Split for spans that exceed the maximum
+// request size on their own.
+var ErrSpanTooLarge = errors.New("span exceeds the maximum request size")
+
+// spanIndex locates a span in a slice of ResourceSpans.
+type spanIndex struct {
+	resource, scope, span int
+}
+
+// Split splits rss into groups that each serialize to an
+// ExportTraceServiceRequest of at most maxSize bytes, as measured by size
+// with the encoding of the requests (proto.Size for Protobuf). rss is
+// returned as the only group if maxSize is not positive or the request is
+// small enough.
+//
+// Spans that exceed maxSize on their own are dropped and an error wrapping
+// ErrSpanTooLarge is returned with the groups of the other spans.
+func Split(rss []*tracepb.ResourceSpans, maxSize int, size func(proto.Message) int) ([][]*tracepb.ResourceSpans, error) {
+	if maxSize <= 0 || requestSize(rss, size) <= maxSize {
+		return [][]*tracepb.ResourceSpans{rss}, nil
+	}
+
+	var idx []spanIndex
+	for r, rs := range rss {
+		for s, ss := range rs.ScopeSpans {
+			for i := range ss.Spans {
+				idx = append(idx, spanIndex{resource: r, scope: s, span: i})
+			}
+		}
+	}
+
+	var (
+		groups  [][]*tracepb.ResourceSpans
+		dropped int
+	)
+	var split func([]spanIndex)
+	split = func(idx []spanIndex) {
+		if len(idx) == 0 {
+			return
+		}
+		group := subset(rss, idx)
+		if requestSize(group, size) <= maxSize {
+			groups = append(groups, group)
+			return
+		}
+		if len(idx) == 1 {
+			dropped++
+			return
+		}
+		split(idx[:len(idx)/2])
+		split(idx[len(idx)/2:])
+	}
+	split(idx)
+
+	if dropped > 0 {
+		return groups, fmt.Errorf("%w: %d spans dropped (max %d bytes)", ErrSpanTooLarge, dropped, maxSize)
+	}
+	return groups, nil
+}
+
+func requestSize(rss []*tracepb.ResourceSpans, size func(proto.Message) int) int {
+	return size(&coltracepb.ExportTraceServiceRequest{ResourceSpans: rss})
+}
+
+// subset returns the ResourceSpans containing only the spans of rss
+// located by idx. idx must be ordered.
+func subset(rss []*tracepb.ResourceSpans, idx []spanIndex) []*tracepb.ResourceSpans {
+	var (
+		out []*tracepb.ResourceSpans
+		rs  *tracepb.ResourceSpans
+		ss  *tracepb.ScopeSpans
+	)
+	last := spanIndex{resource: -1, scope: -1}
+	for _, i := range idx {
+		if i.resource != last.resource {
+			src := rss[i.resource]
+			rs = &tracepb.ResourceSpans{
+				Resource:  src.Resource,
+				SchemaUrl: src.SchemaUrl,
+			}
+			out = append(out, rs)
+			last.scope = -1
+		}
+		if i.resource != last.resource || i.scope != last.scope {
+			src := rss[i.resource].ScopeSpans[i.scope]
+			ss = &tracepb.ScopeSpans{
+				Scope:     src.Scope,
+				SchemaUrl: src.SchemaUrl,
+			}
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, rss[i.resource].ScopeSpans[i.scope].Spans[i.span])
+		last = i
+	}
+	return out
+}
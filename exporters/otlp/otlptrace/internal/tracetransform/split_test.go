@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetransform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func splitTestData() []*tracepb.ResourceSpans {
+	span := func(name string, size int) *tracepb.Span {
+		return &tracepb.Span{
+			Name: name,
+			Attributes: []*commonpb.KeyValue{{
+				Key:   "payload",
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: strings.Repeat("x", size)}},
+			}},
+		}
+	}
+	return []*tracepb.ResourceSpans{
+		{
+			Resource:  &resourcepb.Resource{Attributes: []*commonpb.KeyValue{{Key: "r", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 1}}}}},
+			SchemaUrl: "resource/1",
+			ScopeSpans: []*tracepb.ScopeSpans{
+				{
+					Scope: &commonpb.InstrumentationScope{Name: "a"},
+					Spans: []*tracepb.Span{span("a1", 100), span("a2", 100), span("a3", 100)},
+				},
+				{
+					Scope: &commonpb.InstrumentationScope{Name: "b"},
+					Spans: []*tracepb.Span{span("b1", 100)},
+				},
+			},
+		},
+		{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{{Key: "r", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 2}}}}},
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{Name: "c"},
+				Spans: []*tracepb.Span{span("c1", 100), span("c2", 1000), span("c3", 100)},
+			}},
+		},
+	}
+}
+
+// spanNames returns the names of spans in groups, prefixed with the scope
+// name and the resource schema URL.
+func spanNames(groups [][]*tracepb.ResourceSpans) []string {
+	var names []string
+	for _, rss := range groups {
+		for _, rs := range rss {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					names = append(names, rs.SchemaUrl+":"+ss.Scope.Name+":"+s.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestSplitNoLimit(t *testing.T) {
+	rss := splitTestData()
+	for _, max := range []int{0, -1, requestSize(rss, proto.Size)} {
+		groups, err := Split(rss, max, proto.Size)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, rss, groups[0])
+	}
+}
+
+func TestSplit(t *testing.T) {
+	rss := splitTestData()
+	const max = 450
+	groups, err := Split(rss, max, proto.Size)
+	assert.ErrorIs(t, err, ErrSpanTooLarge)
+
+	assert.Greater(t, len(groups), 1)
+	for _, g := range groups {
+		assert.LessOrEqual(t, requestSize(g, proto.Size), max)
+		for _, rs := range g {
+			// The resource of a span must be kept.
+			var src *tracepb.ResourceSpans
+			for _, r := range rss {
+				if proto.Equal(r.Resource, rs.Resource) {
+					src = r
+				}
+			}
+			require.NotNil(t, src)
+			assert.Equal(t, src.SchemaUrl, rs.SchemaUrl)
+		}
+	}
+	// All spans but the one too large are kept, in order.
+	assert.Equal(t, []string{
+		"resource/1:a:a1",
+		"resource/1:a:a2",
+		"resource/1:a:a3",
+		"resource/1:b:b1",
+		":c:c1",
+		":c:c3",
+	}, spanNames(groups))
+}
@@ -17,6 +17,7 @@ package otlptracegrpc // import "github.com/middleware-labs/otel/exporters/otlp/
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -25,22 +26,38 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/tracetransform"
+	"github.com/middleware-labs/otel/metric/instrument"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
+// instrumentationName is the name of the Meter reporting the spans rejected by
+// the receiver and the state of the circuit breaker and the concurrency
+// limiter.
+const instrumentationName = "github.com/middleware-labs/otel/exporters/otlp/otlptrace/otlptracegrpc"
+
+// PartialSuccessError is the error passed to the global error handler when
+// the receiver only accepts part of the exported spans. Use errors.As to read
+// the number of rejected spans and the message of the receiver.
+type PartialSuccessError = internal.PartialSuccess
+
 type client struct {
 	endpoint      string
 	dialOpts      []grpc.DialOption
 	metadata      metadata.MD
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
+	maxSize       int
+	auth          internal.Authenticator
+	rejected      instrument.Int64Counter
 
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
@@ -74,7 +91,10 @@ func newClient(opts ...Option) *client {
 	c := &client{
 		endpoint:      cfg.Traces.Endpoint,
 		exportTimeout: cfg.Traces.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
+		requestFunc:   cfg.RequestFunc(retryable, instrumentationName),
+		maxSize:       cfg.Traces.MaxRequestSize,
+		auth:          cfg.Traces.Authenticator,
+		rejected:      internal.RejectedSpansCounter(cfg.MeterProvider, instrumentationName),
 		dialOpts:      cfg.DialOptions,
 		stopCtx:       ctx,
 		stopFunc:      cancel,
@@ -197,13 +217,26 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
+	groups, splitErr := tracetransform.Split(protoSpans, c.maxSize, proto.Size)
+	for _, rss := range groups {
+		if err := c.uploadTraces(ctx, rss); err != nil {
+			return err
+		}
+	}
+	return splitErr
+}
+
+func (c *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		resp, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
+		resp, err := c.export(iCtx, &coltracepb.ExportTraceServiceRequest{
 			ResourceSpans: protoSpans,
 		})
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedSpans()
+			if n > 0 {
+				c.rejected.Add(iCtx, n)
+			}
 			if n != 0 || msg != "" {
 				err := internal.TracePartialSuccessError(n, msg)
 				otel.Handle(err)
@@ -218,6 +251,45 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 	})
 }
 
+// export sends req with the credentials of the Authenticator, if any. A
+// request rejected as unauthenticated is sent again once after the
+// credentials are refreshed.
+func (c *client) export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	if c.auth == nil {
+		return c.tsc.Export(ctx, req)
+	}
+	authCtx, err := c.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.tsc.Export(authCtx, req)
+	if status.Code(err) != codes.Unauthenticated {
+		return resp, err
+	}
+
+	if rErr := c.auth.Refresh(ctx); rErr != nil {
+		return resp, fmt.Errorf("%w: refresh credentials: %v", err, rErr)
+	}
+	if authCtx, err = c.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return c.tsc.Export(authCtx, req)
+}
+
+// authenticate returns a copy of ctx with the headers of the Authenticator
+// appended to its outgoing metadata.
+func (c *client) authenticate(ctx context.Context) (context.Context, error) {
+	headers, err := c.auth.Headers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+	kv := make([]string, 0, 2*len(headers))
+	for k, v := range headers {
+		kv = append(kv, k, v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
+
 // exportContext returns a copy of parent with an appropriate deadline and
 // cancellation function.
 //
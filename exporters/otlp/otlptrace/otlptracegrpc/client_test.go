@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -76,6 +77,24 @@ func TestNewEndToEnd(t *testing.T) {
 				otlptracegrpc.WithCompressor(gzip.Name),
 			},
 		},
+		{
+			name: "WithZstdCompressor",
+			additionalOpts: []otlptracegrpc.Option{
+				otlptracegrpc.WithCompressor("zstd"),
+			},
+		},
+		{
+			name: "WithSnappyCompressor",
+			additionalOpts: []otlptracegrpc.Option{
+				otlptracegrpc.WithCompressor("snappy"),
+			},
+		},
+		{
+			name: "WithMaxRequestSize",
+			additionalOpts: []otlptracegrpc.Option{
+				otlptracegrpc.WithMaxRequestSize(300),
+			},
+		},
 		{
 			name: "WithServiceConfig",
 			additionalOpts: []otlptracegrpc.Option{
@@ -407,13 +426,110 @@ func TestPartialSuccess(t *testing.T) {
 		errs = append(errs, err)
 	}))
 	ctx := context.Background()
-	exp := newGRPCExporter(t, ctx, mc.endpoint)
+	mp := &otlptracetest.CounterMeterProvider{}
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithMeterProvider(mp))
 	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
 	require.NoError(t, exp.ExportSpans(ctx, roSpans))
 
 	require.Equal(t, 1, len(errs))
 	require.Contains(t, errs[0].Error(), "partially successful")
 	require.Contains(t, errs[0].Error(), "2 spans rejected")
+
+	var psErr otlptracegrpc.PartialSuccessError
+	require.ErrorAs(t, errs[0], &psErr)
+	assert.Equal(t, int64(2), psErr.RejectedItems)
+	assert.Equal(t, int64(2), mp.Sum("otlp.exporter.spans.rejected"))
+}
+
+// refreshingAuthenticator sends a numbered token, incremented when it is
+// refreshed.
+type refreshingAuthenticator struct {
+	n int64
+}
+
+func (a *refreshingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", atomic.LoadInt64(&a.n))}, nil
+}
+
+func (a *refreshingAuthenticator) Refresh(context.Context) error {
+	atomic.AddInt64(&a.n, 1)
+	return nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unauthenticated, "token expired")},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithAuthenticator(&refreshingAuthenticator{}))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+
+	assert.Equal(t, []string{"Bearer token-1"}, mc.getHeaders().Get("authorization"))
+	assert.Len(t, mc.getSpans(), 1)
+}
+
+func TestAuthenticatorUnauthenticated(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{status.Error(codes.Unauthenticated, "invalid credentials")},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithAuthenticator(otlptracegrpc.BasicAuth("user", "pass")))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	err := exp.ExportSpans(ctx, roSpans)
+	assert.ErrorContains(t, err, "invalid credentials")
+	assert.ErrorContains(t, err, "refresh credentials")
+	assert.Empty(t, mc.getSpans())
+}
+
+func TestConcurrencyLimitAndCircuitBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{unavailable, unavailable, unavailable},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	errs := []error{}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	ctx := context.Background()
+	mp := &otlptracetest.CounterMeterProvider{}
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithMeterProvider(mp),
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Nanosecond,
+			MaxInterval:     time.Nanosecond,
+			// Never stop retrying.
+			MaxElapsedTime: 0,
+		}),
+		otlptracegrpc.WithCircuitBreaker(otlptracegrpc.CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 2,
+			OpenTimeout:      time.Hour,
+		}),
+		otlptracegrpc.WithConcurrencyLimit(otlptracegrpc.ConcurrencyLimitConfig{
+			Enabled:      true,
+			InitialLimit: 4,
+		}),
+	)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	err := exp.ExportSpans(ctx, roSpans)
+	assert.ErrorIs(t, err, otlptracegrpc.ErrCircuitOpen)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "circuit breaker opened")
+	assert.Empty(t, mc.getSpans())
+	// The limit is halved for each unavailable response.
+	assert.Equal(t, int64(1), mp.Sum("otlp.exporter.concurrency.limit"))
 }
 
 func TestCustomUserAgent(t *testing.T) {
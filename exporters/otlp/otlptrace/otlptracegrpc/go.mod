@@ -7,6 +7,7 @@ require (
 	github.com/middleware-labs/otel v1.15.0-rc.2
 	github.com/middleware-labs/otel/exporters/otlp/internal/retry v1.15.0-rc.2
 	github.com/middleware-labs/otel/exporters/otlp/otlptrace v1.15.0-rc.2
+	github.com/middleware-labs/otel/metric v1.15.0-rc.2
 	github.com/middleware-labs/otel/sdk v1.15.0-rc.2
 	go.opentelemetry.io/proto/otlp v0.19.0
 	go.uber.org/goleak v1.2.1
@@ -22,8 +23,8 @@ require (
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.7.0 // indirect
+	github.com/klauspost/compress v1.16.7 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
 	github.com/middleware-labs/otel/trace v1.15.0-rc.2 // indirect
 	golang.org/x/net v0.8.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
@@ -24,6 +24,7 @@ import (
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"github.com/middleware-labs/otel/metric"
 )
 
 // Option applies an option to the gRPC driver.
@@ -46,6 +47,24 @@ func asGRPCOptions(opts []Option) []otlpconfig.GRPCOption {
 // entirely handled by the gRPC ClientConn.
 type RetryConfig retry.Config
 
+// CircuitBreakerConfig defines the configuration of a circuit breaker shared
+// by the export requests of the exporter. After FailureThreshold consecutive
+// failed request attempts, the breaker opens and exports fail without being
+// attempted, instead of exhausting their retries, for OpenTimeout. A single
+// trial request is then let through, closing the breaker if it succeeds.
+type CircuitBreakerConfig retry.BreakerConfig
+
+// ConcurrencyLimitConfig defines the configuration of an adaptive limit of
+// the concurrent export requests of the exporter. The limit is increased by
+// one after about limit successful requests, and multiplied by
+// DecreaseFactor after a request fails with a retryable error, between
+// MinLimit and MaxLimit.
+type ConcurrencyLimitConfig retry.LimiterConfig
+
+// ErrCircuitOpen is returned by exports failed without being attempted
+// because the circuit breaker set with WithCircuitBreaker is open.
+var ErrCircuitOpen = retry.ErrCircuitOpen
+
 type wrappedOption struct {
 	otlpconfig.GRPCOption
 }
@@ -84,8 +103,13 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) otlpconfig.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return otlpconfig.GzipCompression
+	case otlpconfig.ZstdName:
+		return otlpconfig.ZstdCompression
+	case otlpconfig.SnappyName:
+		return otlpconfig.SnappyCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -97,7 +121,8 @@ func compressorToCompression(compressor string) otlpconfig.Compression {
 // compressor set has been registered with google.golang.org/grpc/encoding.
 // This can be done by encoding.RegisterCompressor. Some compressors
 // auto-register on import, such as gzip, which can be registered by calling
-// `import _ "google.golang.org/grpc/encoding/gzip"`.
+// `import _ "google.golang.org/grpc/encoding/gzip"`. The "zstd" and "snappy"
+// compressors are registered by this package.
 //
 // This option has no effect if WithGRPCConn is used.
 func WithCompressor(compressor string) Option {
@@ -109,6 +134,18 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{otlpconfig.WithHeaders(headers)}
 }
 
+// WithAuthenticator sets the Authenticator providing the credentials of each
+// export request. Its headers are sent as metadata of the gRPC requests, in
+// addition to the headers of WithHeaders, and it is asked to refresh the
+// credentials when a request is rejected with an Unauthenticated status.
+// BasicAuth, BearerTokenFile, and ClientCredentials return the provided
+// implementations.
+//
+// This option also applies if WithGRPCConn is used.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
 // WithTLSCredentials allows the connection to use TLS credentials when
 // talking to the server. It takes in grpc.TransportCredentials instead of say
 // a Certificate file or a tls.Certificate, because the retrieving of these
@@ -119,10 +156,28 @@ func WithHeaders(headers map[string]string) Option {
 func WithTLSCredentials(creds credentials.TransportCredentials) Option {
 	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
 		cfg.Traces.GRPCCredentials = creds
+		cfg.Traces.CertReloader = nil
 		return cfg
 	})}
 }
 
+// WithClientCertificateFiles sets the files of the PEM encoded client
+// certificate and key used for mutual TLS. The files are loaded again on new
+// TLS handshakes when they have been modified, so rotated certificates are
+// used without recreating the exporter. The client certificates of the TLS
+// configuration are replaced.
+//
+// The files of the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_CLIENT_KEY, or OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE
+// and OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY environment variables are used if
+// this option is not passed.
+//
+// Passing WithTLSCredentials after this option disables it. This option has
+// no effect if WithGRPCConn is used.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{otlpconfig.WithClientCertificateFiles(certFile, keyFile)}
+}
+
 // WithServiceConfig defines the default gRPC service config used.
 //
 // This option has no effect if WithGRPCConn is used.
@@ -171,6 +226,15 @@ func WithTimeout(duration time.Duration) Option {
 	return wrappedOption{otlpconfig.WithTimeout(duration)}
 }
 
+// WithMaxRequestSize sets the maximum size in bytes of the Protobuf
+// serialization of an export request, before compression. Batches of spans
+// exceeding it are split into several requests. Spans exceeding it on their
+// own are dropped and reported as an error. By default, the request size is
+// not limited.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
 // WithRetry sets the retry policy for transient retryable errors that may be
 // returned by the target endpoint when exporting a batch of spans.
 //
@@ -187,3 +251,28 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(settings))}
 }
+
+// WithCircuitBreaker sets the circuit breaker shared by the export requests,
+// failing exports with ErrCircuitOpen while the receiver is unavailable. The
+// opening of the breaker is reported to the global error handler, and its
+// state with the otlp.exporter.circuit_breaker.state up-down counter. If
+// unset, no circuit breaker is used.
+func WithCircuitBreaker(c CircuitBreakerConfig) Option {
+	return wrappedOption{otlpconfig.WithCircuitBreaker(retry.BreakerConfig(c))}
+}
+
+// WithConcurrencyLimit sets the adaptive limit of concurrent export requests.
+// Requests exceeding the limit wait for other requests to complete. The limit
+// is reported with the otlp.exporter.concurrency.limit up-down counter. If
+// unset, the concurrency of export requests is not limited.
+func WithConcurrencyLimit(c ConcurrencyLimitConfig) Option {
+	return wrappedOption{otlpconfig.WithConcurrencyLimit(retry.LimiterConfig(c))}
+}
+
+// WithMeterProvider sets the MeterProvider used to report the number of spans
+// rejected by the receiver in partial success responses, with the
+// otlp.exporter.spans.rejected counter, and the state of the circuit breaker
+// and the concurrency limit. If unset, the global MeterProvider is used.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return wrappedOption{otlpconfig.WithMeterProvider(mp)}
+}
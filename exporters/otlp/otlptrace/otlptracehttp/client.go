@@ -22,11 +22,11 @@ import (
 	"io"
 	"net"
 	"net/http"
-	"net/url"
 	"strconv"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/middleware-labs/otel"
@@ -34,11 +34,32 @@ import (
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/tracetransform"
+	"github.com/middleware-labs/otel/metric/instrument"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
-const contentTypeProto = "application/x-protobuf"
+const (
+	contentTypeProto = "application/x-protobuf"
+	contentTypeJSON  = "application/json"
+
+	// instrumentationName is the name of the Meter reporting the spans
+	// rejected by the receiver and the state of the circuit breaker and the
+	// concurrency limiter.
+	instrumentationName = "github.com/middleware-labs/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+// PartialSuccessError is the error passed to the global error handler when
+// the receiver only accepts part of the exported spans. Use errors.As to read
+// the number of rejected spans and the message of the receiver.
+type PartialSuccessError = internal.PartialSuccess
+
+var (
+	// jsonMarshal encodes enum values as integers as required by OTLP/JSON.
+	jsonMarshal   = protojson.MarshalOptions{UseEnumNumbers: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
 
 var gzPool = sync.Pool{
 	New: func() interface{} {
@@ -72,6 +93,7 @@ type client struct {
 	client      *http.Client
 	stopCh      chan struct{}
 	stopOnce    sync.Once
+	rejected    instrument.Int64Counter
 }
 
 var _ otlptrace.Client = (*client)(nil)
@@ -80,14 +102,17 @@ var _ otlptrace.Client = (*client)(nil)
 func NewClient(opts ...Option) otlptrace.Client {
 	cfg := otlpconfig.NewHTTPConfig(asHTTPOptions(opts)...)
 
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Traces.Timeout,
-	}
-	if cfg.Traces.TLSCfg != nil {
-		transport := ourTransport.Clone()
-		transport.TLSClientConfig = cfg.Traces.TLSCfg
-		httpClient.Transport = transport
+	httpClient := cfg.Traces.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Transport: internal.HTTPTransport(ourTransport, internal.HTTPTransportConfig{
+				Endpoint: cfg.Traces.Endpoint,
+				TLSCfg:   cfg.Traces.TLSCfg,
+				Proxy:    cfg.Traces.Proxy,
+				Dialer:   cfg.Traces.Dialer,
+			}),
+			Timeout: cfg.Traces.Timeout,
+		}
 	}
 
 	stopCh := make(chan struct{})
@@ -95,9 +120,10 @@ func NewClient(opts ...Option) otlptrace.Client {
 		name:        "traces",
 		cfg:         cfg.Traces,
 		generalCfg:  cfg,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
+		requestFunc: cfg.RequestFunc(evaluate, instrumentationName),
 		stopCh:      stopCh,
 		client:      httpClient,
+		rejected:    internal.RejectedSpansCounter(cfg.MeterProvider, instrumentationName),
 	}
 }
 
@@ -125,12 +151,23 @@ func (d *client) Stop(ctx context.Context) error {
 	return nil
 }
 
-// UploadTraces sends a batch of spans to the collector.
+// UploadTraces sends a batch of spans to the collector. The batch is split
+// into several requests if it exceeds the maximum request size.
 func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	groups, splitErr := tracetransform.Split(protoSpans, d.cfg.MaxRequestSize, d.size)
+	for _, rss := range groups {
+		if err := d.uploadTraces(ctx, rss); err != nil {
+			return err
+		}
+	}
+	return splitErr
+}
+
+func (d *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -150,8 +187,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		default:
 		}
 
-		request.reset(ctx)
-		resp, err := d.client.Do(request.Request)
+		resp, err := d.do(ctx, request)
 		if err != nil {
 			return err
 		}
@@ -175,13 +211,16 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 
 			if respData.Len() != 0 {
 				var respProto coltracepb.ExportTraceServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
+				if err := d.unmarshal(respData.Bytes(), &respProto); err != nil {
 					return err
 				}
 
 				if respProto.PartialSuccess != nil {
 					msg := respProto.PartialSuccess.GetErrorMessage()
 					n := respProto.PartialSuccess.GetRejectedSpans()
+					if n > 0 {
+						d.rejected.Add(ctx, n)
+					}
 					if n != 0 || msg != "" {
 						err := internal.TracePartialSuccessError(n, msg)
 						otel.Handle(err)
@@ -203,7 +242,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
-	u := url.URL{Scheme: d.getScheme(), Host: d.cfg.Endpoint, Path: d.cfg.URLPath}
+	u := internal.HTTPRequestURL(d.cfg.Endpoint, d.cfg.URLPath, d.cfg.Insecure)
 	r, err := http.NewRequest(http.MethodPost, u.String(), nil)
 	if err != nil {
 		return request{Request: r}, err
@@ -214,7 +253,11 @@ func (d *client) newRequest(body []byte) (request, error) {
 	for k, v := range d.cfg.Headers {
 		r.Header.Set(k, v)
 	}
-	r.Header.Set("Content-Type", contentTypeProto)
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		r.Header.Set("Content-Type", contentTypeJSON)
+	} else {
+		r.Header.Set("Content-Type", contentTypeProto)
+	}
 
 	req := request{Request: r}
 	switch Compression(d.cfg.Compression) {
@@ -241,11 +284,101 @@ func (d *client) newRequest(body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+	case ZstdCompression, SnappyCompression:
+		compress, encoding := otlpconfig.ZstdCompress, otlpconfig.ZstdName
+		if Compression(d.cfg.Compression) == SnappyCompression {
+			compress, encoding = otlpconfig.SnappyCompress, otlpconfig.SnappyName
+		}
+		b, err := compress(body)
+		if err != nil {
+			return req, err
+		}
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", encoding)
+		req.bodyReader = bodyReader(b)
 	}
 
 	return req, nil
 }
 
+// do sends request with the credentials of the Authenticator, if any. A
+// request rejected as unauthenticated is sent again once after the
+// credentials are refreshed.
+func (d *client) do(ctx context.Context, request request) (*http.Response, error) {
+	if err := d.authenticate(ctx, &request); err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(request.Request)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || d.cfg.Authenticator == nil {
+		return resp, err
+	}
+
+	// Drain the body to reuse the connection.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		otel.Handle(err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		otel.Handle(err)
+	}
+	if err := d.cfg.Authenticator.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send to %s: %s: refresh credentials: %w", request.URL, resp.Status, err)
+	}
+	if err := d.authenticate(ctx, &request); err != nil {
+		return nil, err
+	}
+	return d.client.Do(request.Request)
+}
+
+// authenticate resets request and sets the headers of the Authenticator, if
+// any.
+func (d *client) authenticate(ctx context.Context, request *request) error {
+	request.reset(ctx)
+	if d.cfg.Authenticator == nil {
+		return nil
+	}
+	headers, err := d.cfg.Authenticator.Headers(ctx)
+	if err != nil {
+		return fmt.Errorf("authenticate request: %w", err)
+	}
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	return nil
+}
+
+// marshal encodes msg with the encoding of the client.
+func (d *client) marshal(msg proto.Message) ([]byte, error) {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return proto.Marshal(msg)
+	}
+	b, err := jsonMarshal.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return internal.HexEncodeIDs(b)
+}
+
+// size returns the size of msg encoded by marshal, or zero if it cannot be
+// encoded.
+func (d *client) size(msg proto.Message) int {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return proto.Size(msg)
+	}
+	b, err := d.marshal(msg)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// unmarshal decodes a response with the encoding of the client.
+func (d *client) unmarshal(b []byte, msg proto.Message) error {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return proto.Unmarshal(b, msg)
+	}
+	return jsonUnmarshal.Unmarshal(b, msg)
+}
+
 // MarshalLog is the marshaling function used by the logging system to represent this Client.
 func (d *client) MarshalLog() interface{} {
 	return struct {
@@ -316,13 +449,6 @@ func evaluate(err error) (bool, time.Duration) {
 	return true, time.Duration(rErr.throttle)
 }
 
-func (d *client) getScheme() string {
-	if d.cfg.Insecure {
-		return "http"
-	}
-	return "https"
-}
-
 func (d *client) contextWithStop(ctx context.Context) (context.Context, context.CancelFunc) {
 	// Unify the parent context Done signal with the client's stop
 	// channel.
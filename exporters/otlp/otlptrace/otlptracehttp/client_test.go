@@ -18,9 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -67,6 +72,37 @@ func TestEndToEnd(t *testing.T) {
 				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
 			},
 		},
+		{
+			name: "with zstd compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.ZstdCompression),
+			},
+		},
+		{
+			name: "with snappy compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.SnappyCompression),
+			},
+		},
+		{
+			name: "with max request size",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithMaxRequestSize(300),
+			},
+		},
+		{
+			name: "with JSON encoding",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+			},
+		},
+		{
+			name: "with JSON encoding and gzip compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			},
+		},
 		{
 			name: "retry",
 			opts: []otlptracehttp.Option{
@@ -187,6 +223,118 @@ func TestEndToEnd(t *testing.T) {
 	}
 }
 
+func TestClientCertificateFiles(t *testing.T) {
+	pem, err := generateWeakCertificate()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.Certificate, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.PrivateKey, 0o600))
+
+	mc := runMockCollector(t, mockCollectorConfig{
+		WithTLS:           true,
+		RequireClientCert: true,
+	})
+	defer mc.MustStop(t)
+
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithTLSClientConfig(mc.ClientTLSConfig()),
+		otlptracehttp.WithClientCertificateFiles(certFile, keyFile),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	otlptracetest.RunEndToEndTest(ctx, t, exporter, mc)
+}
+
+func TestUnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, which the directories of
+	// t.TempDir may exceed.
+	dir, err := os.MkdirTemp("", "otlp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mc := runMockCollector(t, mockCollectorConfig{
+		UnixSocket: filepath.Join(dir, "collector.sock"),
+	})
+	defer mc.MustStop(t)
+
+	client := otlptracehttp.NewClient(otlptracehttp.WithEndpoint(mc.Endpoint()))
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	otlptracetest.RunEndToEndTest(ctx, t, exporter, mc)
+}
+
+func TestTransportOptions(t *testing.T) {
+	// The endpoint is never resolved, the requests reach the collector
+	// through the proxy, the dialer, or the HTTP client.
+	const endpoint = "collector.invalid:4318"
+
+	tests := []struct {
+		name string
+		opt  func(collector string) otlptracehttp.Option
+	}{
+		{
+			name: "WithProxy",
+			opt: func(collector string) otlptracehttp.Option {
+				return otlptracehttp.WithProxy(func(*http.Request) (*url.URL, error) {
+					return &url.URL{Scheme: "http", Host: collector}, nil
+				})
+			},
+		},
+		{
+			name: "WithDialer",
+			opt: func(collector string) otlptracehttp.Option {
+				return otlptracehttp.WithDialer(func(ctx context.Context, network, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, network, collector)
+				})
+			},
+		},
+		{
+			name: "WithHTTPClient",
+			opt: func(collector string) otlptracehttp.Option {
+				return otlptracehttp.WithHTTPClient(&http.Client{
+					Transport: &http.Transport{
+						Proxy: http.ProxyURL(&url.URL{Scheme: "http", Host: collector}),
+					},
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := runMockCollector(t, mockCollectorConfig{})
+			defer mc.MustStop(t)
+
+			client := otlptracehttp.NewClient(
+				otlptracehttp.WithEndpoint(endpoint),
+				otlptracehttp.WithInsecure(),
+				otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+				tt.opt(mc.Endpoint()),
+			)
+			ctx := context.Background()
+			exporter, err := otlptrace.New(ctx, client)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(ctx))
+			}()
+			require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+			assert.Len(t, mc.GetSpans(), 1)
+		})
+	}
+}
+
 func TestExporterShutdown(t *testing.T) {
 	mc := runMockCollector(t, mockCollectorConfig{})
 	defer func() {
@@ -256,6 +404,118 @@ func TestNoRetry(t *testing.T) {
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestAuthenticator(t *testing.T) {
+	var tokens int64
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&tokens, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, n)
+	}))
+	defer tokenServer.Close()
+
+	// The first token is rejected, as if it had been revoked.
+	mc := runMockCollector(t, mockCollectorConfig{
+		Authorization: "Bearer token-2",
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithAuthenticator(otlptracehttp.ClientCredentials(otlptracehttp.ClientCredentialsConfig{
+			TokenURL:     tokenServer.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+		})),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&tokens))
+
+	// The refreshed token is reused.
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 2)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&tokens))
+}
+
+func TestAuthenticatorUnauthorized(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		Authorization: "Basic dXNlcjpwYXNz",
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithAuthenticator(otlptracehttp.BasicAuth("user", "wrong")),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorContains(t, err, "401 Unauthorized")
+	assert.ErrorContains(t, err, "refresh credentials")
+	assert.Empty(t, mc.GetSpans())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{
+			http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable,
+		},
+	})
+	defer mc.MustStop(t)
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Nanosecond,
+			MaxInterval:     1 * time.Nanosecond,
+			// Never stop retry of retry-able status.
+			MaxElapsedTime: 0,
+		}),
+		otlptracehttp.WithCircuitBreaker(otlptracehttp.CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 2,
+			OpenTimeout:      time.Hour,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	// The export fails after two attempts instead of retrying forever.
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorIs(t, err, otlptracehttp.ErrCircuitOpen)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "circuit breaker opened")
+
+	// The next export is not attempted.
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorIs(t, err, otlptracehttp.ErrCircuitOpen)
+	assert.Empty(t, mc.GetSpans())
+	assert.Len(t, mc.injectHTTPStatus, 1)
+}
+
 func TestEmptyData(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -371,33 +631,42 @@ func TestStopWhileExporting(t *testing.T) {
 }
 
 func TestPartialSuccess(t *testing.T) {
-	mcCfg := mockCollectorConfig{
-		Partial: &coltracepb.ExportTracePartialSuccess{
-			RejectedSpans: 2,
-			ErrorMessage:  "partially successful",
-		},
-	}
-	mc := runMockCollector(t, mcCfg)
-	defer mc.MustStop(t)
-	driver := otlptracehttp.NewClient(
-		otlptracehttp.WithEndpoint(mc.Endpoint()),
-		otlptracehttp.WithInsecure(),
-	)
-	ctx := context.Background()
-	exporter, err := otlptrace.New(ctx, driver)
-	require.NoError(t, err)
-	defer func() {
-		assert.NoError(t, exporter.Shutdown(context.Background()))
-	}()
+	for _, enc := range []otlptracehttp.Encoding{otlptracehttp.ProtobufEncoding, otlptracehttp.JSONEncoding} {
+		mcCfg := mockCollectorConfig{
+			Partial: &coltracepb.ExportTracePartialSuccess{
+				RejectedSpans: 2,
+				ErrorMessage:  "partially successful",
+			},
+		}
+		mc := runMockCollector(t, mcCfg)
+		mp := &otlptracetest.CounterMeterProvider{}
+		driver := otlptracehttp.NewClient(
+			otlptracehttp.WithEndpoint(mc.Endpoint()),
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithEncoding(enc),
+			otlptracehttp.WithMeterProvider(mp),
+		)
+		ctx := context.Background()
+		exporter, err := otlptrace.New(ctx, driver)
+		require.NoError(t, err)
 
-	errs := []error{}
-	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
-		errs = append(errs, err)
-	}))
-	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
-	assert.NoError(t, err)
+		errs := []error{}
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			errs = append(errs, err)
+		}))
+		err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+		assert.NoError(t, err)
+
+		require.Equal(t, 1, len(errs))
+		require.Contains(t, errs[0].Error(), "partially successful")
+		require.Contains(t, errs[0].Error(), "2 spans rejected")
+
+		var psErr otlptracehttp.PartialSuccessError
+		require.ErrorAs(t, errs[0], &psErr)
+		assert.Equal(t, int64(2), psErr.RejectedItems)
+		assert.Equal(t, int64(2), mp.Sum("otlp.exporter.spans.rejected"))
 
-	require.Equal(t, 1, len(errs))
-	require.Contains(t, errs[0].Error(), "partially successful")
-	require.Contains(t, errs[0].Error(), "2 spans rejected")
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+		mc.MustStop(t)
+	}
 }
@@ -14,6 +14,7 @@
 
 /*
 Package otlptracehttp a client that sends traces to the collector using HTTP
-with binary protobuf payloads.
+with binary protobuf payloads, or OTLP/JSON payloads when the WithEncoding
+option is used.
 */
 package otlptracehttp // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/otlptracehttp"
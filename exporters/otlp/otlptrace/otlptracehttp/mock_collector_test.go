@@ -26,10 +26,14 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/middleware-labs/otel/exporters/otlp/internal"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -51,6 +55,7 @@ type mockCollector struct {
 
 	clientTLSConfig *tls.Config
 	expectedHeaders map[string]string
+	authorization   string
 }
 
 func (c *mockCollector) Stop() error {
@@ -94,10 +99,20 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	if c.authorization != "" && r.Header.Get("Authorization") != c.authorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
 	response := collectortracepb.ExportTraceServiceResponse{
 		PartialSuccess: c.partial,
 	}
-	rawResponse, err := proto.Marshal(&response)
+	var rawResponse []byte
+	var err error
+	if r.Header.Get("content-type") == "application/json" {
+		rawResponse, err = protojson.Marshal(&response)
+	} else {
+		rawResponse, err = proto.Marshal(&response)
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -126,11 +141,19 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 
 func unmarshalTraceRequest(rawRequest []byte, contentType string) (*collectortracepb.ExportTraceServiceRequest, error) {
 	request := &collectortracepb.ExportTraceServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	switch contentType {
+	case "application/x-protobuf":
+		err := proto.Unmarshal(rawRequest, request)
+		return request, err
+	case "application/json":
+		b, err := internal.Base64EncodeIDs(rawRequest)
+		if err != nil {
+			return request, err
+		}
+		err = protojson.Unmarshal(b, request)
+		return request, err
 	}
-	err := proto.Unmarshal(rawRequest, request)
-	return request, err
+	return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 }
 
 func (c *mockCollector) checkHeaders(r *http.Request) bool {
@@ -167,12 +190,26 @@ func (c *mockCollector) getInjectResponseHeader() (h map[string]string) {
 }
 
 func readRequest(r *http.Request) ([]byte, error) {
-	if r.Header.Get("Content-Encoding") == "gzip" {
+	switch r.Header.Get("Content-Encoding") {
+	case "gzip":
 		return readGzipBody(r.Body)
+	case "zstd":
+		return readZstdBody(r.Body)
+	case "snappy":
+		return io.ReadAll(snappy.NewReader(r.Body))
 	}
 	return io.ReadAll(r.Body)
 }
 
+func readZstdBody(body io.Reader) ([]byte, error) {
+	decoder, err := zstd.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+	return io.ReadAll(decoder)
+}
+
 func readGzipBody(body io.Reader) ([]byte, error) {
 	rawRequest := bytes.Buffer{}
 	gunzipper, err := gzip.NewReader(body)
@@ -213,7 +250,13 @@ type mockCollectorConfig struct {
 	Partial              *collectortracepb.ExportTracePartialSuccess
 	Delay                <-chan struct{}
 	WithTLS              bool
+	RequireClientCert    bool
 	ExpectedHeaders      map[string]string
+	// Authorization is the only Authorization header accepted, if set.
+	Authorization string
+	// UnixSocket is the path of the Unix domain socket listened on instead
+	// of Port, if set.
+	UnixSocket string
 }
 
 func (c *mockCollectorConfig) fillInDefaults() {
@@ -224,12 +267,24 @@ func (c *mockCollectorConfig) fillInDefaults() {
 
 func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 	cfg.fillInDefaults()
-	ln, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", cfg.Port))
-	require.NoError(t, err)
-	_, portStr, err := net.SplitHostPort(ln.Addr().String())
-	require.NoError(t, err)
+	var (
+		ln       net.Listener
+		endpoint string
+		err      error
+	)
+	if cfg.UnixSocket != "" {
+		ln, err = net.Listen("unix", cfg.UnixSocket)
+		require.NoError(t, err)
+		endpoint = "unix://" + cfg.UnixSocket
+	} else {
+		ln, err = net.Listen("tcp", fmt.Sprintf("localhost:%d", cfg.Port))
+		require.NoError(t, err)
+		_, portStr, err := net.SplitHostPort(ln.Addr().String())
+		require.NoError(t, err)
+		endpoint = fmt.Sprintf("localhost:%s", portStr)
+	}
 	m := &mockCollector{
-		endpoint:             fmt.Sprintf("localhost:%s", portStr),
+		endpoint:             endpoint,
 		spansStorage:         otlptracetest.NewSpansStorage(),
 		injectHTTPStatus:     cfg.InjectHTTPStatus,
 		injectResponseHeader: cfg.InjectResponseHeader,
@@ -237,6 +292,7 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		partial:              cfg.Partial,
 		delay:                cfg.Delay,
 		expectedHeaders:      cfg.ExpectedHeaders,
+		authorization:        cfg.Authorization,
 	}
 	mux := http.NewServeMux()
 	mux.Handle(cfg.TracesURLPath, http.HandlerFunc(m.serveTraces))
@@ -251,6 +307,9 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		server.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{tlsCertificate},
 		}
+		if cfg.RequireClientCert {
+			server.TLSConfig.ClientAuth = tls.RequireAnyClientCert
+		}
 
 		m.clientTLSConfig = &tls.Config{
 			InsecureSkipVerify: true,
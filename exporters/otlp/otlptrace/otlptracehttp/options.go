@@ -15,11 +15,16 @@
 package otlptracehttp // import "github.com/middleware-labs/otel/exporters/otlp/otlptrace/otlptracehttp"
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/middleware-labs/otel/exporters/otlp/internal/retry"
 	"github.com/middleware-labs/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"github.com/middleware-labs/otel/metric"
 )
 
 // Compression describes the compression used for payloads sent to the
@@ -33,6 +38,24 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(otlpconfig.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(otlpconfig.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with the snappy framing format.
+	SnappyCompression = Compression(otlpconfig.SnappyCompression)
+)
+
+// Encoding describes the encoding of the payloads sent to the collector.
+type Encoding otlpconfig.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads encoded in the
+	// binary Protobuf format (application/x-protobuf).
+	ProtobufEncoding = Encoding(otlpconfig.MarshalProto)
+	// JSONEncoding tells the driver to send payloads encoded in the
+	// OTLP/JSON format (application/json).
+	JSONEncoding = Encoding(otlpconfig.MarshalJSON)
 )
 
 // Option applies an option to the HTTP client.
@@ -52,6 +75,24 @@ func asHTTPOptions(opts []Option) []otlpconfig.HTTPOption {
 // failure using an exponential backoff.
 type RetryConfig retry.Config
 
+// CircuitBreakerConfig defines the configuration of a circuit breaker shared
+// by the export requests of the exporter. After FailureThreshold consecutive
+// failed request attempts, the breaker opens and exports fail without being
+// attempted, instead of exhausting their retries, for OpenTimeout. A single
+// trial request is then let through, closing the breaker if it succeeds.
+type CircuitBreakerConfig retry.BreakerConfig
+
+// ConcurrencyLimitConfig defines the configuration of an adaptive limit of
+// the concurrent export requests of the exporter. The limit is increased by
+// one after about limit successful requests, and multiplied by
+// DecreaseFactor after a request fails with a retryable error, between
+// MinLimit and MaxLimit.
+type ConcurrencyLimitConfig retry.LimiterConfig
+
+// ErrCircuitOpen is returned by exports failed without being attempted
+// because the circuit breaker set with WithCircuitBreaker is open.
+var ErrCircuitOpen = retry.ErrCircuitOpen
+
 type wrappedOption struct {
 	otlpconfig.HTTPOption
 }
@@ -65,6 +106,12 @@ func (w wrappedOption) applyHTTPOption(cfg otlpconfig.Config) otlpconfig.Config
 // unset, it will instead try to use
 // the default endpoint (localhost:4318). Note that the endpoint
 // must not contain any URL path.
+//
+// The endpoint can also be a Unix domain socket, given in the
+// unix:///path/to/socket form, as can the OTEL_EXPORTER_OTLP_ENDPOINT and
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables. The requests are
+// then sent over plain HTTP to the default URL path, or the one of
+// WithURLPath.
 func WithEndpoint(endpoint string) Option {
 	return wrappedOption{otlpconfig.WithEndpoint(endpoint)}
 }
@@ -74,6 +121,24 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{otlpconfig.WithCompression(otlpconfig.Compression(compression))}
 }
 
+// WithEncoding tells the driver the encoding of the sent data. JSONEncoding
+// sends the OTLP/JSON encoding, with hex-encoded trace and span IDs and
+// lowerCamelCase field names. The OTEL_EXPORTER_OTLP_PROTOCOL and
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL environment variables set to
+// "http/protobuf" or "http/json" are used if this option is not passed.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{otlpconfig.WithMarshaler(otlpconfig.Marshaler(encoding))}
+}
+
+// WithMaxRequestSize sets the maximum size in bytes of the serialization of
+// an export request with the configured encoding, before compression.
+// Batches of spans exceeding it are split into several requests. Spans
+// exceeding it on their own are dropped and reported as an error. By default,
+// the request size is not limited.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
 // WithURLPath allows one to override the default URL path used
 // for sending traces. If unset, default ("/v1/traces") will be used.
 func WithURLPath(urlPath string) Option {
@@ -87,6 +152,53 @@ func WithTLSClientConfig(tlsCfg *tls.Config) Option {
 	return wrappedOption{otlpconfig.WithTLSClientConfig(tlsCfg)}
 }
 
+// WithClientCertificateFiles sets the files of the PEM encoded client
+// certificate and key used for mutual TLS. The files are loaded again on new
+// TLS handshakes when they have been modified, so rotated certificates are
+// used without recreating the exporter. The client certificates of the TLS
+// configuration are replaced.
+//
+// The files of the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_CLIENT_KEY, or OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE
+// and OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY environment variables are used if
+// this option is not passed.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{otlpconfig.WithClientCertificateFiles(certFile, keyFile)}
+}
+
+// HTTPTransportProxyFunc is a function that resolves which URL to use as
+// proxy for a given request. It has the signature of the Proxy field of
+// http.Transport.
+type HTTPTransportProxyFunc func(*http.Request) (*url.URL, error)
+
+// WithProxy sets the function resolving the proxy the requests are sent
+// through. Returning a nil URL sends a request without proxy. If this option
+// is not passed, http.ProxyFromEnvironment is used, which reads the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. Requests to a
+// Unix domain socket are never sent through a proxy.
+func WithProxy(pf HTTPTransportProxyFunc) Option {
+	return wrappedOption{otlpconfig.WithProxy(otlpconfig.HTTPTransportProxyFunc(pf))}
+}
+
+// WithDialer sets the function establishing the network connections to the
+// collector, instead of a net.Dialer. For Unix domain socket endpoints, it is
+// called with the "unix" network and the path of the socket.
+func WithDialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return wrappedOption{otlpconfig.WithDialer(dial)}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests. It takes
+// precedence over WithTLSClientConfig, WithClientCertificateFiles,
+// WithProxy, WithDialer, and WithTimeout, as well as the
+// OTEL_EXPORTER_OTLP_CERTIFICATE, OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE, and
+// OTEL_EXPORTER_OTLP_TIMEOUT
+// environment variables: these settings must be configured on the client,
+// which is used as is. The endpoint, with the URL path, only determines the
+// URL of the requests.
+func WithHTTPClient(c *http.Client) Option {
+	return wrappedOption{otlpconfig.WithHTTPClient(c)}
+}
+
 // WithInsecure tells the driver to connect to the collector using the
 // HTTP scheme, instead of HTTPS.
 func WithInsecure() Option {
@@ -100,6 +212,15 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{otlpconfig.WithHeaders(headers)}
 }
 
+// WithAuthenticator sets the Authenticator providing the credentials of each
+// export request. Its headers are sent with the HTTP requests, overriding
+// the headers of WithHeaders, and it is asked to refresh the credentials when
+// a request is rejected with a 401 Unauthorized status. BasicAuth,
+// BearerTokenFile, and ClientCredentials return the provided implementations.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
 // WithTimeout tells the driver the max waiting time for the backend to process
 // each spans batch.  If unset, the default will be 10 seconds.
 func WithTimeout(duration time.Duration) Option {
@@ -114,3 +235,28 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(rc))}
 }
+
+// WithCircuitBreaker sets the circuit breaker shared by the export requests,
+// failing exports with ErrCircuitOpen while the receiver is unavailable. The
+// opening of the breaker is reported to the global error handler, and its
+// state with the otlp.exporter.circuit_breaker.state up-down counter. If
+// unset, no circuit breaker is used.
+func WithCircuitBreaker(c CircuitBreakerConfig) Option {
+	return wrappedOption{otlpconfig.WithCircuitBreaker(retry.BreakerConfig(c))}
+}
+
+// WithConcurrencyLimit sets the adaptive limit of concurrent export requests.
+// Requests exceeding the limit wait for other requests to complete. The limit
+// is reported with the otlp.exporter.concurrency.limit up-down counter. If
+// unset, the concurrency of export requests is not limited.
+func WithConcurrencyLimit(c ConcurrencyLimitConfig) Option {
+	return wrappedOption{otlpconfig.WithConcurrencyLimit(retry.LimiterConfig(c))}
+}
+
+// WithMeterProvider sets the MeterProvider used to report the number of spans
+// rejected by the receiver in partial success responses, with the
+// otlp.exporter.spans.rejected counter, and the state of the circuit breaker
+// and the concurrency limit. If unset, the global MeterProvider is used.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return wrappedOption{otlpconfig.WithMeterProvider(mp)}
+}
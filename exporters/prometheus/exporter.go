@@ -169,6 +169,8 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 				addGaugeMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
 			case metricdata.Gauge[float64]:
 				addGaugeMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+			case metricdata.Summary:
+				addSummaryMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
 			}
 		}
 	}
@@ -204,6 +206,32 @@ func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogra
 	}
 }
 
+func addSummaryMetric(ch chan<- prometheus.Metric, summary metricdata.Summary, m metricdata.Metrics, ks, vs [2]string, name string, mfs map[string]*dto.MetricFamily) {
+	drop, help := validateMetrics(name, m.Description, dto.MetricType_SUMMARY.Enum(), mfs)
+	if drop {
+		return
+	}
+	if help != "" {
+		m.Description = help
+	}
+
+	for _, dp := range summary.DataPoints {
+		keys, values := getAttrs(dp.Attributes, ks, vs)
+
+		desc := prometheus.NewDesc(name, m.Description, keys, nil)
+		quantiles := make(map[float64]float64, len(dp.QuantileValues))
+		for _, q := range dp.QuantileValues {
+			quantiles[q.Quantile] = q.Value
+		}
+		m, err := prometheus.NewConstSummary(desc, dp.Count, dp.Sum, quantiles, values...)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		ch <- m
+	}
+}
+
 func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, ks, vs [2]string, name string, mfs map[string]*dto.MetricFamily) {
 	valueType := prometheus.CounterValue
 	metricType := dto.MetricType_COUNTER
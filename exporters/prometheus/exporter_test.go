@@ -29,6 +29,7 @@ import (
 	"github.com/middleware-labs/otel/metric/instrument"
 	"github.com/middleware-labs/otel/sdk/metric"
 	"github.com/middleware-labs/otel/sdk/metric/aggregation"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata"
 	"github.com/middleware-labs/otel/sdk/resource"
 	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
 )
@@ -406,6 +407,47 @@ func TestMultiScopes(t *testing.T) {
 	require.NoError(t, err)
 }
 
+type producerFunc func(context.Context) ([]metricdata.ScopeMetrics, error)
+
+func (f producerFunc) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
+	return f(ctx)
+}
+
+func TestSummary(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	exporter, err := New(WithRegisterer(registry), WithoutScopeInfo(), WithoutTargetInfo())
+	require.NoError(t, err)
+
+	// Summaries are only produced by bridges, not by OpenTelemetry instruments.
+	exporter.RegisterProducer(producerFunc(func(context.Context) ([]metricdata.ScopeMetrics, error) {
+		return []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name:        "latency",
+				Description: "a summary from a bridge",
+				Data: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{{
+						Attributes: attribute.NewSet(attribute.String("A", "B")),
+						Count:      10,
+						Sum:        150,
+						QuantileValues: []metricdata.QuantileValue{
+							{Quantile: 0.5, Value: 12},
+							{Quantile: 0.99, Value: 40},
+						},
+					}},
+				},
+			}},
+		}}, nil
+	}))
+	_ = metric.NewMeterProvider(metric.WithReader(exporter))
+
+	file, err := os.Open("testdata/summary.txt")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, file.Close()) })
+
+	err = testutil.GatherAndCompare(registry, file)
+	require.NoError(t, err)
+}
+
 func TestDuplicateMetrics(t *testing.T) {
 	testCases := []struct {
 		name                  string
@@ -136,6 +136,10 @@ func redactAggregationTimestamps(orig metricdata.Aggregation) metricdata.Aggrega
 			Temporality: a.Temporality,
 			DataPoints:  redactHistogramTimestamps(a.DataPoints),
 		}
+	case metricdata.Summary:
+		return metricdata.Summary{
+			DataPoints: redactSummaryTimestamps(a.DataPoints),
+		}
 	default:
 		global.Error(errUnknownAggType, fmt.Sprintf("%T", a))
 		return orig
@@ -158,6 +162,19 @@ func redactHistogramTimestamps[T int64 | float64](hdp []metricdata.HistogramData
 	return out
 }
 
+func redactSummaryTimestamps(sdp []metricdata.SummaryDataPoint) []metricdata.SummaryDataPoint {
+	out := make([]metricdata.SummaryDataPoint, len(sdp))
+	for i, dp := range sdp {
+		out[i] = metricdata.SummaryDataPoint{
+			Attributes:     dp.Attributes,
+			Count:          dp.Count,
+			Sum:            dp.Sum,
+			QuantileValues: dp.QuantileValues,
+		}
+	}
+	return out
+}
+
 func redactDataPointTimestamps[T int64 | float64](sdp []metricdata.DataPoint[T]) []metricdata.DataPoint[T] {
 	out := make([]metricdata.DataPoint[T], len(sdp))
 	for i, dp := range sdp {
@@ -14,12 +14,19 @@
 
 package zipkin // import "github.com/middleware-labs/otel/exporters/zipkin"
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 // Environment variable names.
 const (
 	// Endpoint for Zipkin collector.
 	envEndpoint = "OTEL_EXPORTER_ZIPKIN_ENDPOINT"
+	// Maximum time, in milliseconds, the exporter waits for each batch
+	// export.
+	envTimeout = "OTEL_EXPORTER_ZIPKIN_TIMEOUT"
 )
 
 // envOr returns an env variable's value if it is exists or the default if not.
@@ -29,3 +36,17 @@ func envOr(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// envDurationOr returns the duration in milliseconds held by an env variable
+// if it exists and is a valid non-negative integer, or the default if not.
+func envDurationOr(key string, defaultValue time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	ms, err := strconv.Atoi(v)
+	if err != nil || ms < 0 {
+		return defaultValue
+	}
+	return time.Duration(ms) * time.Millisecond
+}
@@ -17,6 +17,7 @@ package zipkin
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,3 +61,25 @@ func TestEnvOrWithCollectorEndpointOptionsFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvDurationOr(t *testing.T) {
+	envStore := ottest.NewEnvStore()
+	envStore.Record(envTimeout)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+
+	for _, tc := range []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: defaultTimeout},
+		{value: "500", want: 500 * time.Millisecond},
+		{value: "0", want: 0},
+		{value: "-1", want: defaultTimeout},
+		{value: "invalid", want: defaultTimeout},
+	} {
+		require.NoError(t, os.Setenv(envTimeout, tc.value))
+		assert.Equal(t, tc.want, envDurationOr(envTimeout, defaultTimeout), tc.value)
+	}
+}
@@ -3,21 +3,23 @@ module github.com/middleware-labs/otel/exporters/zipkin
 go 1.19
 
 require (
+	github.com/cenkalti/backoff/v4 v4.2.0
 	github.com/go-logr/logr v1.2.4
 	github.com/go-logr/stdr v1.2.2
 	github.com/google/go-cmp v0.5.9
-	github.com/openzipkin/zipkin-go v0.4.1
-	github.com/stretchr/testify v1.8.2
 	github.com/middleware-labs/otel v1.15.0-rc.2
 	github.com/middleware-labs/otel/sdk v1.15.0-rc.2
 	github.com/middleware-labs/otel/trace v1.15.0-rc.2
+	github.com/openzipkin/zipkin-go v0.4.1
+	github.com/stretchr/testify v1.8.2
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin // import "github.com/middleware-labs/otel/exporters/zipkin"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryConfig defines configuration for retrying batches in case of export
+// failure using an exponential backoff. It mirrors the retry configuration
+// of the OTLP exporters, whose implementation is internal to
+// github.com/middleware-labs/otel/exporters/otlp and cannot be shared.
+type RetryConfig struct {
+	// Enabled indicates whether or not to retry sending batches in case of
+	// export failure.
+	Enabled bool
+	// InitialInterval the time to wait after the first failure before
+	// retrying. If zero, 5 seconds is used.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on backoff interval. Once this value is
+	// reached the delay between consecutive retries will always be
+	// `MaxInterval`. If zero, 30 seconds is used.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request/batch.  Once this value is reached, the data
+	// is discarded. If zero, 1 minute is used.
+	MaxElapsedTime time.Duration
+}
+
+// requestFunc wraps a request with retry logic.
+type requestFunc func(context.Context, func(context.Context) error) error
+
+// evaluateFunc returns if an error is retry-able and if an explicit throttle
+// duration should be honored that was included in the error.
+type evaluateFunc func(error) (bool, time.Duration)
+
+// requestFunc returns a requestFunc using the evaluate function to determine
+// if requests can be retried and based on the exponential backoff
+// configuration of c.
+func (c RetryConfig) requestFunc(evaluate evaluateFunc) requestFunc {
+	if !c.Enabled {
+		return func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}
+	}
+
+	return func(ctx context.Context, fn func(context.Context) error) error {
+		b := &backoff.ExponentialBackOff{
+			InitialInterval:     nonZero(c.InitialInterval, 5*time.Second),
+			RandomizationFactor: backoff.DefaultRandomizationFactor,
+			Multiplier:          backoff.DefaultMultiplier,
+			MaxInterval:         nonZero(c.MaxInterval, 30*time.Second),
+			MaxElapsedTime:      nonZero(c.MaxElapsedTime, time.Minute),
+			Stop:                backoff.Stop,
+			Clock:               backoff.SystemClock,
+		}
+		b.Reset()
+
+		for {
+			err := fn(ctx)
+			if err == nil {
+				return nil
+			}
+
+			retryable, throttle := evaluate(err)
+			if !retryable {
+				return err
+			}
+
+			bOff := b.NextBackOff()
+			if bOff == backoff.Stop {
+				return fmt.Errorf("max retry time elapsed: %w", err)
+			}
+
+			// Wait for the greater of the backoff or throttle delay.
+			delay := bOff
+			if throttle > bOff {
+				if elapsed := b.GetElapsedTime(); elapsed+throttle > b.MaxElapsedTime {
+					return fmt.Errorf("max retry time would elapse: %w", err)
+				}
+				delay = throttle
+			}
+
+			if ctxErr := waitFunc(ctx, delay); ctxErr != nil {
+				return fmt.Errorf("%w: %s", ctxErr, err)
+			}
+		}
+	}
+}
+
+// nonZero returns v if it is non-zero, otherwise alt.
+func nonZero(v, alt time.Duration) time.Duration {
+	if v != 0 {
+		return v
+	}
+	return alt
+}
+
+// Allow override for testing.
+var waitFunc = wait
+
+// wait takes the caller's context, and the amount of time to wait. It will
+// return nil if the timer fires before or at the same time as the context's
+// deadline. This indicates that the call can be retried.
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Handle the case where the timer and context deadline end
+		// simultaneously by prioritizing the timer expiration nil value
+		// response.
+		select {
+		case <-timer.C:
+		default:
+			return ctx.Err()
+		}
+	case <-timer.C:
+	}
+
+	return nil
+}
+
+// requestError is an error returned from a request to the Zipkin collector
+// that failed to send or was rejected.
+type requestError struct {
+	// status is the response status code. It is zero if no response was
+	// received.
+	status int
+	// retryAfter is the value of the Retry-After response header.
+	retryAfter string
+	err        error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// evaluate returns if err is retry-able and the throttle delay requested by
+// the Zipkin collector.
+func evaluate(err error) (bool, time.Duration) {
+	var rErr *requestError
+	if !errors.As(err, &rErr) {
+		return false, 0
+	}
+
+	switch rErr.status {
+	case 0:
+		// A network error, the collector may be temporarily unavailable.
+		return true, 0
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true, parseRetryAfter(rErr.retryAfter)
+	default:
+		return false, 0
+	}
+}
+
+// parseRetryAfter returns the delay of a Retry-After header value. Both the
+// delay-seconds and the HTTP-date forms are supported.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -16,6 +16,7 @@ package zipkin // import "github.com/middleware-labs/otel/exporters/zipkin"
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -24,22 +25,53 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 
 	sdktrace "github.com/middleware-labs/otel/sdk/trace"
 )
 
 const (
 	defaultCollectorURL = "http://localhost:9411/api/v2/spans"
+	defaultTimeout      = 10 * time.Second
+)
+
+// Encoding is the format spans are encoded in when sent to Zipkin.
+type Encoding int
+
+const (
+	// JSONEncoding encodes spans using the Zipkin v2 JSON format
+	// (application/json).
+	JSONEncoding Encoding = iota
+	// ProtobufEncoding encodes spans using the Zipkin v2 proto3 format
+	// (application/x-protobuf).
+	ProtobufEncoding
+)
+
+// Compression describes the compression used for payloads sent to Zipkin.
+type Compression int
+
+const (
+	// NoCompression tells the exporter to not compress payloads.
+	NoCompression Compression = iota
+	// GzipCompression tells the exporter to gzip compress payloads.
+	GzipCompression
 )
 
 // Exporter exports spans to the zipkin collector.
 type Exporter struct {
-	url    string
-	client *http.Client
-	logger logr.Logger
+	url            string
+	client         *http.Client
+	logger         logr.Logger
+	encoding       Encoding
+	compression    Compression
+	maxPayloadSize int
+	timeout        time.Duration
+	requestFunc    requestFunc
 
 	stoppedMu sync.RWMutex
 	stopped   bool
@@ -51,8 +83,13 @@ var emptyLogger = logr.Logger{}
 
 // Options contains configuration for the exporter.
 type config struct {
-	client *http.Client
-	logger logr.Logger
+	client         *http.Client
+	logger         logr.Logger
+	encoding       Encoding
+	compression    Compression
+	retry          RetryConfig
+	maxPayloadSize int
+	timeout        time.Duration
 }
 
 // Option defines a function that configures the exporter.
@@ -89,6 +126,63 @@ func WithClient(client *http.Client) Option {
 	})
 }
 
+// WithEncoding configures the format spans are encoded in. By default,
+// JSONEncoding is used.
+func WithEncoding(encoding Encoding) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.encoding = encoding
+		return cfg
+	})
+}
+
+// WithCompression configures the compression used for payloads. By
+// default, NoCompression is used.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.compression = compression
+		return cfg
+	})
+}
+
+// WithRetry configures the retry policy for transient errors that may
+// occur when exporting spans. Requests failing with a 429, 502, 503 or 504
+// status code, or with a network error, are retried. A Retry-After header
+// sent with the response is honored.
+//
+// By default, failed requests are not retried.
+func WithRetry(rc RetryConfig) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.retry = rc
+		return cfg
+	})
+}
+
+// WithMaxPayloadSize configures the maximum size, in bytes, of an encoded and
+// uncompressed payload. Batches of spans that encode to a larger payload are
+// split and sent in multiple requests. A single span exceeding the limit is
+// sent on its own. Values less than or equal to zero disable the limit.
+//
+// By default, no limit is applied.
+func WithMaxPayloadSize(size int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxPayloadSize = size
+		return cfg
+	})
+}
+
+// WithTimeout configures the maximum time an export of a batch of spans,
+// including all retries and split requests, may take.
+//
+// If this option is not passed, the value of the
+// OTEL_EXPORTER_ZIPKIN_TIMEOUT environment variable (in milliseconds) is
+// used. If that is not set, a timeout of 10 seconds is used.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.timeout = timeout
+		return cfg
+	})
+}
+
 // New creates a new Zipkin exporter.
 func New(collectorURL string, opts ...Option) (*Exporter, error) {
 	if collectorURL == "" {
@@ -103,7 +197,9 @@ func New(collectorURL string, opts ...Option) (*Exporter, error) {
 		return nil, fmt.Errorf("invalid collector URL %q: no scheme or host", collectorURL)
 	}
 
-	cfg := config{}
+	cfg := config{
+		timeout: envDurationOr(envTimeout, defaultTimeout),
+	}
 	for _, opt := range opts {
 		cfg = opt.apply(cfg)
 	}
@@ -112,9 +208,14 @@ func New(collectorURL string, opts ...Option) (*Exporter, error) {
 		cfg.client = http.DefaultClient
 	}
 	return &Exporter{
-		url:    collectorURL,
-		client: cfg.client,
-		logger: cfg.logger,
+		url:            collectorURL,
+		client:         cfg.client,
+		logger:         cfg.logger,
+		encoding:       cfg.encoding,
+		compression:    cfg.compression,
+		maxPayloadSize: cfg.maxPayloadSize,
+		timeout:        cfg.timeout,
+		requestFunc:    cfg.retry.requestFunc(evaluate),
 	}, nil
 }
 
@@ -132,20 +233,83 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 		e.logf("no spans to export")
 		return nil
 	}
-	models := SpanModels(spans)
-	body, err := json.Marshal(models)
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	return e.export(ctx, SpanModels(spans))
+}
+
+// export sends models to Zipkin, splitting them into multiple requests if
+// their payload exceeds the maximum payload size.
+func (e *Exporter) export(ctx context.Context, models []zkmodel.SpanModel) error {
+	body, err := e.marshal(models)
 	if err != nil {
-		return e.errf("failed to serialize zipkin models to JSON: %v", err)
+		return e.errf("failed to serialize zipkin models: %v", err)
+	}
+
+	if e.maxPayloadSize > 0 && len(body) > e.maxPayloadSize && len(models) > 1 {
+		half := len(models) / 2
+		// Send the second half even if the first fails so as much data as
+		// possible is delivered.
+		errFirst := e.export(ctx, models[:half])
+		errSecond := e.export(ctx, models[half:])
+		if errFirst != nil {
+			return errFirst
+		}
+		return errSecond
+	}
+
+	if e.encoding == JSONEncoding {
+		e.logf("about to send a POST request to %s with body %s", e.url, body)
+	} else {
+		e.logf("about to send a POST request to %s with %d bytes", e.url, len(body))
+	}
+
+	if e.compression == GzipCompression {
+		if body, err = gzipBytes(body); err != nil {
+			return e.errf("failed to compress payload: %v", err)
+		}
 	}
-	e.logf("about to send a POST request to %s with body %s", e.url, body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(body))
+
+	return e.requestFunc(ctx, func(ctx context.Context) error {
+		return e.send(ctx, body)
+	})
+}
+
+// marshal encodes models using the configured encoding.
+func (e *Exporter) marshal(models []zkmodel.SpanModel) ([]byte, error) {
+	if e.encoding != ProtobufEncoding {
+		return json.Marshal(models)
+	}
+
+	sms := make([]*zkmodel.SpanModel, len(models))
+	for i := range models {
+		sms[i] = &models[i]
+	}
+	return zipkin_proto3.SpanSerializer{}.Serialize(sms)
+}
+
+// send makes a single request with body to the Zipkin collector.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
 	if err != nil {
 		return e.errf("failed to create request to %s: %v", e.url, err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if e.encoding == ProtobufEncoding {
+		req.Header.Set("Content-Type", zipkin_proto3.SpanSerializer{}.ContentType())
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if e.compression == GzipCompression {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return e.errf("request to %s failed: %v", e.url, err)
+		return &requestError{err: e.errf("request to %s failed: %v", e.url, err)}
 	}
 	defer resp.Body.Close()
 
@@ -159,12 +323,28 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 	}
 
 	if resp.StatusCode != http.StatusAccepted {
-		return e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+		return &requestError{
+			status:     resp.StatusCode,
+			retryAfter: resp.Header.Get("Retry-After"),
+			err:        e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode),
+		}
 	}
 
 	return nil
 }
 
+func gzipBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(b); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Shutdown stops the exporter flushing any pending exports.
 func (e *Exporter) Shutdown(ctx context.Context) error {
 	e.stoppedMu.Lock()
@@ -15,6 +15,8 @@
 package zipkin
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -22,6 +24,8 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -29,6 +33,7 @@ import (
 	ottest "github.com/middleware-labs/otel/internal/internaltest"
 
 	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -364,3 +369,171 @@ func TestErrorOnExportShutdownExporter(t *testing.T) {
 	assert.NoError(t, exp.Shutdown(context.Background()))
 	assert.NoError(t, exp.ExportSpans(context.Background(), nil))
 }
+
+func testSpans(n int) []sdktrace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, n)
+	for i := range stubs {
+		stubs[i] = tracetest.SpanStub{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{0x01},
+				SpanID:  trace.SpanID{byte(i + 1)},
+			}),
+			SpanKind:  trace.SpanKindServer,
+			Name:      fmt.Sprintf("span-%d", i),
+			StartTime: time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC),
+			EndTime:   time.Date(2020, time.March, 11, 19, 25, 0, 0, time.UTC),
+		}
+	}
+	return stubs.Snapshots()
+}
+
+type recordingCollector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+// newRecordingCollector returns a collector responding to requests with
+// statuses in order. Once statuses are exhausted, 202 is returned.
+func newRecordingCollector(t *testing.T, statuses ...int) *recordingCollector {
+	c := &recordingCollector{statuses: statuses}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.requests = append(c.requests, r)
+		c.bodies = append(c.bodies, body)
+		status := http.StatusAccepted
+		if len(c.statuses) > 0 {
+			status, c.statuses = c.statuses[0], c.statuses[1:]
+		}
+		if status == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *recordingCollector) Requests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+func TestExportSpansProtobufGzip(t *testing.T) {
+	collector := newRecordingCollector(t)
+	exp, err := New(collector.URL, WithEncoding(ProtobufEncoding), WithCompression(GzipCompression))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), testSpans(2)))
+	require.Equal(t, 1, collector.Requests())
+
+	req := collector.requests[0]
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+
+	gz, err := gzip.NewReader(bytes.NewReader(collector.bodies[0]))
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	models, err := zipkin_proto3.ParseSpans(body, false)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	assert.Equal(t, "span-0", models[0].Name)
+	assert.Equal(t, "span-1", models[1].Name)
+}
+
+func TestExportSpansRetry(t *testing.T) {
+	rc := RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Minute,
+	}
+
+	t.Run("Retryable", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		exp, err := New(collector.URL, WithRetry(rc))
+		require.NoError(t, err)
+
+		require.NoError(t, exp.ExportSpans(context.Background(), testSpans(1)))
+		assert.Equal(t, 3, collector.Requests())
+	})
+
+	t.Run("NonRetryable", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusBadRequest)
+		exp, err := New(collector.URL, WithRetry(rc))
+		require.NoError(t, err)
+
+		assert.Error(t, exp.ExportSpans(context.Background(), testSpans(1)))
+		assert.Equal(t, 1, collector.Requests())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusServiceUnavailable)
+		exp, err := New(collector.URL)
+		require.NoError(t, err)
+
+		assert.Error(t, exp.ExportSpans(context.Background(), testSpans(1)))
+		assert.Equal(t, 1, collector.Requests())
+	})
+}
+
+func TestExportSpansMaxPayloadSize(t *testing.T) {
+	spans := testSpans(5)
+	single, err := json.Marshal(SpanModels(spans[:1]))
+	require.NoError(t, err)
+
+	collector := newRecordingCollector(t)
+	exp, err := New(collector.URL, WithMaxPayloadSize(2*len(single)+1))
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+
+	var total int
+	for _, body := range collector.bodies {
+		assert.LessOrEqual(t, len(body), 2*len(single)+1)
+		var models []zkmodel.SpanModel
+		require.NoError(t, json.Unmarshal(body, &models))
+		total += len(models)
+	}
+	assert.Equal(t, len(spans), total)
+	assert.Greater(t, collector.Requests(), 1)
+}
+
+func TestExportSpansTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(block) })
+
+	exp, err := New(srv.URL, WithTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.ExportSpans(context.Background(), testSpans(1)), context.DeadlineExceeded.Error())
+}
+
+func TestNewTimeoutFromEnv(t *testing.T) {
+	envStore := ottest.NewEnvStore()
+	envStore.Record(envTimeout)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+
+	require.NoError(t, os.Setenv(envTimeout, "2500"))
+	exp, err := New("")
+	require.NoError(t, err)
+	assert.Equal(t, 2500*time.Millisecond, exp.timeout)
+
+	exp, err = New("", WithTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, exp.timeout)
+}
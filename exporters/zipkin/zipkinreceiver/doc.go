@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zipkinreceiver converts spans received in the Zipkin v2 format into
+// OpenTelemetry spans and forwards them to a SpanExporter.
+//
+// It is intended to ease the migration of applications instrumented with a
+// Zipkin client: spans those applications report can be received in the same
+// process, or in a small sidecar, and exported with any OpenTelemetry
+// exporter. Use NewHandler to receive spans over HTTP (the Zipkin
+// /api/v2/spans endpoint) and ListenUDP to receive them as UDP datagrams.
+//
+// The conversion is the reverse of the one applied by the Zipkin exporter.
+// Because the exporter merges resource attributes into the span tags, all
+// received tags except the ones with a special meaning are set as span
+// attributes. Only the service name of the local endpoint is used for the
+// span resource.
+//
+// The SpanExporter interface does not require exporters to be safe for
+// concurrent use, so the exports of an exporter are serialized, even when it
+// is shared by several handlers and listeners. Exports of different exporters
+// run concurrently.
+package zipkinreceiver // import "github.com/middleware-labs/otel/exporters/zipkin/zipkinreceiver"
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver // import "github.com/middleware-labs/otel/exporters/zipkin/zipkinreceiver"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/sdk/resource"
+	tracesdk "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+const (
+	keyInstrumentationLibraryName    = "otel.library.name"
+	keyInstrumentationLibraryVersion = "otel.library.version"
+	keyStatusCode                    = "otel.status_code"
+	keyError                         = "error"
+)
+
+// ParseJSON decodes a Zipkin v2 JSON encoded list of spans into SpanStubs.
+func ParseJSON(data []byte) (tracetest.SpanStubs, error) {
+	var models []zkmodel.SpanModel
+	if err := json.Unmarshal(data, &models); err != nil {
+		return nil, err
+	}
+	return SpanStubs(models), nil
+}
+
+// ParseProtobuf decodes a Zipkin v2 proto3 encoded list of spans into
+// SpanStubs.
+func ParseProtobuf(data []byte) (tracetest.SpanStubs, error) {
+	models, err := zipkin_proto3.ParseSpans(data, false)
+	if err != nil {
+		return nil, err
+	}
+	stubs := make(tracetest.SpanStubs, 0, len(models))
+	for _, m := range models {
+		stubs = append(stubs, SpanStub(*m))
+	}
+	return stubs, nil
+}
+
+// SpanStubs converts Zipkin model spans into SpanStubs.
+func SpanStubs(models []zkmodel.SpanModel) tracetest.SpanStubs {
+	if len(models) == 0 {
+		return nil
+	}
+	stubs := make(tracetest.SpanStubs, 0, len(models))
+	for _, m := range models {
+		stubs = append(stubs, SpanStub(m))
+	}
+	return stubs
+}
+
+// SpanStub converts a Zipkin model span into a SpanStub.
+func SpanStub(m zkmodel.SpanModel) tracetest.SpanStub {
+	stub := tracetest.SpanStub{
+		Name:        m.Name,
+		SpanContext: spanContext(m.SpanContext, m.ID),
+		SpanKind:    spanKind(m.Kind),
+		StartTime:   m.Timestamp,
+		EndTime:     m.Timestamp.Add(m.Duration),
+		Events:      events(m.Annotations),
+		Resource:    spanResource(m.LocalEndpoint),
+	}
+	if m.ParentID != nil {
+		stub.Parent = spanContext(m.SpanContext, *m.ParentID)
+	}
+
+	var statusCode, errDesc string
+	var hasError bool
+	for _, k := range sortedKeys(m.Tags) {
+		v := m.Tags[k]
+		switch k {
+		case keyStatusCode:
+			statusCode = v
+		case keyError:
+			hasError, errDesc = true, v
+		case keyInstrumentationLibraryName:
+			stub.InstrumentationLibrary.Name = v
+		case keyInstrumentationLibraryVersion:
+			stub.InstrumentationLibrary.Version = v
+		default:
+			stub.Attributes = append(stub.Attributes, attribute.String(k, v))
+		}
+	}
+	stub.Attributes = append(stub.Attributes, remoteEndpointAttributes(m.RemoteEndpoint)...)
+	stub.Status = status(statusCode, hasError, errDesc)
+	return stub
+}
+
+func spanContext(sc zkmodel.SpanContext, id zkmodel.ID) trace.SpanContext {
+	var scc trace.SpanContextConfig
+	binary.BigEndian.PutUint64(scc.TraceID[:8], sc.TraceID.High)
+	binary.BigEndian.PutUint64(scc.TraceID[8:], sc.TraceID.Low)
+	binary.BigEndian.PutUint64(scc.SpanID[:], uint64(id))
+	// Received spans have been recorded, unless explicitly marked as not
+	// sampled they are considered sampled.
+	if sc.Debug || sc.Sampled == nil || *sc.Sampled {
+		scc.TraceFlags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(scc)
+}
+
+func spanKind(k zkmodel.Kind) trace.SpanKind {
+	switch k {
+	case zkmodel.Server:
+		return trace.SpanKindServer
+	case zkmodel.Client:
+		return trace.SpanKindClient
+	case zkmodel.Producer:
+		return trace.SpanKindProducer
+	case zkmodel.Consumer:
+		return trace.SpanKindConsumer
+	}
+	return trace.SpanKindInternal
+}
+
+func spanResource(ep *zkmodel.Endpoint) *resource.Resource {
+	if ep == nil || ep.ServiceName == "" {
+		return resource.Empty()
+	}
+	return resource.NewSchemaless(semconv.ServiceName(ep.ServiceName))
+}
+
+func remoteEndpointAttributes(ep *zkmodel.Endpoint) []attribute.KeyValue {
+	if ep == nil {
+		return nil
+	}
+	var attrs []attribute.KeyValue
+	if ep.ServiceName != "" {
+		attrs = append(attrs, semconv.PeerService(ep.ServiceName))
+	}
+	switch {
+	case ep.IPv4 != nil:
+		attrs = append(attrs, semconv.NetSockPeerAddr(ep.IPv4.String()))
+	case ep.IPv6 != nil:
+		attrs = append(attrs, semconv.NetSockPeerAddr(ep.IPv6.String()))
+	}
+	if ep.Port != 0 {
+		attrs = append(attrs, semconv.NetSockPeerPort(int(ep.Port)))
+	}
+	return attrs
+}
+
+func status(code string, hasError bool, desc string) tracesdk.Status {
+	switch {
+	case strings.EqualFold(code, "ERROR") || hasError:
+		return tracesdk.Status{Code: codes.Error, Description: desc}
+	case strings.EqualFold(code, "OK"):
+		return tracesdk.Status{Code: codes.Ok}
+	}
+	return tracesdk.Status{}
+}
+
+// events converts Zipkin annotations into events. Annotations in the
+// "name: {json}" form written by the Zipkin exporter are split back into an
+// event name and its attributes.
+func events(annotations []zkmodel.Annotation) []tracesdk.Event {
+	if len(annotations) == 0 {
+		return nil
+	}
+	evts := make([]tracesdk.Event, 0, len(annotations))
+	for _, a := range annotations {
+		evt := tracesdk.Event{Name: a.Value, Time: a.Timestamp}
+		if i := strings.Index(a.Value, ": {"); i >= 0 {
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(a.Value[i+2:]), &m); err == nil {
+				evt.Name = a.Value[:i]
+				evt.Attributes = jsonAttributes(m)
+			}
+		}
+		evts = append(evts, evt)
+	}
+	return evts
+}
+
+func jsonAttributes(m map[string]interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		switch val := m[k].(type) {
+		case string:
+			attrs = append(attrs, attribute.String(k, val))
+		case bool:
+			attrs = append(attrs, attribute.Bool(k, val))
+		case float64:
+			if val == float64(int64(val)) {
+				attrs = append(attrs, attribute.Int64(k, int64(val)))
+			} else {
+				attrs = append(attrs, attribute.Float64(k, val))
+			}
+		default:
+			// Slices and nested values keep their JSON representation.
+			data, err := json.Marshal(val)
+			if err == nil {
+				attrs = append(attrs, attribute.String(k, string(data)))
+			}
+		}
+	}
+	return attrs
+}
+
+// sortedKeys returns the keys of m in increasing order, so the attributes
+// built from m have a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
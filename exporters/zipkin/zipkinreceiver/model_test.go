@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/exporters/zipkin"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/resource"
+	tracesdk "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var (
+	traceID = trace.TraceID{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F}
+	spanID  = trace.SpanID{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8}
+	parent  = trace.SpanID{0xDF, 0xDE, 0xDD, 0xDC, 0xDB, 0xDA, 0xD9, 0xD8}
+	start   = time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC)
+)
+
+func testStub() tracetest.SpanStub {
+	return tracetest.SpanStub{
+		Name: "foo",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     parent,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		SpanKind:   trace.SpanKindClient,
+		StartTime:  start,
+		EndTime:    start.Add(time.Minute),
+		Attributes: []attribute.KeyValue{attribute.String("key", "value")},
+		Events: []tracesdk.Event{
+			{Name: "plain", Time: start.Add(time.Second)},
+			{
+				Name: "with attributes",
+				Time: start.Add(2 * time.Second),
+				Attributes: []attribute.KeyValue{
+					attribute.Int64("int", 1),
+					attribute.Bool("bool", true),
+				},
+			},
+		},
+		Status: tracesdk.Status{Code: codes.Error, Description: "boom"},
+		Resource: resource.NewSchemaless(
+			semconv.ServiceName("svc"),
+		),
+		InstrumentationLibrary: instrumentation.Library{Name: "lib", Version: "v1"},
+	}
+}
+
+func TestSpanStubRoundTrip(t *testing.T) {
+	want := testStub()
+	models := zipkin.SpanModels(tracetest.SpanStubs{want}.Snapshots())
+	require.Len(t, models, 1)
+
+	got := SpanStub(models[0])
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.SpanContext, got.SpanContext)
+	assert.Equal(t, want.Parent, got.Parent)
+	assert.Equal(t, want.SpanKind, got.SpanKind)
+	assert.True(t, want.StartTime.Equal(got.StartTime))
+	assert.True(t, want.EndTime.Equal(got.EndTime))
+	// The service.name resource attribute is also sent as a tag. Tags are
+	// converted in key order.
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("key", "value"),
+		attribute.String("service.name", "svc"),
+	}, got.Attributes)
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Resource, got.Resource)
+	assert.Equal(t, want.InstrumentationLibrary, got.InstrumentationLibrary)
+
+	require.Len(t, got.Events, 2)
+	assert.Equal(t, "plain", got.Events[0].Name)
+	assert.Equal(t, "with attributes", got.Events[1].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Bool("bool", true),
+		attribute.Int64("int", 1),
+	}, got.Events[1].Attributes)
+}
+
+func TestParseJSON(t *testing.T) {
+	data, err := json.Marshal(zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots()))
+	require.NoError(t, err)
+
+	stubs, err := ParseJSON(data)
+	require.NoError(t, err)
+	require.Len(t, stubs, 1)
+	assert.Equal(t, "foo", stubs[0].Name)
+
+	_, err = ParseJSON([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSpanStubNotSampled(t *testing.T) {
+	models := zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots())
+	sampled := false
+	models[0].Sampled = &sampled
+	assert.False(t, SpanStub(models[0]).SpanContext.IsSampled())
+}
+
+func TestSpanStubRemoteEndpoint(t *testing.T) {
+	stub := testStub()
+	stub.Attributes = []attribute.KeyValue{
+		semconv.NetSockPeerAddr("10.0.0.1"),
+		semconv.NetSockPeerPort(8080),
+	}
+	models := zipkin.SpanModels(tracetest.SpanStubs{stub}.Snapshots())
+	got := SpanStub(models[0])
+	assert.Contains(t, got.Attributes, semconv.NetSockPeerAddr("10.0.0.1"))
+	assert.Contains(t, got.Attributes, semconv.NetSockPeerPort(8080))
+}
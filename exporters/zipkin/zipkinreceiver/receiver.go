@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver // import "github.com/middleware-labs/otel/exporters/zipkin/zipkinreceiver"
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/middleware-labs/otel"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+)
+
+const (
+	// maxBodySize is the maximum size of a request body accepted by the
+	// handler.
+	maxBodySize = 16 << 20
+	// maxDatagramSize is the maximum size of a UDP datagram.
+	maxDatagramSize = 65000
+)
+
+// NewHandler returns an http.Handler that receives spans POSTed in the
+// Zipkin v2 format and exports them with exporter.
+//
+// Both the JSON (application/json) and the proto3 (application/x-protobuf)
+// encodings are accepted, optionally gzip compressed. A 202 status is
+// returned once the spans have been exported.
+func NewHandler(exporter sdktrace.SpanExporter) http.Handler {
+	return &handler{exporter: newLockedExporter(exporter)}
+}
+
+type handler struct {
+	exporter lockedExporter
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := io.Reader(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		// Limit the decompressed body as well, a small compressed body can
+		// expand to an arbitrarily large one.
+		body = io.LimitReader(gz, maxBodySize+1)
+	}
+	data, err := io.ReadAll(body)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) || len(data) > maxBodySize {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var stubs tracetest.SpanStubs
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-protobuf":
+		stubs, err = ParseProtobuf(data)
+	case "", "application/json":
+		stubs, err = ParseJSON(data)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q", mediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.exporter.export(r.Context(), stubs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// UDPListener receives spans sent as UDP datagrams, each containing a Zipkin
+// v2 JSON encoded list of spans, and exports them.
+type UDPListener struct {
+	conn     net.PacketConn
+	exporter lockedExporter
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// ListenUDP listens for Zipkin spans on the UDP address addr and exports them
+// with exporter. Errors decoding or exporting spans are sent to the global
+// ErrorHandler.
+//
+// The returned UDPListener needs to be closed to stop receiving spans.
+func ListenUDP(addr string, exporter sdktrace.SpanExporter) (*UDPListener, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	l := &UDPListener{
+		conn:     conn,
+		exporter: newLockedExporter(exporter),
+		done:     make(chan struct{}),
+	}
+	go l.serve()
+	return l, nil
+}
+
+// Addr returns the address the UDPListener is listening on.
+func (l *UDPListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// Close stops the UDPListener. It waits for the spans being exported to be
+// processed.
+func (l *UDPListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		err = l.conn.Close()
+		<-l.done
+	})
+	return err
+}
+
+func (l *UDPListener) serve() {
+	defer close(l.done)
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, _, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				otel.Handle(fmt.Errorf("zipkin receiver: %w", err))
+			}
+			return
+		}
+
+		stubs, err := ParseJSON(buf[:n])
+		if err != nil {
+			otel.Handle(fmt.Errorf("zipkin receiver: invalid datagram: %w", err))
+			continue
+		}
+		if err := l.exporter.export(context.Background(), stubs); err != nil {
+			otel.Handle(fmt.Errorf("zipkin receiver: %w", err))
+		}
+	}
+}
+
+// exporterLocks holds the mutex of each exporter used by the handlers and
+// listeners of the package. The SpanExporter interface does not require
+// exporters to be safe for concurrent use, but handlers serve requests
+// concurrently and an exporter can be shared with a listener.
+var exporterLocks sync.Map
+
+// lockedExporter serializes the exports of an exporter. Exports of different
+// exporters do not wait for each other.
+type lockedExporter struct {
+	exporter sdktrace.SpanExporter
+	mu       *sync.Mutex
+}
+
+func newLockedExporter(exporter sdktrace.SpanExporter) lockedExporter {
+	if t := reflect.TypeOf(exporter); t == nil || !t.Comparable() {
+		// Exporters that cannot be used as map keys cannot be shared.
+		return lockedExporter{exporter: exporter, mu: new(sync.Mutex)}
+	}
+	mu, _ := exporterLocks.LoadOrStore(exporter, new(sync.Mutex))
+	return lockedExporter{exporter: exporter, mu: mu.(*sync.Mutex)}
+}
+
+// export exports stubs, waiting for any other export of the same exporter to
+// return first.
+func (e lockedExporter) export(ctx context.Context, stubs tracetest.SpanStubs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exporter.ExportSpans(ctx, stubs.Snapshots())
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkinreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/exporters/zipkin"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+)
+
+func TestHandler(t *testing.T) {
+	models := zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots())
+	jsonData, err := json.Marshal(models)
+	require.NoError(t, err)
+	ptrs := make([]*zkmodel.SpanModel, len(models))
+	for i := range models {
+		ptrs[i] = &models[i]
+	}
+	protoData, err := zipkin_proto3.SpanSerializer{}.Serialize(ptrs)
+	require.NoError(t, err)
+	var gzData bytes.Buffer
+	gz := gzip.NewWriter(&gzData)
+	_, err = gz.Write(jsonData)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	// A compressed body expanding past maxBodySize.
+	var bomb bytes.Buffer
+	gz = gzip.NewWriter(&bomb)
+	_, err = gz.Write(make([]byte, maxBodySize+1))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		encoding    string
+		body        []byte
+		wantStatus  int
+		wantSpans   int
+	}{
+		{name: "JSON", method: http.MethodPost, contentType: "application/json", body: jsonData, wantStatus: http.StatusAccepted, wantSpans: 1},
+		{name: "Protobuf", method: http.MethodPost, contentType: "application/x-protobuf", body: protoData, wantStatus: http.StatusAccepted, wantSpans: 1},
+		{name: "Gzip", method: http.MethodPost, contentType: "application/json", encoding: "gzip", body: gzData.Bytes(), wantStatus: http.StatusAccepted, wantSpans: 1},
+		{name: "InvalidBody", method: http.MethodPost, contentType: "application/json", body: []byte("{"), wantStatus: http.StatusBadRequest},
+		{name: "TooLarge", method: http.MethodPost, contentType: "application/json", body: make([]byte, maxBodySize+1), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "GzipTooLarge", method: http.MethodPost, contentType: "application/json", encoding: "gzip", body: bomb.Bytes(), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "UnsupportedContentType", method: http.MethodPost, contentType: "text/plain", body: jsonData, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "MethodNotAllowed", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			exp := tracetest.NewInMemoryExporter()
+			req := httptest.NewRequest(tc.method, "/api/v2/spans", bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			if tc.encoding != "" {
+				req.Header.Set("Content-Encoding", tc.encoding)
+			}
+			rec := httptest.NewRecorder()
+
+			NewHandler(exp).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Len(t, exp.GetSpans(), tc.wantSpans)
+		})
+	}
+}
+
+func TestUDPListener(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	l, err := ListenUDP("127.0.0.1:0", exp)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, l.Close()) }()
+
+	data, err := json.Marshal(zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots()))
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(data)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(exp.GetSpans()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "foo", exp.GetSpans()[0].Name)
+}
+
+func TestUDPListenerCloseIdempotent(t *testing.T) {
+	l, err := ListenUDP("127.0.0.1:0", tracetest.NewInMemoryExporter())
+	require.NoError(t, err)
+	assert.NoError(t, l.Close())
+	assert.NoError(t, l.Close())
+}
+
+// serialExporter is an exporter recording if ExportSpans is called
+// concurrently.
+type serialExporter struct {
+	*tracetest.InMemoryExporter
+
+	active     atomic.Int32
+	concurrent atomic.Bool
+}
+
+func (e *serialExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if e.active.Add(1) > 1 {
+		e.concurrent.Store(true)
+	}
+	defer e.active.Add(-1)
+	time.Sleep(time.Millisecond)
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func TestExportSerialized(t *testing.T) {
+	exp := &serialExporter{InMemoryExporter: tracetest.NewInMemoryExporter()}
+	l, err := ListenUDP("127.0.0.1:0", exp)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, l.Close()) }()
+	srv := httptest.NewServer(NewHandler(exp))
+	defer srv.Close()
+
+	data, err := json.Marshal(zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots()))
+	require.NoError(t, err)
+	conn, err := net.Dial("udp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(data))
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+				resp.Body.Close()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := conn.Write(data)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		return len(exp.GetSpans()) == 2*n
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, exp.concurrent.Load(), "ExportSpans called concurrently")
+}
+
+// blockingExporter is an exporter whose exports wait for release to be
+// closed.
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	e.started <- struct{}{}
+	<-e.release
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestExportersNotSerialized(t *testing.T) {
+	blocked := &blockingExporter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	blockedSrv := httptest.NewServer(NewHandler(blocked))
+	defer blockedSrv.Close()
+	defer close(blocked.release)
+	exp := tracetest.NewInMemoryExporter()
+	srv := httptest.NewServer(NewHandler(exp))
+	defer srv.Close()
+
+	data, err := json.Marshal(zipkin.SpanModels(tracetest.SpanStubs{testStub()}.Snapshots()))
+	require.NoError(t, err)
+	go func() {
+		resp, err := http.Post(blockedSrv.URL, "application/json", bytes.NewReader(data))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-blocked.started
+
+	// The export of another exporter does not wait for the blocked one.
+	resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Len(t, exp.GetSpans(), 1)
+}
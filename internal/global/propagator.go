@@ -32,8 +32,8 @@ type textMapPropagator struct {
 }
 
 // Compile-time guarantee that textMapPropagator implements the
-// propagation.TextMapPropagator interface.
-var _ propagation.TextMapPropagator = (*textMapPropagator)(nil)
+// propagation.FieldsExtractor interface.
+var _ propagation.FieldsExtractor = (*textMapPropagator)(nil)
 
 func newTextMapPropagator() *textMapPropagator {
 	return &textMapPropagator{
@@ -76,6 +76,12 @@ func (p *textMapPropagator) Extract(ctx context.Context, carrier propagation.Tex
 	return p.effectiveDelegate().Extract(ctx, carrier)
 }
 
+// ExtractFields reads cross-cutting concerns from the carrier into a Context
+// and returns the carrier keys that were read.
+func (p *textMapPropagator) ExtractFields(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, []string) {
+	return propagation.ExtractWithFields(ctx, p.effectiveDelegate(), carrier)
+}
+
 // Fields returns the keys whose values are set with Inject.
 func (p *textMapPropagator) Fields() []string {
 	return p.effectiveDelegate().Fields()
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commentWidth is the width generated comments are wrapped at, tabs
+// counting as four columns.
+const commentWidth = 80
+
+// funcs are the functions available to the templates.
+var funcs = map[string]any{
+	"comment":    comment,
+	"represents": represents,
+	"sentence":   sentence,
+	"deprecated": deprecated,
+}
+
+// sentence returns text on a single line, ending with a period.
+func sentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" || strings.HasSuffix(text, ".") {
+		return text
+	}
+	return text + "."
+}
+
+// represents returns the sentence describing a convention from its brief
+// description.
+func represents(brief string) string {
+	brief = sentence(brief)
+	if brief == "" {
+		return ""
+	}
+	for _, article := range []string{"A ", "An ", "The "} {
+		if strings.HasPrefix(brief, article) {
+			return "It represents " + lowerFirst(brief)
+		}
+	}
+	return "It represents the " + lowerFirst(brief)
+}
+
+// lowerFirst returns s with its first letter in lowercase, unless it is part
+// of an acronym.
+func lowerFirst(s string) string {
+	first, n := utf8.DecodeRuneInString(s)
+	if next, _ := utf8.DecodeRuneInString(s[n:]); unicode.IsUpper(next) {
+		return s
+	}
+	return string(unicode.ToLower(first)) + s[n:]
+}
+
+// deprecated returns the deprecation paragraph of a convention, or an empty
+// string if reason is empty.
+func deprecated(reason string) string {
+	if reason = sentence(reason); reason == "" {
+		return ""
+	}
+	return "Deprecated: " + reason
+}
+
+// comment returns the Go comment, indented with indent, made of the
+// non-empty paragraphs. Paragraphs are wrapped at commentWidth, and their
+// own paragraphs, separated by blank lines, are preserved.
+func comment(indent string, paragraphs ...string) string {
+	width := commentWidth - len("// ") - 4*strings.Count(indent, "\t") - strings.Count(indent, " ")
+	var blocks []string
+	for _, p := range paragraphs {
+		for _, block := range strings.Split(strings.TrimSpace(p), "\n\n") {
+			if words := strings.Fields(block); len(words) > 0 {
+				blocks = append(blocks, strings.Join(wrap(words, width), "\n"))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			b.WriteString("\n" + indent + "//\n")
+		}
+		for j, line := range strings.Split(block, "\n") {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(indent + "// " + line)
+		}
+	}
+	return b.String()
+}
+
+// wrap returns the lines of words no longer than width, unless a single word
+// is longer.
+func wrap(words []string, width int) []string {
+	var lines []string
+	line := words[0]
+	for _, w := range words[1:] {
+		if len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		line += " " + w
+	}
+	return append(lines, line)
+}
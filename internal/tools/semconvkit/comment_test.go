@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepresents(t *testing.T) {
+	assert.Equal(t, "It represents a string identifying the remoting system.", represents("A string identifying the remoting system."))
+	assert.Equal(t, "It represents the fetch size used for paging.", represents("The fetch size\n used for paging"))
+	assert.Equal(t, "It represents the name of the operation.", represents("Name of the operation."))
+	assert.Equal(t, "It represents the HTTP request method.", represents("HTTP request method."))
+	assert.Equal(t, "", represents(""))
+}
+
+func TestComment(t *testing.T) {
+	got := comment("\t",
+		"SystemKey is the attribute Key conforming to the \"db.system\" semantic conventions.",
+		"",
+		"First note paragraph.\n\nSecond note paragraph.",
+		deprecated("not reported anymore"),
+	)
+	assert.Equal(t, "\t// SystemKey is the attribute Key conforming to the \"db.system\" semantic\n"+
+		"\t// conventions.\n"+
+		"\t//\n"+
+		"\t// First note paragraph.\n"+
+		"\t//\n"+
+		"\t// Second note paragraph.\n"+
+		"\t//\n"+
+		"\t// Deprecated: not reported anymore.", got)
+}
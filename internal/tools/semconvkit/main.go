@@ -16,12 +16,24 @@
 // convention code. It is expected to be used in with the semconvgen utility
 // (go.opentelemetry.io/build-tools/semconvgen) to completely generate
 // versioned sub-packages of github.com/middleware-labs/otel/semconv.
+//
+// If the semantic convention YAML model is passed with the -input flag, the
+// attributes and metrics of each namespace of the -namespaces flag are
+// generated into a <namespace>conv sub-package: typed attribute builders,
+// enum types for enumerated attributes, and constructors of the metric
+// instruments with the unit and description of the conventions.
+//
+// The dbconv, messagingconv, and rpcconv sub-packages also hold helpers of the
+// instrumentation of database, messaging, and RPC calls. They are always
+// generated.
 package main
 
 import (
+	"bytes"
 	"embed"
 	"flag"
 	"fmt"
+	"go/format"
 	"log"
 	"os"
 	"path/filepath"
@@ -30,11 +42,16 @@ import (
 )
 
 var (
-	out = flag.String("output", "./", "output directory")
-	tag = flag.String("tag", "", "OpenTelemetry tagged version")
+	out        = flag.String("output", "./", "output directory")
+	tag        = flag.String("tag", "", "OpenTelemetry tagged version")
+	input      = flag.String("input", "", "semantic convention YAML model directory, namespace packages are only generated if set")
+	namespaces = flag.String("namespaces", "cloud,db,faas,messaging,rpc", "comma separated namespaces to generate packages for")
 
-	//go:embed templates/*.tmpl templates/netconv/*.tmpl templates/httpconv/*.tmpl
+	//go:embed templates/*.tmpl templates/netconv/*.tmpl templates/httpconv/*.tmpl templates/conv/*.tmpl templates/dbconv/*.tmpl templates/messagingconv/*.tmpl templates/rpcconv/*.tmpl
 	rootFS embed.FS
+
+	// helpers are the namespaces with instrumentation helpers.
+	helpers = []string{"db", "messaging", "rpc"}
 )
 
 // SemanticConventions are information about the semantic conventions being
@@ -48,25 +65,85 @@ func (sc SemanticConventions) SemVer() string {
 	return strings.TrimPrefix(*tag, "v")
 }
 
-// render renders all templates to the dest directory using the data.
-func render(src, dest string, data *SemanticConventions) error {
-	tmpls, err := template.ParseFS(rootFS, src)
+// render renders all templates to the dest directory using the data. The
+// rendered Go source is formatted.
+func render(src, dest string, data any) error {
+	tmpls, err := template.New("semconvkit").Funcs(funcs).ParseFS(rootFS, src)
 	if err != nil {
 		return err
 	}
 	for _, tmpl := range tmpls.Templates() {
-		target := filepath.Join(dest, strings.TrimSuffix(tmpl.Name(), ".tmpl"))
-		wr, err := os.Create(target)
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		code, err := format.Source(buf.Bytes())
 		if err != nil {
+			return fmt.Errorf("%s: %w", tmpl.Name(), err)
+		}
+
+		target := filepath.Join(dest, strings.TrimSuffix(tmpl.Name(), ".tmpl"))
+		if err := os.WriteFile(target, code, 0o644); err != nil {
 			return err
 		}
+	}
 
-		err = tmpl.Execute(wr, data)
-		if err != nil {
+	return nil
+}
+
+// renderNamespace renders the package of ns in the dest directory. The files
+// of the attributes and metrics are only rendered if ns has any.
+func renderNamespace(dest string, ns *Namespace) error {
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+	srcs := []string{"templates/conv/doc.go.tmpl"}
+	if len(ns.Attributes) > 0 {
+		srcs = append(srcs, "templates/conv/attribute.go.tmpl")
+	}
+	if len(ns.Metrics) > 0 {
+		srcs = append(srcs, "templates/conv/metric.go.tmpl")
+	}
+	for _, src := range srcs {
+		if err := render(src, dest, ns); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
+// renderHelpers renders the instrumentation helpers of the ns namespace in
+// the dest directory.
+func renderHelpers(dest, ns string, sc *SemanticConventions) error {
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+	doc := &Namespace{SemanticConventions: *sc, Name: ns}
+	if err := render("templates/conv/doc.go.tmpl", dest, doc); err != nil {
+		return err
+	}
+	return render(fmt.Sprintf("templates/%sconv/*.tmpl", ns), dest, sc)
+}
+
+// generateNamespaces renders the packages of the names namespaces from the
+// semantic convention model in the dir directory.
+func generateNamespaces(dir string, names []string, sc *SemanticConventions) error {
+	groups, err := loadModel(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		ns, err := newNamespace(*sc, name, groups)
+		if err != nil {
+			return err
+		}
+		if len(ns.Attributes) == 0 && len(ns.Metrics) == 0 {
+			return fmt.Errorf("no semantic conventions for the %q namespace", name)
+		}
+		if err := renderNamespace(filepath.Join(*out, ns.Package()), ns); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -100,4 +177,17 @@ func main() {
 	if err := render("templates/httpconv/*.tmpl", dest, sc); err != nil {
 		log.Fatal(err)
 	}
+
+	for _, ns := range helpers {
+		dest = fmt.Sprintf("%s/%sconv", *out, ns)
+		if err := renderHelpers(dest, ns, sc); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *input != "" {
+		if err := generateNamespaces(*input, strings.Split(*namespaces, ","), sc); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
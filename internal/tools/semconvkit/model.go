@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// file is a YAML file of the semantic convention model.
+type file struct {
+	Groups []group `yaml:"groups"`
+}
+
+// group is a group of semantic conventions. Attributes are defined by any
+// type of group, metrics by the groups of the metric type.
+type group struct {
+	ID         string      `yaml:"id"`
+	Type       string      `yaml:"type"`
+	Prefix     string      `yaml:"prefix"`
+	Brief      string      `yaml:"brief"`
+	Attributes []attribute `yaml:"attributes"`
+
+	MetricName string `yaml:"metric_name"`
+	Instrument string `yaml:"instrument"`
+	Unit       string `yaml:"unit"`
+}
+
+// attribute is an attribute definition, or a reference to an attribute
+// defined by another group.
+type attribute struct {
+	ID         string        `yaml:"id"`
+	Ref        string        `yaml:"ref"`
+	Type       attributeType `yaml:"type"`
+	Brief      string        `yaml:"brief"`
+	Note       string        `yaml:"note"`
+	Stability  string        `yaml:"stability"`
+	Deprecated string        `yaml:"deprecated"`
+}
+
+// attributeType is either the name of a primitive type, or the members of
+// an enumerated type.
+type attributeType struct {
+	Name    string
+	Members []member
+}
+
+// member is a member of an enumerated attribute type.
+type member struct {
+	ID    string    `yaml:"id"`
+	Value yaml.Node `yaml:"value"`
+	Brief string    `yaml:"brief"`
+}
+
+// UnmarshalYAML decodes the type of an attribute.
+func (t *attributeType) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		return n.Decode(&t.Name)
+	}
+	var enum struct {
+		Members []member `yaml:"members"`
+	}
+	if err := n.Decode(&enum); err != nil {
+		return err
+	}
+	t.Members = enum.Members
+	return nil
+}
+
+// goTypes maps the primitive attribute types to the Go type of their values
+// and the attribute.Key method creating a KeyValue from them.
+var goTypes = map[string]struct{ Type, Method string }{
+	"string":    {"string", "String"},
+	"string[]":  {"...string", "StringSlice"},
+	"int":       {"int", "Int"},
+	"int[]":     {"...int", "IntSlice"},
+	"double":    {"float64", "Float64"},
+	"double[]":  {"...float64", "Float64Slice"},
+	"boolean":   {"bool", "Bool"},
+	"boolean[]": {"...bool", "BoolSlice"},
+}
+
+// instruments maps the instrument kinds of the model to the Go name of the
+// instrument created for them. The model does not define the type of the
+// measurements: histograms record float64 values, counters record int64
+// values, and gauges observe float64 values.
+var instruments = map[string]string{
+	"counter":       "Int64Counter",
+	"updowncounter": "Int64UpDownCounter",
+	"histogram":     "Float64Histogram",
+	"gauge":         "Float64ObservableGauge",
+}
+
+// Namespace holds the semantic conventions of a namespace, the first
+// segment of the attribute and metric names, generated into its own package.
+type Namespace struct {
+	SemanticConventions
+
+	// Name is the name of the namespace, e.g. "db".
+	Name string
+	// Attributes are the attributes of the namespace, in model order.
+	Attributes []Attribute
+	// Metrics are the metrics of the namespace, sorted by name.
+	Metrics []Metric
+}
+
+// Package returns the name of the package of the namespace.
+func (ns Namespace) Package() string {
+	return ns.Name + "conv"
+}
+
+// Attribute is an attribute of a namespace.
+type Attribute struct {
+	// Name is the fully qualified name of the attribute, e.g. "db.system".
+	Name string
+	// GoName is the Go identifier of the attribute, without the namespace.
+	GoName string
+	// Brief, Note, and Deprecated document the attribute.
+	Brief, Note, Deprecated string
+	// Type is the primitive type of the attribute, empty for enumerations.
+	Type string
+	// ValueType is the Go type of the values of the attribute.
+	ValueType string
+	// Method is the attribute.Key method creating a KeyValue of the
+	// attribute.
+	Method string
+	// Members are the members of an enumerated attribute.
+	Members []Member
+}
+
+// Member is a member of an enumerated attribute.
+type Member struct {
+	// GoName is the Go identifier of the member constant.
+	GoName string
+	// Value is the Go literal of the value of the member.
+	Value string
+	// Brief documents the member.
+	Brief string
+}
+
+// Metric is a metric of a namespace.
+type Metric struct {
+	// Name is the name of the metric, e.g. "rpc.server.duration".
+	Name string
+	// GoName is the Go identifier of the metric, without the namespace.
+	GoName string
+	// Brief documents the metric.
+	Brief string
+	// Unit is the UCUM unit of the metric.
+	Unit string
+	// Instrument is the Go name of the instrument recording the metric.
+	Instrument string
+}
+
+// loadModel returns the groups of all the YAML files found in dir.
+func loadModel(dir string) ([]group, error) {
+	var groups []group
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var f file
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		groups = append(groups, f.Groups...)
+		return nil
+	})
+	return groups, err
+}
+
+// newNamespace returns the attributes and metrics of the namespace name
+// defined by groups. Attributes of unsupported types, like templates, are
+// ignored.
+func newNamespace(sc SemanticConventions, name string, groups []group) (*Namespace, error) {
+	ns := &Namespace{SemanticConventions: sc, Name: name}
+	prefix := name + "."
+	// Identifiers must be unique in the generated package.
+	idents := map[string]string{}
+	declare := func(ident, of string) error {
+		if other, ok := idents[ident]; ok {
+			return fmt.Errorf("%s: identifier %s of %s conflicts with %s", name, ident, of, other)
+		}
+		idents[ident] = of
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, g := range groups {
+		for _, a := range g.Attributes {
+			if a.Ref != "" {
+				continue
+			}
+			fqn := a.ID
+			if g.Prefix != "" {
+				fqn = g.Prefix + "." + a.ID
+			}
+			if !strings.HasPrefix(fqn, prefix) || seen[fqn] {
+				continue
+			}
+			seen[fqn] = true
+
+			attr, ok, err := newAttribute(fqn, prefix, a)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if err := declare(attr.GoName+"Key", fqn); err != nil {
+				return nil, err
+			}
+			if err := declare(attr.GoName, fqn); err != nil {
+				return nil, err
+			}
+			for _, m := range attr.Members {
+				if err := declare(m.GoName, fqn); err != nil {
+					return nil, err
+				}
+			}
+			ns.Attributes = append(ns.Attributes, attr)
+		}
+
+		if g.Type != "metric" || !strings.HasPrefix(g.MetricName, prefix) {
+			continue
+		}
+		inst, ok := instruments[g.Instrument]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown instrument %q", g.MetricName, g.Instrument)
+		}
+		m := Metric{
+			Name:       g.MetricName,
+			GoName:     goName(strings.TrimPrefix(g.MetricName, prefix)),
+			Brief:      g.Brief,
+			Unit:       g.Unit,
+			Instrument: inst,
+		}
+		if err := declare("New"+m.GoName, m.Name); err != nil {
+			return nil, err
+		}
+		ns.Metrics = append(ns.Metrics, m)
+	}
+	sort.Slice(ns.Metrics, func(i, j int) bool { return ns.Metrics[i].Name < ns.Metrics[j].Name })
+	return ns, nil
+}
+
+// newAttribute returns the Attribute of the definition a of the attribute
+// fqn. It returns false if the type of the attribute is not supported.
+func newAttribute(fqn, prefix string, a attribute) (Attribute, bool, error) {
+	attr := Attribute{
+		Name:       fqn,
+		GoName:     goName(strings.TrimPrefix(fqn, prefix)),
+		Brief:      a.Brief,
+		Note:       a.Note,
+		Deprecated: a.Deprecated,
+	}
+	if a.Type.Members == nil {
+		t, ok := goTypes[a.Type.Name]
+		if !ok {
+			return attr, false, nil
+		}
+		attr.Type, attr.ValueType, attr.Method = a.Type.Name, t.Type, t.Method
+		return attr, true, nil
+	}
+
+	// Enumerations have the type of the values of their members, which must
+	// all be strings or all be integers.
+	for i, m := range a.Type.Members {
+		if m.Value.Kind != yaml.ScalarNode {
+			return attr, false, fmt.Errorf("%s: invalid value of member %q", fqn, m.ID)
+		}
+		var valueType, method string
+		value := m.Value.Value
+		switch m.Value.Tag {
+		case "!!str":
+			valueType, method = "string", "String"
+			value = fmt.Sprintf("%q", value)
+		case "!!int":
+			valueType, method = "int", "Int"
+		default:
+			return attr, false, fmt.Errorf("%s: unsupported value %q of member %q", fqn, value, m.ID)
+		}
+		if i == 0 {
+			attr.ValueType, attr.Method = valueType, method
+		} else if valueType != attr.ValueType {
+			return attr, false, fmt.Errorf("%s: members of different types", fqn)
+		}
+		attr.Members = append(attr.Members, Member{
+			GoName: attr.GoName + goName(m.ID),
+			Value:  value,
+			Brief:  m.Brief,
+		})
+	}
+	return attr, true, nil
+}
+
+// acronyms are the words of the names written in a specific case in Go
+// identifiers, by lowercase word.
+var acronyms = func() map[string]string {
+	words := []string{
+		"ACL", "API", "ARN", "AWS", "CPU", "DB", "DNS", "EC2", "ECS", "EKS",
+		"FaaS", "GCP", "GRPC", "GUID", "HTTP", "HTTPS", "ID", "IP", "JDBC",
+		"JSON", "K8S", "MSSQL", "OS", "PID", "QPS", "RAM", "RPC", "SDK",
+		"SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "URI", "URL",
+		"UTF8", "UUID", "VM", "XML",
+		"CosmosDB", "CouchDB", "DynamoDB", "GraphQL", "HBase", "MariaDB",
+		"MongoDB", "MySQL", "PostgreSQL", "RabbitMQ", "RocketMQ", "SQLite",
+	}
+	m := make(map[string]string, len(words))
+	for _, w := range words {
+		m[strings.ToLower(w)] = w
+	}
+	return m
+}()
+
+// goName returns the exported Go identifier of a dotted, snake case, name.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' '
+	}) {
+		if a, ok := acronyms[strings.ToLower(word)]; ok {
+			b.WriteString(a)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNamespace(t *testing.T, name string) *Namespace {
+	t.Helper()
+	groups, err := loadModel("testdata/model")
+	require.NoError(t, err)
+	ns, err := newNamespace(SemanticConventions{TagVer: "v1.18.0"}, name, groups)
+	require.NoError(t, err)
+	return ns
+}
+
+func TestNewNamespace(t *testing.T) {
+	ns := testNamespace(t, "db")
+	assert.Equal(t, "dbconv", ns.Package())
+
+	names := make([]string, len(ns.Attributes))
+	for i, a := range ns.Attributes {
+		names[i] = a.Name
+	}
+	assert.Equal(t, []string{
+		"db.system",
+		"db.statement",
+		"db.jdbc.driver_classname",
+		"db.cassandra.page_size",
+		"db.cassandra.speculative_execution_count",
+	}, names)
+
+	system := ns.Attributes[0]
+	assert.Equal(t, "System", system.GoName)
+	assert.Equal(t, "string", system.ValueType)
+	assert.Equal(t, []Member{
+		{GoName: "SystemOtherSQL", Value: `"other_sql"`, Brief: "Some other SQL database. Fallback only. See notes."},
+		{GoName: "SystemMySQL", Value: `"mysql"`, Brief: "MySQL"},
+		{GoName: "SystemPostgreSQL", Value: `"postgresql"`, Brief: "PostgreSQL"},
+	}, system.Members)
+
+	assert.Equal(t, "Not reported anymore.", ns.Attributes[4].Deprecated)
+
+	require.Len(t, ns.Metrics, 1)
+	assert.Equal(t, Metric{
+		Name:       "db.client.connections.usage",
+		GoName:     "ClientConnectionsUsage",
+		Brief:      "The number of connections that are currently in state described by the `state` attribute",
+		Unit:       "{connection}",
+		Instrument: "Int64UpDownCounter",
+	}, ns.Metrics[0])
+}
+
+func TestNewNamespaceEnumTypes(t *testing.T) {
+	ns := testNamespace(t, "rpc")
+
+	attrs := map[string]Attribute{}
+	for _, a := range ns.Attributes {
+		attrs[a.Name] = a
+	}
+	assert.NotContains(t, attrs, "rpc.jsonrpc.request.header", "template attributes are not supported")
+
+	code := attrs["rpc.grpc.status_code"]
+	assert.Equal(t, "int", code.ValueType)
+	assert.Equal(t, "Int", code.Method)
+	assert.Equal(t, "0", code.Members[0].Value)
+
+	tags := attrs["rpc.jsonrpc.tags"]
+	assert.Equal(t, "...string", tags.ValueType)
+	assert.Equal(t, "StringSlice", tags.Method)
+
+	// Metrics are sorted by name.
+	require.Len(t, ns.Metrics, 3)
+	assert.Equal(t, "rpc.client.active_requests", ns.Metrics[0].Name)
+	assert.Equal(t, "Float64Histogram", ns.Metrics[1].Instrument)
+}
+
+func TestNewNamespaceConflict(t *testing.T) {
+	groups := []group{{
+		Prefix: "db",
+		Attributes: []attribute{
+			{ID: "system", Type: attributeType{Members: []member{{ID: "sql"}}}},
+			{ID: "system_sql", Type: attributeType{Name: "string"}},
+		},
+	}}
+	groups[0].Attributes[0].Type.Members[0].Value.SetString("sql")
+	_, err := newNamespace(SemanticConventions{}, "db", groups)
+	assert.ErrorContains(t, err, "identifier SystemSQL of db.system_sql conflicts with db.system")
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"system":                    "System",
+		"mssql.instance_name":       "MSSQLInstanceName",
+		"jdbc.driver_classname":     "JDBCDriverClassname",
+		"grpc.status_code":          "GRPCStatusCode",
+		"server.requests_per_rpc":   "ServerRequestsPerRPC",
+		"destination.name":          "DestinationName",
+		"rabbitmq.routing_key":      "RabbitMQRoutingKey",
+		"invoked-provider.cloud_id": "InvokedProviderCloudID",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, goName(name), name)
+	}
+}
+
+func TestRenderNamespace(t *testing.T) {
+	for _, name := range []string{"db", "rpc"} {
+		ns := testNamespace(t, name)
+		dir := t.TempDir()
+		require.NoError(t, renderNamespace(dir, ns))
+
+		decls := map[string]bool{}
+		for _, f := range []string{"doc.go", "attribute.go", "metric.go"} {
+			file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, f), nil, 0)
+			require.NoError(t, err, f)
+			assert.Equal(t, ns.Package(), file.Name.Name)
+			for name := range file.Scope.Objects {
+				decls[name] = true
+			}
+		}
+		for _, a := range ns.Attributes {
+			assert.True(t, decls[a.GoName+"Key"], a.Name)
+			assert.True(t, decls[a.GoName], a.Name)
+		}
+		for _, m := range ns.Metrics {
+			assert.True(t, decls["New"+m.GoName], m.Name)
+		}
+	}
+}
+
+func TestRenderNamespaceWithoutMetrics(t *testing.T) {
+	ns := testNamespace(t, "db")
+	ns.Metrics = nil
+	dir := t.TempDir()
+	require.NoError(t, renderNamespace(dir, ns))
+	_, err := os.Stat(filepath.Join(dir, "metric.go"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
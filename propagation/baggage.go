@@ -26,13 +26,34 @@ const baggageHeader = "baggage"
 //
 // This propagates user-defined baggage associated with a trace. The complete
 // specification is defined at https://www.w3.org/TR/baggage/.
-type Baggage struct{}
+//
+// The zero value injects all baggage members. Use NewBaggage to create a
+// Baggage propagator with a policy restricting the members that are
+// injected.
+type Baggage struct {
+	policy *baggagePolicy
+}
+
+// NewBaggage returns a Baggage propagator that only injects the baggage
+// members allowed by the policy configured with opts. Extraction is not
+// affected by the policy.
+func NewBaggage(opts ...BaggageOption) Baggage {
+	var p baggagePolicy
+	for _, o := range opts {
+		p = o.applyBaggage(p)
+	}
+	return Baggage{policy: &p}
+}
 
-var _ TextMapPropagator = Baggage{}
+var _ FieldsExtractor = Baggage{}
 
 // Inject sets baggage key-values from ctx into the carrier.
 func (b Baggage) Inject(ctx context.Context, carrier TextMapCarrier) {
-	bStr := baggage.FromContext(ctx).String()
+	bag := baggage.FromContext(ctx)
+	if b.policy != nil {
+		bag = b.policy.apply(DestinationFromContext(ctx), bag)
+	}
+	bStr := bag.String()
 	if bStr != "" {
 		carrier.Set(baggageHeader, bStr)
 	}
@@ -52,6 +73,14 @@ func (b Baggage) Extract(parent context.Context, carrier TextMapCarrier) context
 	return baggage.ContextWithBaggage(parent, bag)
 }
 
+// ExtractFields returns a copy of parent with the baggage from the carrier
+// added the same way Extract does. It also returns the carrier keys that
+// were read.
+func (b Baggage) ExtractFields(parent context.Context, carrier TextMapCarrier) (context.Context, []string) {
+	rc := &recordingCarrier{TextMapCarrier: carrier}
+	return b.Extract(parent, rc), rc.fields
+}
+
 // Fields returns the keys who's values are set with Inject.
 func (b Baggage) Fields() []string {
 	return []string{baggageHeader}
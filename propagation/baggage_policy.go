@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "github.com/middleware-labs/otel/propagation"
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/middleware-labs/otel/baggage"
+)
+
+type destinationKeyType int
+
+const destinationKey destinationKeyType = 0
+
+// ContextWithDestination returns a copy of parent with host set as the
+// destination the context is being propagated to. Propagators use the
+// destination to decide what is injected (see NewBaggage).
+//
+// The host may contain a port, it is ignored when matching hosts.
+func ContextWithDestination(parent context.Context, host string) context.Context {
+	return context.WithValue(parent, destinationKey, host)
+}
+
+// DestinationFromContext returns the destination host set in ctx with
+// ContextWithDestination. An empty string is returned if no destination is
+// set.
+func DestinationFromContext(ctx context.Context) string {
+	host, _ := ctx.Value(destinationKey).(string)
+	return host
+}
+
+// BaggageFilter reports whether member is injected when propagating to the
+// destination host. The destination is the one set with
+// ContextWithDestination, or an empty string if unknown.
+//
+// The member properties (the W3C Baggage metadata) are available to the
+// filter so members can be marked for restricted propagation.
+type BaggageFilter func(destination string, member baggage.Member) bool
+
+// BaggageOption configures the policy of a Baggage propagator.
+type BaggageOption interface {
+	applyBaggage(baggagePolicy) baggagePolicy
+}
+
+type baggageOptionFunc func(baggagePolicy) baggagePolicy
+
+func (fn baggageOptionFunc) applyBaggage(p baggagePolicy) baggagePolicy {
+	return fn(p)
+}
+
+// WithBaggageFilter adds filter to the policy. A member is only injected if
+// all filters of the policy accept it.
+func WithBaggageFilter(filter BaggageFilter) BaggageOption {
+	return baggageOptionFunc(func(p baggagePolicy) baggagePolicy {
+		if filter != nil {
+			p.filters = append(p.filters, filter)
+		}
+		return p
+	})
+}
+
+// WithBaggageAllowedKeys restricts the injected members to the ones with one
+// of keys.
+func WithBaggageAllowedKeys(keys ...string) BaggageOption {
+	allowed := keySet(keys)
+	return WithBaggageFilter(func(_ string, m baggage.Member) bool {
+		_, ok := allowed[m.Key()]
+		return ok
+	})
+}
+
+// WithBaggageDeniedKeys prevents the members with one of keys from being
+// injected.
+func WithBaggageDeniedKeys(keys ...string) BaggageOption {
+	denied := keySet(keys)
+	return WithBaggageFilter(func(_ string, m baggage.Member) bool {
+		_, ok := denied[m.Key()]
+		return !ok
+	})
+}
+
+// WithBaggageAllowedHosts restricts baggage injection to destinations
+// matching one of hosts. Nothing is injected if the destination is unknown.
+//
+// A host matches a destination if they are equal, ignoring case and port. A
+// host with a leading dot (i.e. ".example.com") matches all subdomains of the
+// domain as well as the domain itself.
+func WithBaggageAllowedHosts(hosts ...string) BaggageOption {
+	return WithBaggageFilter(func(dest string, _ baggage.Member) bool {
+		return dest != "" && matchHosts(hosts, dest)
+	})
+}
+
+// WithBaggageDeniedHosts prevents baggage from being injected when
+// propagating to destinations matching one of hosts. See
+// WithBaggageAllowedHosts for how hosts are matched.
+func WithBaggageDeniedHosts(hosts ...string) BaggageOption {
+	return WithBaggageFilter(func(dest string, _ baggage.Member) bool {
+		return dest == "" || !matchHosts(hosts, dest)
+	})
+}
+
+// WithBaggageMaxMembers limits the number of injected members to n. Members
+// are kept in key order until the limit is reached. Values less than or equal
+// to zero are ignored.
+func WithBaggageMaxMembers(n int) BaggageOption {
+	return baggageOptionFunc(func(p baggagePolicy) baggagePolicy {
+		if n > 0 {
+			p.maxMembers = n
+		}
+		return p
+	})
+}
+
+// WithBaggageMaxBytes limits the size of the injected baggage header to n
+// bytes. Members are kept in key order as long as they fit within the limit.
+// Values less than or equal to zero are ignored.
+func WithBaggageMaxBytes(n int) BaggageOption {
+	return baggageOptionFunc(func(p baggagePolicy) baggagePolicy {
+		if n > 0 {
+			p.maxBytes = n
+		}
+		return p
+	})
+}
+
+// baggagePolicy decides what baggage members a Baggage propagator injects.
+type baggagePolicy struct {
+	filters    []BaggageFilter
+	maxMembers int
+	maxBytes   int
+}
+
+// apply returns the members of bag allowed to be propagated to dest.
+func (p *baggagePolicy) apply(dest string, bag baggage.Baggage) baggage.Baggage {
+	if len(p.filters) == 0 && p.maxMembers == 0 && p.maxBytes == 0 {
+		return bag
+	}
+
+	members := bag.Members()
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Key() < members[j].Key()
+	})
+
+	kept := members[:0]
+	var size int
+	for _, m := range members {
+		if !p.allowed(dest, m) {
+			continue
+		}
+		if p.maxMembers > 0 && len(kept) == p.maxMembers {
+			break
+		}
+		if p.maxBytes > 0 {
+			// Account for the list delimiter.
+			n := len(m.String())
+			if len(kept) > 0 {
+				n++
+			}
+			if size+n > p.maxBytes {
+				continue
+			}
+			size += n
+		}
+		kept = append(kept, m)
+	}
+
+	out, err := baggage.New(kept...)
+	if err != nil {
+		// All members were validated when bag was created.
+		return baggage.Baggage{}
+	}
+	return out
+}
+
+func (p *baggagePolicy) allowed(dest string, m baggage.Member) bool {
+	for _, f := range p.filters {
+		if !f(dest, m) {
+			return false
+		}
+	}
+	return true
+}
+
+func keySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// matchHosts returns if dest matches any of hosts.
+func matchHosts(hosts []string, dest string) bool {
+	if h, _, err := net.SplitHostPort(dest); err == nil {
+		dest = h
+	}
+	dest = strings.ToLower(dest)
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if strings.HasPrefix(h, ".") {
+			if dest == h[1:] || strings.HasSuffix(dest, h) {
+				return true
+			}
+			continue
+		}
+		if dest == h {
+			return true
+		}
+	}
+	return false
+}
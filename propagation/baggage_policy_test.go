@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/middleware-labs/otel/baggage"
+	"github.com/middleware-labs/otel/propagation"
+)
+
+func TestBaggagePolicyInject(t *testing.T) {
+	bag := members{
+		{Key: "tenant", Value: "internal-1"},
+		{Key: "user", Value: "alice"},
+		{Key: "region", Value: "eu", Properties: []property{{Key: "scope", Value: "internal"}}},
+	}.Baggage(t)
+	internalOnly := propagation.WithBaggageFilter(func(dest string, m baggage.Member) bool {
+		for _, p := range m.Properties() {
+			if v, _ := p.Value(); p.Key() == "scope" && v == "internal" {
+				return dest == "svc.internal"
+			}
+		}
+		return true
+	})
+
+	tests := []struct {
+		name string
+		opts []propagation.BaggageOption
+		dest string
+		want string
+	}{
+		{
+			name: "no policy",
+			want: bag.String(),
+		},
+		{
+			name: "allowed keys",
+			opts: []propagation.BaggageOption{propagation.WithBaggageAllowedKeys("user", "missing")},
+			want: "user=alice",
+		},
+		{
+			name: "denied keys",
+			opts: []propagation.BaggageOption{propagation.WithBaggageDeniedKeys("tenant", "region")},
+			want: "user=alice",
+		},
+		{
+			name: "allowed host",
+			opts: []propagation.BaggageOption{propagation.WithBaggageAllowedHosts(".example.com")},
+			dest: "api.Example.com:443",
+			want: bag.String(),
+		},
+		{
+			name: "allowed host unknown destination",
+			opts: []propagation.BaggageOption{propagation.WithBaggageAllowedHosts(".example.com")},
+		},
+		{
+			name: "host not allowed",
+			opts: []propagation.BaggageOption{propagation.WithBaggageAllowedHosts("example.com")},
+			dest: "api.example.com",
+		},
+		{
+			name: "denied host",
+			opts: []propagation.BaggageOption{propagation.WithBaggageDeniedHosts("thirdparty.io")},
+			dest: "thirdparty.io:8080",
+		},
+		{
+			name: "metadata filter external",
+			opts: []propagation.BaggageOption{internalOnly},
+			dest: "thirdparty.io",
+			want: "tenant=internal-1,user=alice",
+		},
+		{
+			name: "max members",
+			opts: []propagation.BaggageOption{propagation.WithBaggageMaxMembers(1)},
+			want: "region=eu;scope=internal",
+		},
+		{
+			name: "max bytes",
+			opts: []propagation.BaggageOption{propagation.WithBaggageMaxBytes(len("region=eu;scope=internal,user=alice"))},
+			want: "region=eu;scope=internal,user=alice",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := baggage.ContextWithBaggage(context.Background(), bag)
+			if tc.dest != "" {
+				ctx = propagation.ContextWithDestination(ctx, tc.dest)
+			}
+			carrier := propagation.MapCarrier{}
+			propagation.NewBaggage(tc.opts...).Inject(ctx, carrier)
+
+			want, err := baggage.Parse(tc.want)
+			assert.NoError(t, err)
+			got, err := baggage.Parse(carrier.Get("baggage"))
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, want.Members(), got.Members())
+		})
+	}
+}
+
+func TestBaggagePolicyDoesNotAffectExtract(t *testing.T) {
+	p := propagation.NewBaggage(propagation.WithBaggageAllowedKeys("none"))
+	ctx := p.Extract(context.Background(), propagation.MapCarrier{"baggage": "key=value"})
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+}
+
+func TestDestinationFromContext(t *testing.T) {
+	assert.Equal(t, "", propagation.DestinationFromContext(context.Background()))
+	ctx := propagation.ContextWithDestination(context.Background(), "example.com")
+	assert.Equal(t, "example.com", propagation.DestinationFromContext(ctx))
+}
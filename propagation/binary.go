@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "github.com/middleware-labs/otel/propagation"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/middleware-labs/otel/trace"
+)
+
+const (
+	binaryVersion = 0
+
+	binaryTraceIDField    = 0
+	binarySpanIDField     = 1
+	binaryTraceFlagsField = 2
+
+	binaryTraceIDOffset    = 2
+	binarySpanIDOffset     = binaryTraceIDOffset + 16 + 1
+	binaryTraceFlagsOffset = binarySpanIDOffset + 8 + 1
+
+	// binaryLen is the length of an encoded traceparent.
+	binaryLen = binaryTraceFlagsOffset + 1
+)
+
+var (
+	errBinaryTooShort    = errors.New("binary traceparent: too short")
+	errBinaryVersion     = errors.New("binary traceparent: unsupported version")
+	errBinaryFieldID     = errors.New("binary traceparent: invalid field identifier")
+	errBinarySpanContext = errors.New("binary traceparent: invalid span context")
+	errBinaryEmpty       = errors.New("binary traceparent: empty")
+)
+
+// BinaryTraceContext encodes the W3C traceparent in a compact binary form
+// for transports where the text encoding is too costly (i.e. message
+// headers with a byte value).
+//
+// The encoding is a version byte followed by field-identifier-prefixed
+// values:
+//
+//	version (1 byte) = 0
+//	field 0 (1 byte) | trace-id (16 bytes)
+//	field 1 (1 byte) | parent-id (8 bytes)
+//	field 2 (1 byte) | trace-flags (1 byte)
+//
+// All the trace-flags, including the random flag, are encoded. The tracestate
+// is not part of the binary encoding.
+type BinaryTraceContext struct{}
+
+// Marshal returns the binary encoding of sc. Nil is returned if sc is not
+// valid.
+func (BinaryTraceContext) Marshal(sc trace.SpanContext) []byte {
+	if !sc.IsValid() {
+		return nil
+	}
+
+	b := make([]byte, binaryLen)
+	b[0] = binaryVersion
+
+	tid := sc.TraceID()
+	b[binaryTraceIDOffset-1] = binaryTraceIDField
+	copy(b[binaryTraceIDOffset:], tid[:])
+
+	sid := sc.SpanID()
+	b[binarySpanIDOffset-1] = binarySpanIDField
+	copy(b[binarySpanIDOffset:], sid[:])
+
+	b[binaryTraceFlagsOffset-1] = binaryTraceFlagsField
+	b[binaryTraceFlagsOffset] = byte(sc.TraceFlags())
+	return b
+}
+
+// Unmarshal decodes a remote SpanContext from its binary encoding. An error
+// is returned if b is not of the supported version.
+func (BinaryTraceContext) Unmarshal(b []byte) (trace.SpanContext, error) {
+	if len(b) == 0 {
+		return trace.SpanContext{}, errBinaryEmpty
+	}
+	if b[0] != binaryVersion {
+		return trace.SpanContext{}, errBinaryVersion
+	}
+	// The trace-flags field is optional, all others are required.
+	if len(b) < binarySpanIDOffset+8 {
+		return trace.SpanContext{}, errBinaryTooShort
+	}
+	if b[binaryTraceIDOffset-1] != binaryTraceIDField || b[binarySpanIDOffset-1] != binarySpanIDField {
+		return trace.SpanContext{}, errBinaryFieldID
+	}
+
+	scc := trace.SpanContextConfig{Remote: true}
+	copy(scc.TraceID[:], b[binaryTraceIDOffset:])
+	copy(scc.SpanID[:], b[binarySpanIDOffset:])
+	if len(b) > binaryTraceFlagsOffset {
+		if b[binaryTraceFlagsOffset-1] != binaryTraceFlagsField {
+			return trace.SpanContext{}, errBinaryFieldID
+		}
+		scc.TraceFlags = trace.TraceFlags(b[binaryTraceFlagsOffset])
+	}
+
+	sc := trace.NewSpanContext(scc)
+	if !sc.IsValid() {
+		return trace.SpanContext{}, errBinarySpanContext
+	}
+	return sc, nil
+}
+
+// Inject returns the binary encoding of the SpanContext held by ctx. Nil is
+// returned if ctx does not contain a valid SpanContext.
+func (bt BinaryTraceContext) Inject(ctx context.Context) []byte {
+	return bt.Marshal(trace.SpanContextFromContext(ctx))
+}
+
+// Extract returns a copy of ctx with the SpanContext decoded from b set as
+// the remote SpanContext. If b cannot be decoded, ctx is returned directly.
+func (bt BinaryTraceContext) Extract(ctx context.Context, b []byte) context.Context {
+	sc, err := bt.Unmarshal(b)
+	if err != nil {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
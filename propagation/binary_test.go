@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/propagation"
+	"github.com/middleware-labs/otel/trace"
+)
+
+func TestBinaryTraceContextRoundTrip(t *testing.T) {
+	bt := propagation.BinaryTraceContext{}
+	for _, flags := range []trace.TraceFlags{0, trace.FlagsSampled, trace.FlagsRandom, trace.FlagsSampled | trace.FlagsRandom} {
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: flags,
+		})
+
+		b := bt.Marshal(sc)
+		require.Len(t, b, 29)
+
+		got, err := bt.Unmarshal(b)
+		require.NoError(t, err)
+		assert.Equal(t, sc.WithRemote(true), got)
+	}
+}
+
+func TestBinaryTraceContextMarshalInvalid(t *testing.T) {
+	assert.Nil(t, propagation.BinaryTraceContext{}.Marshal(trace.SpanContext{}))
+}
+
+func TestBinaryTraceContextUnmarshal(t *testing.T) {
+	valid := propagation.BinaryTraceContext{}.Marshal(trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	noFlags := valid[:27]
+	future := append([]byte{1}, valid[1:]...)
+	future = append(future, 0xff, 0xff)
+	badField := append([]byte{}, valid...)
+	badField[1] = 7
+	zeroTraceID := append([]byte{}, valid...)
+	copy(zeroTraceID[2:18], make([]byte, 16))
+
+	tests := []struct {
+		name    string
+		b       []byte
+		wantErr bool
+		flags   trace.TraceFlags
+	}{
+		{name: "empty", b: nil, wantErr: true},
+		{name: "short", b: valid[:20], wantErr: true},
+		{name: "invalid field", b: badField, wantErr: true},
+		{name: "invalid trace ID", b: zeroTraceID, wantErr: true},
+		{name: "unknown version", b: future, wantErr: true},
+		{name: "without flags", b: noFlags},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := propagation.BinaryTraceContext{}.Unmarshal(tc.b)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.False(t, sc.IsValid())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, traceID, sc.TraceID())
+			assert.Equal(t, spanID, sc.SpanID())
+			assert.Equal(t, tc.flags, sc.TraceFlags())
+			assert.True(t, sc.IsRemote())
+		})
+	}
+}
+
+func TestBinaryTraceContextInjectExtract(t *testing.T) {
+	bt := propagation.BinaryTraceContext{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	b := bt.Inject(trace.ContextWithSpanContext(context.Background(), sc))
+	ctx := bt.Extract(context.Background(), b)
+	assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(ctx))
+
+	ctx = context.Background()
+	assert.Equal(t, ctx, bt.Extract(ctx, []byte{0, 1}))
+}
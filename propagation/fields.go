@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "github.com/middleware-labs/otel/propagation"
+
+import "context"
+
+// FieldsExtractor is an optional extension of a TextMapPropagator that
+// reports which carrier keys were read during extraction.
+//
+// This is useful for messaging instrumentation that needs to remove the
+// propagation fields from a message before handing it to user code.
+type FieldsExtractor interface {
+	TextMapPropagator
+
+	// ExtractFields reads cross-cutting concerns from the carrier into a
+	// Context the same way Extract does. It also returns the carrier keys
+	// that held a value read by the propagator.
+	ExtractFields(ctx context.Context, carrier TextMapCarrier) (context.Context, []string)
+}
+
+// ExtractWithFields extracts cross-cutting concerns from carrier using p and
+// returns the carrier keys that were read. If p implements FieldsExtractor
+// its ExtractFields method is used, otherwise the keys are determined by
+// recording the non-empty values p reads from the carrier.
+func ExtractWithFields(ctx context.Context, p TextMapPropagator, carrier TextMapCarrier) (context.Context, []string) {
+	if fe, ok := p.(FieldsExtractor); ok {
+		return fe.ExtractFields(ctx, carrier)
+	}
+	rc := &recordingCarrier{TextMapCarrier: carrier}
+	ctx = p.Extract(ctx, rc)
+	return ctx, rc.fields
+}
+
+// recordingCarrier is a TextMapCarrier that records the keys of all
+// non-empty values read from the wrapped carrier.
+type recordingCarrier struct {
+	TextMapCarrier
+
+	fields []string
+}
+
+// Get returns the value associated with the passed key and records key if
+// the value is not empty.
+func (c *recordingCarrier) Get(key string) string {
+	v := c.TextMapCarrier.Get(key)
+	if v == "" {
+		return v
+	}
+	for _, f := range c.fields {
+		if f == key {
+			return v
+		}
+	}
+	c.fields = append(c.fields, key)
+	return v
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/middleware-labs/otel/baggage"
+	"github.com/middleware-labs/otel/propagation"
+	"github.com/middleware-labs/otel/trace"
+)
+
+const validTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceContextExtractFields(t *testing.T) {
+	carrier := propagation.MapCarrier{
+		"traceparent": validTraceparent,
+		"other":       "value",
+	}
+	ctx, fields := propagation.TraceContext{}.ExtractFields(context.Background(), carrier)
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.Equal(t, []string{"traceparent"}, fields)
+}
+
+func TestBaggageExtractFields(t *testing.T) {
+	carrier := propagation.MapCarrier{"baggage": "key=value"}
+	ctx, fields := propagation.Baggage{}.ExtractFields(context.Background(), carrier)
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+	assert.Equal(t, []string{"baggage"}, fields)
+}
+
+func TestCompositeExtractFields(t *testing.T) {
+	p := propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+		propagation.TraceContext{},
+	)
+	carrier := propagation.MapCarrier{
+		"traceparent": validTraceparent,
+		"tracestate":  "key=value",
+		"baggage":     "key=value",
+	}
+	_, fields := propagation.ExtractWithFields(context.Background(), p, carrier)
+	assert.Equal(t, []string{"traceparent", "tracestate", "baggage"}, fields)
+}
+
+func TestExtractWithFieldsRecordsReads(t *testing.T) {
+	// propagator does not implement FieldsExtractor and reads nothing.
+	_, fields := propagation.ExtractWithFields(context.Background(), propagator{"a"}, propagation.MapCarrier{"a": "b"})
+	assert.Empty(t, fields)
+}
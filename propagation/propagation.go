@@ -17,6 +17,7 @@ package propagation // import "github.com/middleware-labs/otel/propagation"
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 // TextMapCarrier is the storage medium used by a TextMapPropagator.
@@ -88,6 +89,104 @@ func (hc HeaderCarrier) Keys() []string {
 	return keys
 }
 
+// MetadataCarrier adapts gRPC metadata to satisfy the TextMapCarrier
+// interface.
+//
+// The underlying type matches google.golang.org/grpc/metadata.MD so a
+// metadata.MD can be converted directly: MetadataCarrier(md). Keys are
+// lower-cased the same way the gRPC metadata package does.
+type MetadataCarrier map[string][]string
+
+// Compile time check that MetadataCarrier implements the TextMapCarrier.
+var _ TextMapCarrier = MetadataCarrier{}
+
+// Get returns the first value associated with the passed key.
+func (mc MetadataCarrier) Get(key string) string {
+	v := mc[strings.ToLower(key)]
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+// Set stores the key-value pair, replacing any existing values of key.
+func (mc MetadataCarrier) Set(key, value string) {
+	mc[strings.ToLower(key)] = []string{value}
+}
+
+// Keys lists the keys stored in this carrier.
+func (mc MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MessageHeader is a single key-value header of a message. It has the same
+// layout as the record headers used by most messaging clients (i.e. Kafka,
+// NATS).
+type MessageHeader struct {
+	Key   string
+	Value []byte
+}
+
+// MessageHeaderCarrier adapts a slice of MessageHeader to satisfy the
+// TextMapCarrier interface. Because Set may grow the slice, the carrier
+// must be used as a pointer: (*MessageHeaderCarrier)(&headers).
+type MessageHeaderCarrier []MessageHeader
+
+// Compile time check that *MessageHeaderCarrier implements the
+// TextMapCarrier.
+var _ TextMapCarrier = (*MessageHeaderCarrier)(nil)
+
+// Get returns the value of the first header with the passed key.
+func (mc *MessageHeaderCarrier) Get(key string) string {
+	for _, h := range *mc {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set stores the key-value pair. The first header with key is replaced and
+// any other headers with the same key are removed. If no header with key
+// exists, a new one is appended.
+func (mc *MessageHeaderCarrier) Set(key, value string) {
+	headers := (*mc)[:0]
+	found := false
+	for _, h := range *mc {
+		if h.Key != key {
+			headers = append(headers, h)
+			continue
+		}
+		if !found {
+			found = true
+			headers = append(headers, MessageHeader{Key: key, Value: []byte(value)})
+		}
+	}
+	if !found {
+		headers = append(headers, MessageHeader{Key: key, Value: []byte(value)})
+	}
+	*mc = headers
+}
+
+// Keys lists the unique keys stored in this carrier in the order they first
+// appear.
+func (mc *MessageHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*mc))
+	seen := make(map[string]struct{}, len(*mc))
+	for _, h := range *mc {
+		if _, ok := seen[h.Key]; ok {
+			continue
+		}
+		seen[h.Key] = struct{}{}
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
 // TextMapPropagator propagates cross-cutting concerns as key-value text
 // pairs within a carrier that travels in-band across process boundaries.
 type TextMapPropagator interface {
@@ -125,6 +224,23 @@ func (p compositeTextMapPropagator) Extract(ctx context.Context, carrier TextMap
 	return ctx
 }
 
+func (p compositeTextMapPropagator) ExtractFields(ctx context.Context, carrier TextMapCarrier) (context.Context, []string) {
+	var fields []string
+	seen := make(map[string]struct{})
+	for _, i := range p {
+		var f []string
+		ctx, f = ExtractWithFields(ctx, i, carrier)
+		for _, k := range f {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			fields = append(fields, k)
+		}
+	}
+	return ctx, fields
+}
+
 func (p compositeTextMapPropagator) Fields() []string {
 	unique := make(map[string]struct{})
 	for _, i := range p {
@@ -147,7 +263,8 @@ func (p compositeTextMapPropagator) Fields() []string {
 // The returned TextMapPropagator will inject and extract cross-cutting
 // concerns in the order the TextMapPropagators were provided. Additionally,
 // the Fields method will return a de-duplicated slice of the keys that are
-// set with the Inject method.
+// set with the Inject method. The returned TextMapPropagator also implements
+// FieldsExtractor.
 func NewCompositeTextMapPropagator(p ...TextMapPropagator) TextMapPropagator {
 	return compositeTextMapPropagator(p)
 }
@@ -135,3 +135,55 @@ func TestMapCarrierKeys(t *testing.T) {
 	sort.Strings(keys)
 	assert.Equal(t, []string{"baz", "foo"}, keys)
 }
+
+func TestMetadataCarrier(t *testing.T) {
+	md := map[string][]string{"existing": {"a", "b"}}
+	c := propagation.MetadataCarrier(md)
+
+	c.Set("Traceparent", "value")
+	assert.Equal(t, []string{"value"}, md["traceparent"])
+	assert.Equal(t, "value", c.Get("TRACEPARENT"))
+	assert.Equal(t, "a", c.Get("existing"))
+	assert.Equal(t, "", c.Get("missing"))
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"existing", "traceparent"}, keys)
+}
+
+func TestMessageHeaderCarrier(t *testing.T) {
+	headers := []propagation.MessageHeader{
+		{Key: "a", Value: []byte("1")},
+		{Key: "traceparent", Value: []byte("old")},
+		{Key: "b", Value: []byte("2")},
+		{Key: "traceparent", Value: []byte("dup")},
+	}
+	c := (*propagation.MessageHeaderCarrier)(&headers)
+
+	assert.Equal(t, "old", c.Get("traceparent"))
+	assert.Equal(t, []string{"a", "traceparent", "b"}, c.Keys())
+
+	c.Set("traceparent", "new")
+	assert.Equal(t, []propagation.MessageHeader{
+		{Key: "a", Value: []byte("1")},
+		{Key: "traceparent", Value: []byte("new")},
+		{Key: "b", Value: []byte("2")},
+	}, headers)
+
+	c.Set("tracestate", "key=value")
+	assert.Equal(t, "key=value", c.Get("tracestate"))
+	assert.Len(t, headers, 4)
+	assert.Equal(t, "", c.Get("missing"))
+}
+
+func TestMessageHeaderCarrierPropagation(t *testing.T) {
+	var headers []propagation.MessageHeader
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	propagation.TraceContext{}.Inject(ctx, (*propagation.MessageHeaderCarrier)(&headers))
+	assert.Equal(t, []propagation.MessageHeader{{
+		Key:   "traceparent",
+		Value: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+	}}, headers)
+}
@@ -33,6 +33,10 @@ const (
 // TraceContext is a propagator that supports the W3C Trace Context format
 // (https://www.w3.org/TR/trace-context/)
 //
+// Both the sampled and the random (W3C Trace Context Level 2) trace-flags
+// are propagated. Traceparent headers with a version greater than the
+// supported one are parsed according to the supported version.
+//
 // This propagator will propagate the traceparent and tracestate headers to
 // guarantee traces are not broken. It is up to the users of this propagator
 // to choose if they want to participate in a trace by modifying the
@@ -40,8 +44,12 @@ const (
 // their proprietary information.
 type TraceContext struct{}
 
-var _ TextMapPropagator = TraceContext{}
-var traceCtxRegExp = regexp.MustCompile("^(?P<version>[0-9a-f]{2})-(?P<traceID>[a-f0-9]{32})-(?P<spanID>[a-f0-9]{16})-(?P<traceFlags>[a-f0-9]{2})(?:-.*)?$")
+var _ FieldsExtractor = TraceContext{}
+var traceCtxRegExp = regexp.MustCompile("^(?P<version>[0-9a-f]{2})-(?P<traceID>[a-f0-9]{32})-(?P<spanID>[a-f0-9]{16})-(?P<traceFlags>[a-f0-9]{2})(?:-(?P<extra>.*))?$")
+
+// supportedFlags are the trace-flags defined by the supported version of the
+// W3C Trace Context specification: the sampled and the random flag.
+const supportedFlags = trace.FlagsSampled | trace.FlagsRandom
 
 // Inject set tracecontext from the Context into the carrier.
 func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
@@ -54,8 +62,9 @@ func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
 		carrier.Set(tracestateHeader, ts)
 	}
 
-	// Clear all flags other than the trace-context supported sampling bit.
-	flags := sc.TraceFlags() & trace.FlagsSampled
+	// Clear all flags other than the trace-context supported sampled and
+	// random bits.
+	flags := sc.TraceFlags() & supportedFlags
 
 	h := fmt.Sprintf("%.2x-%s-%s-%s",
 		supportedVersion,
@@ -78,6 +87,13 @@ func (tc TraceContext) Extract(ctx context.Context, carrier TextMapCarrier) cont
 	return trace.ContextWithRemoteSpanContext(ctx, sc)
 }
 
+// ExtractFields reads tracecontext from the carrier into a returned Context
+// the same way Extract does. It also returns the carrier keys that were read.
+func (tc TraceContext) ExtractFields(ctx context.Context, carrier TextMapCarrier) (context.Context, []string) {
+	rc := &recordingCarrier{TextMapCarrier: carrier}
+	return tc.Extract(ctx, rc), rc.fields
+}
+
 func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 	h := carrier.Get(traceparentHeader)
 	if h == "" {
@@ -90,7 +106,7 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 
-	if len(matches) < 5 { // four subgroups plus the overall match
+	if len(matches) < 6 { // five subgroups plus the overall match
 		return trace.SpanContext{}
 	}
 
@@ -106,7 +122,10 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 
-	if version == 0 && len(matches) != 5 { // four subgroups plus the overall match
+	// Version 00 does not define any data after the trace-flags. Future
+	// versions are parsed as version 00 and any additional data is ignored
+	// so new versions can be introduced without breaking traces.
+	if version == 0 && matches[5] != "" {
 		return trace.SpanContext{}
 	}
 
@@ -133,11 +152,12 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 	opts, err := hex.DecodeString(matches[4])
-	if err != nil || len(opts) < 1 || (version == 0 && opts[0] > 2) {
+	if err != nil || len(opts) < 1 || (version == 0 && trace.TraceFlags(opts[0])&^supportedFlags != 0) {
 		return trace.SpanContext{}
 	}
-	// Clear all flags other than the trace-context supported sampling bit.
-	scc.TraceFlags = trace.TraceFlags(opts[0]) & trace.FlagsSampled
+	// Clear all flags other than the trace-context supported sampled and
+	// random bits.
+	scc.TraceFlags = trace.TraceFlags(opts[0]) & supportedFlags
 
 	// Ignore the error returned here. Failure to parse tracestate MUST NOT
 	// affect the parsing of traceparent according to the W3C tracecontext
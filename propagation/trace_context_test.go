@@ -139,6 +139,42 @@ func TestExtractValidTraceContext(t *testing.T) {
 				Remote:  true,
 			}),
 		},
+		{
+			name: "random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-02"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "sampled and random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "future version random bit set",
+			header: http.Header{
+				traceparent: []string{"02-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0a"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
 		{
 			name: "future version additional data",
 			header: http.Header{
@@ -244,6 +280,14 @@ func TestExtractInvalidTraceContextFromHTTPReq(t *testing.T) {
 			name:   "trace-flag unused bits set",
 			header: "00-ab000000000000000000000000000000-cd00000000000000-09",
 		},
+		{
+			name:   "version 00 additional data",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-XYZxsf09",
+		},
+		{
+			name:   "version ff",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
 		{
 			name:   "missing options",
 			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
@@ -301,7 +345,7 @@ func TestInjectValidTraceContext(t *testing.T) {
 		{
 			name: "unsupported trace flag bits dropped",
 			header: http.Header{
-				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03"},
 			},
 			sc: trace.NewSpanContext(trace.SpanContextConfig{
 				TraceID:    traceID,
@@ -310,6 +354,18 @@ func TestInjectValidTraceContext(t *testing.T) {
 				Remote:     true,
 			}),
 		},
+		{
+			name: "random",
+			header: http.Header{
+				traceparent: []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-02"},
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
 		{
 			name: "with tracestate",
 			header: http.Header{
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package translation applies the changes described by a telemetry schema
+// file to telemetry data.
+//
+// A Translator is created from a parsed schema file (see the
+// github.com/middleware-labs/otel/schema/v1.1 package) and translates
+// telemetry produced with any version of that schema, identified by its
+// schema URL, to the version of the schema file. Resources are translated with
+// the Resource method, spans are translated by wrapping a SpanExporter or
+// SpanProcessor with NewSpanExporter or NewSpanProcessor, and metrics are
+// translated by wrapping a metric Exporter with NewMetricExporter.
+//
+// A Registry holds the OpenTelemetry schema files and the schema files of
+// other schema families, loaded from a local directory or an embedded file
+// system, and can be used with MergeWithSchemas from the
+// github.com/middleware-labs/otel/sdk/resource package to merge resources with
+// different schema URLs.
+//
+// Lower level access to the translation between two arbitrary versions of the
+// schema is provided by the Translation type.
+package translation // import "github.com/middleware-labs/otel/schema/translation"
@@ -0,0 +1,36 @@
+module github.com/middleware-labs/otel/schema/translation
+
+go 1.19
+
+require (
+	github.com/Masterminds/semver/v3 v3.2.0
+	github.com/middleware-labs/otel v1.15.0-rc.2
+	github.com/middleware-labs/otel/schema v0.0.4
+	github.com/middleware-labs/otel/sdk v1.15.0-rc.2
+	github.com/middleware-labs/otel/sdk/metric v0.38.0-rc.2
+	github.com/stretchr/testify v1.8.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/middleware-labs/otel/metric v1.15.0-rc.2 // indirect
+	github.com/middleware-labs/otel/trace v1.15.0-rc.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/middleware-labs/otel => ../..
+
+replace github.com/middleware-labs/otel/metric => ../../metric
+
+replace github.com/middleware-labs/otel/schema => ../
+
+replace github.com/middleware-labs/otel/sdk => ../../sdk
+
+replace github.com/middleware-labs/otel/sdk/metric => ../../sdk/metric
+
+replace github.com/middleware-labs/otel/trace => ../../trace
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/middleware-labs/otel/schema/translation"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/metric"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+)
+
+// NewMetricExporter returns a metric Exporter that translates metrics to the
+// schema version of t before exporting them with exporter.
+//
+// Metric names and data point attributes are translated from the schema URL
+// of their instrumentation scope, and the resource from the resource schema
+// URL. Data without a schema URL is not translated. Data with a schema URL
+// that cannot be translated by t is exported unchanged and the error is sent
+// to the global ErrorHandler.
+func NewMetricExporter(t *Translator, exporter metric.Exporter) metric.Exporter {
+	return &metricExporter{translator: t, Exporter: exporter}
+}
+
+type metricExporter struct {
+	translator *Translator
+	metric.Exporter
+}
+
+func (e *metricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	return e.Exporter.Export(ctx, e.translator.resourceMetrics(rm))
+}
+
+// resourceMetrics returns rm translated. The passed ResourceMetrics is not
+// modified, it may be reused by the reader.
+func (t *Translator) resourceMetrics(rm *metricdata.ResourceMetrics) *metricdata.ResourceMetrics {
+	res, err := t.Resource(rm.Resource)
+	if err != nil {
+		otel.Handle(fmt.Errorf("schema translation: resource: %w", err))
+		res = rm.Resource
+	}
+	out := &metricdata.ResourceMetrics{
+		Resource:     res,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics)),
+	}
+	for i, sm := range rm.ScopeMetrics {
+		out.ScopeMetrics[i] = sm
+		if sm.Scope.SchemaURL == "" || sm.Scope.SchemaURL == t.schemaURL {
+			continue
+		}
+		tr, err := t.Translation(sm.Scope.SchemaURL, t.schemaURL)
+		if err != nil {
+			otel.Handle(fmt.Errorf("schema translation: scope %q: %w", sm.Scope.Name, err))
+			continue
+		}
+		out.ScopeMetrics[i].Scope.SchemaURL = t.schemaURL
+		out.ScopeMetrics[i].Metrics = tr.metrics(sm.Metrics)
+	}
+	return out
+}
+
+// metrics translates each data point of metrics. Data points translated to
+// the same name are grouped into the same Metrics, in the order the names are
+// first seen.
+func (t *Translation) metrics(metrics []metricdata.Metrics) []metricdata.Metrics {
+	if t.IsIdentity() {
+		return metrics
+	}
+	var out []metricdata.Metrics
+	index := make(map[string]int)
+	add := func(m metricdata.Metrics) {
+		if i, ok := index[m.Name]; ok {
+			if merged, ok := merge(out[i].Data, m.Data); ok {
+				out[i].Data = merged
+				return
+			}
+		}
+		index[m.Name] = len(out)
+		out = append(out, m)
+	}
+	for _, m := range metrics {
+		for _, tm := range t.metric(m) {
+			add(tm)
+		}
+	}
+	return out
+}
+
+// metric translates the data points of m. Multiple Metrics are returned if
+// the data points are translated to different names.
+func (t *Translation) metric(m metricdata.Metrics) []metricdata.Metrics {
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		return splitPoints(t, m, data.DataPoints, dataPointAttrs[int64], func(dps []metricdata.DataPoint[int64]) metricdata.Aggregation {
+			return metricdata.Gauge[int64]{DataPoints: dps}
+		})
+	case metricdata.Gauge[float64]:
+		return splitPoints(t, m, data.DataPoints, dataPointAttrs[float64], func(dps []metricdata.DataPoint[float64]) metricdata.Aggregation {
+			return metricdata.Gauge[float64]{DataPoints: dps}
+		})
+	case metricdata.Sum[int64]:
+		return splitPoints(t, m, data.DataPoints, dataPointAttrs[int64], func(dps []metricdata.DataPoint[int64]) metricdata.Aggregation {
+			data.DataPoints = dps
+			return data
+		})
+	case metricdata.Sum[float64]:
+		return splitPoints(t, m, data.DataPoints, dataPointAttrs[float64], func(dps []metricdata.DataPoint[float64]) metricdata.Aggregation {
+			data.DataPoints = dps
+			return data
+		})
+	case metricdata.Histogram[int64]:
+		return splitPoints(t, m, data.DataPoints, histogramDataPointAttrs[int64], func(dps []metricdata.HistogramDataPoint[int64]) metricdata.Aggregation {
+			data.DataPoints = dps
+			return data
+		})
+	case metricdata.Histogram[float64]:
+		return splitPoints(t, m, data.DataPoints, histogramDataPointAttrs[float64], func(dps []metricdata.HistogramDataPoint[float64]) metricdata.Aggregation {
+			data.DataPoints = dps
+			return data
+		})
+	case metricdata.Summary:
+		return splitPoints(t, m, data.DataPoints, summaryDataPointAttrs, func(dps []metricdata.SummaryDataPoint) metricdata.Aggregation {
+			return metricdata.Summary{DataPoints: dps}
+		})
+	}
+	// Unknown aggregations are only renamed.
+	m.Name, _ = t.Metric(m.Name, nil)
+	return []metricdata.Metrics{m}
+}
+
+func dataPointAttrs[N int64 | float64](dp *metricdata.DataPoint[N]) *attribute.Set {
+	return &dp.Attributes
+}
+
+func histogramDataPointAttrs[N int64 | float64](dp *metricdata.HistogramDataPoint[N]) *attribute.Set {
+	return &dp.Attributes
+}
+
+func summaryDataPointAttrs(dp *metricdata.SummaryDataPoint) *attribute.Set {
+	return &dp.Attributes
+}
+
+// splitPoints translates the attributes of the data points dps of m and
+// groups them by their translated metric name. The aggregation of each group
+// is created with agg.
+func splitPoints[T any](t *Translation, m metricdata.Metrics, dps []T, attrs func(*T) *attribute.Set, agg func([]T) metricdata.Aggregation) []metricdata.Metrics {
+	if len(dps) == 0 {
+		m.Name, _ = t.Metric(m.Name, nil)
+		return []metricdata.Metrics{m}
+	}
+
+	var names []string
+	groups := make(map[string][]T)
+	for _, dp := range dps {
+		set := attrs(&dp)
+		name, kvs := t.Metric(m.Name, set.ToSlice())
+		*set = attribute.NewSet(kvs...)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], dp)
+	}
+
+	out := make([]metricdata.Metrics, 0, len(names))
+	for _, name := range names {
+		tm := m
+		tm.Name = name
+		tm.Data = agg(groups[name])
+		out = append(out, tm)
+	}
+	return out
+}
+
+// merge returns the data points of a and b combined into a single
+// aggregation if they are compatible.
+func merge(a, b metricdata.Aggregation) (metricdata.Aggregation, bool) {
+	switch x := a.(type) {
+	case metricdata.Gauge[int64]:
+		if y, ok := b.(metricdata.Gauge[int64]); ok {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Gauge[float64]:
+		if y, ok := b.(metricdata.Gauge[float64]); ok {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Sum[int64]:
+		if y, ok := b.(metricdata.Sum[int64]); ok && x.Temporality == y.Temporality && x.IsMonotonic == y.IsMonotonic {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Sum[float64]:
+		if y, ok := b.(metricdata.Sum[float64]); ok && x.Temporality == y.Temporality && x.IsMonotonic == y.IsMonotonic {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Histogram[int64]:
+		if y, ok := b.(metricdata.Histogram[int64]); ok && x.Temporality == y.Temporality {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Histogram[float64]:
+		if y, ok := b.(metricdata.Histogram[float64]); ok && x.Temporality == y.Temporality {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	case metricdata.Summary:
+		if y, ok := b.(metricdata.Summary); ok {
+			x.DataPoints = append(x.DataPoints, y.DataPoints...)
+			return x, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/metric"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata"
+	"github.com/middleware-labs/otel/sdk/metric/metricdata/metricdatatest"
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+type recordingExporter struct {
+	metric.Exporter
+	got *metricdata.ResourceMetrics
+}
+
+func (e *recordingExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	e.got = rm
+	return nil
+}
+
+func TestMetricExporter(t *testing.T) {
+	tr := newTestTranslator(t)
+	rec := &recordingExporter{}
+	exp := NewMetricExporter(tr, rec)
+
+	in := &metricdata.ResourceMetrics{
+		Resource: resource.NewWithAttributes(v100, attribute.String("telemetry.auto.version", "1")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "lib", SchemaURL: v100},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "system.paging.operations",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(attribute.String("direction", "in")), Value: 1},
+							{Attributes: attribute.NewSet(attribute.String("direction", "out")), Value: 2},
+							{Attributes: attribute.NewSet(attribute.String("direction", "in"), attribute.String("type", "major")), Value: 3},
+						},
+					},
+				},
+				{
+					Name: "http.server.duration",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{Attributes: attribute.NewSet(attribute.Int("http.status_code", 200)), Count: 1},
+						},
+					},
+				},
+			},
+		}},
+	}
+	require.NoError(t, exp.Export(context.Background(), in))
+
+	want := metricdata.ResourceMetrics{
+		Resource: resource.NewWithAttributes(v120, attribute.String("telemetry.auto_instr.version", "1")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "lib", SchemaURL: v120},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "system.paging.operations.in",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(), Value: 1},
+							{Attributes: attribute.NewSet(attribute.String("type", "major")), Value: 3},
+						},
+					},
+				},
+				{
+					Name: "system.paging.operations.out",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(), Value: 2},
+						},
+					},
+				},
+				{
+					Name: "http.server.duration",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{Attributes: attribute.NewSet(attribute.Int("http.response.status_code", 200)), Count: 1},
+						},
+					},
+				},
+			},
+		}},
+	}
+	require.NotNil(t, rec.got)
+	metricdatatest.AssertEqual(t, want, *rec.got)
+
+	// The input is not modified.
+	assert.Equal(t, "system.paging.operations", in.ScopeMetrics[0].Metrics[0].Name)
+	assert.Equal(t, v100, in.ScopeMetrics[0].Scope.SchemaURL)
+}
+
+func TestMetricExporterMergesSplitMetrics(t *testing.T) {
+	s := newTestTranslator(t)
+	down, err := s.Translation(v120, v100)
+	require.NoError(t, err)
+
+	got := down.metrics([]metricdata.Metrics{
+		{
+			Name: "system.paging.operations.in",
+			Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{{Value: 1}}},
+		},
+		{
+			Name: "system.paging.operations.out",
+			Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{{Value: 2}}},
+		},
+	})
+	require.Len(t, got, 1)
+	assert.Equal(t, "system.paging.operations", got[0].Name)
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Gauge[float64]{
+		DataPoints: []metricdata.DataPoint[float64]{
+			{Attributes: attribute.NewSet(attribute.String("direction", "in")), Value: 1},
+			{Attributes: attribute.NewSet(attribute.String("direction", "out")), Value: 2},
+		},
+	}, got[0].Data)
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/middleware-labs/otel/schema/translation"
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/middleware-labs/otel/attribute"
+	schema "github.com/middleware-labs/otel/schema/v1.1"
+	"github.com/middleware-labs/otel/schema/v1.1/ast"
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+// otelSchemas holds the schema files published by OpenTelemetry for the
+// semantic conventions of the semconv package. A schema file defines all the
+// versions prior to its own, only the newest one is needed.
+//
+//go:embed schemas/*.yaml
+var otelSchemas embed.FS
+
+// ErrNoSchema is returned by a Registry when no registered schema file
+// defines a requested schema version.
+var ErrNoSchema = errors.New("no schema file registered")
+
+// Registry holds the schema files of one or more schema families and
+// translates telemetry between any of the versions they define.
+//
+// The OpenTelemetry schema files of the semantic convention versions provided
+// by the github.com/middleware-labs/otel/semconv packages are registered by
+// default. Other schema files can be registered from the local file system
+// with LoadDir or from an embedded file system (see the embed package) with
+// LoadFS.
+//
+// A Registry implements the SchemaTranslator interface from the
+// github.com/middleware-labs/otel/sdk/resource package and can be used to
+// merge resources with different schema URLs.
+type Registry struct {
+	mu sync.RWMutex
+	// translators by schema family, sorted by their schema version.
+	translators map[string][]*Translator
+}
+
+var _ resource.SchemaTranslator = (*Registry)(nil)
+
+// NewRegistry returns a Registry holding the OpenTelemetry schema files.
+func NewRegistry() *Registry {
+	r := &Registry{translators: make(map[string][]*Translator)}
+	if err := r.LoadFS(otelSchemas); err != nil {
+		// The embedded files are validated by the tests.
+		panic(err)
+	}
+	return r
+}
+
+// Register adds the schema file s to r.
+func (r *Registry) Register(s *ast.Schema) error {
+	t, err := NewTranslator(s)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := r.translators[t.family]
+	i := sort.Search(len(ts), func(i int) bool { return !ts[i].target.LessThan(t.target) })
+	if i < len(ts) && ts[i].target.Equal(t.target) {
+		ts[i] = t
+	} else {
+		ts = append(ts, nil)
+		copy(ts[i+1:], ts[i:])
+		ts[i] = t
+	}
+	r.translators[t.family] = ts
+	return nil
+}
+
+// LoadFS registers all the schema files with a ".yaml" or ".yml" extension
+// found in fsys. The returned error identifies the files that could not be
+// parsed or registered.
+func (r *Registry) LoadFS(fsys fs.FS) error {
+	var errs []error
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := path.Ext(p); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		if err := r.loadFile(fsys, p); err != nil {
+			errs = append(errs, fmt.Errorf("schema file %s: %w", p, err))
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return loadErrs(errs)
+}
+
+type loadErrs []error
+
+func (e loadErrs) Error() string {
+	errStr := make([]string, len(e))
+	for i, err := range e {
+		errStr[i] = fmt.Sprintf("* %s", err)
+	}
+
+	format := "%d errors occurred loading schema files:\n\t%s"
+	return fmt.Sprintf(format, len(e), strings.Join(errStr, "\n\t"))
+}
+
+func (e loadErrs) Unwrap() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e[1:]
+}
+
+func (e loadErrs) Is(target error) bool {
+	return len(e) != 0 && errors.Is(e[0], target)
+}
+
+// LoadDir registers all the schema files found in the local directory dir.
+// See LoadFS for more information.
+func (r *Registry) LoadDir(dir string) error {
+	return r.LoadFS(os.DirFS(dir))
+}
+
+func (r *Registry) loadFile(fsys fs.FS, p string) error {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := schema.Parse(f)
+	if err != nil {
+		return err
+	}
+	return r.Register(s)
+}
+
+// Translator returns the Translator of the newest registered schema file of
+// the family of schemaURL that defines the version of schemaURL.
+func (r *Registry) Translator(schemaURL string) (*Translator, error) {
+	family, _, err := splitSchemaURL(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ts := r.translators[family]
+	for i := len(ts) - 1; i >= 0; i-- {
+		if _, err := ts[i].version(schemaURL); err == nil {
+			return ts[i], nil
+		}
+	}
+	if len(ts) == 0 {
+		return nil, fmt.Errorf("%w for schema family %s", ErrNoSchema, family)
+	}
+	return nil, fmt.Errorf("%w: %s (newest registered version %s)", ErrUnknownVersion, schemaURL, ts[len(ts)-1].target)
+}
+
+// Translation returns the Translation between the schema URLs from and to
+// using the newest registered schema file that defines both versions.
+func (r *Registry) Translation(from, to string) (*Translation, error) {
+	fromFamily, fromVer, err := splitSchemaURL(from)
+	if err != nil {
+		return nil, err
+	}
+	_, toVer, err := splitSchemaURL(to)
+	if err != nil {
+		return nil, err
+	}
+	newest := to
+	if toVer.LessThan(fromVer) {
+		newest = from
+	}
+	t, err := r.Translator(newest)
+	if err != nil {
+		return nil, err
+	}
+	if t.family != fromFamily {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaFamily, from)
+	}
+	return t.Translation(from, to)
+}
+
+// TranslateResource returns the resource attributes attrs translated from
+// the schema URL from to the schema URL to.
+func (r *Registry) TranslateResource(from, to string, attrs []attribute.KeyValue) ([]attribute.KeyValue, error) {
+	tr, err := r.Translation(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return tr.Resource(attrs), nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	require.NoError(t, r.LoadDir("testdata/registry"))
+	return r
+}
+
+func TestRegistryTranslator(t *testing.T) {
+	r := newTestRegistry(t)
+
+	tr, err := r.Translator(v100)
+	require.NoError(t, err)
+	assert.Equal(t, v120, tr.SchemaURL(), "newest schema file")
+
+	_, err = r.Translator("https://example.com/schemas/1.3.0")
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+
+	_, err = r.Translator("https://other.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrNoSchema)
+}
+
+func TestRegistryTranslateResource(t *testing.T) {
+	r := newTestRegistry(t)
+
+	got, err := r.TranslateResource(v100, v120, []attribute.KeyValue{
+		attribute.String("telemetry.auto.version", "1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("telemetry.auto_instr.version", "1")}, got)
+
+	got, err = r.TranslateResource(v120, v110, []attribute.KeyValue{
+		attribute.String("server.address", "host"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("net.peer.name", "host")}, got)
+
+	_, err = r.TranslateResource("https://other.com/schemas/1.0.0", v120, nil)
+	assert.ErrorIs(t, err, ErrSchemaFamily)
+}
+
+func TestRegistryLoadErrors(t *testing.T) {
+	r := NewRegistry()
+	err := r.LoadDir("testdata/invalid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown-field.yaml")
+
+	_, err = r.Translator(v100)
+	assert.ErrorIs(t, err, ErrNoSchema)
+}
+
+func TestRegistryMergeResources(t *testing.T) {
+	r := newTestRegistry(t)
+
+	a := resource.NewWithAttributes(v100, attribute.String("telemetry.auto.version", "1"))
+	b := resource.NewWithAttributes(v120, attribute.String("service.name", "svc"))
+	got, err := resource.MergeWithSchemas(a, b, r)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(v120,
+		attribute.String("telemetry.auto_instr.version", "1"),
+		attribute.String("service.name", "svc"),
+	), got)
+
+	unknown := resource.NewWithAttributes("https://example.com/schemas/0.9.0", attribute.String("k", "v"))
+	_, err = resource.MergeWithSchemas(unknown, b, r)
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestRegistryOTelSchemas(t *testing.T) {
+	r := NewRegistry()
+
+	a := resource.NewWithAttributes(
+		"https://opentelemetry.io/schemas/1.12.0",
+		attribute.String("service.name", "svc"),
+	)
+	b := resource.NewWithAttributes(
+		"https://opentelemetry.io/schemas/1.17.0",
+		attribute.String("host.name", "host"),
+	)
+	got, err := resource.MergeWithSchemas(a, b, r)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(
+		"https://opentelemetry.io/schemas/1.17.0",
+		attribute.String("service.name", "svc"),
+		attribute.String("host.name", "host"),
+	), got)
+
+	tr, err := r.Translation("https://opentelemetry.io/schemas/1.12.0", "https://opentelemetry.io/schemas/1.18.0")
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("net.sock.peer.addr", "10.0.0.1"),
+		attribute.String("messaging.destination.name", "orders"),
+	}, tr.Span("span", []attribute.KeyValue{
+		attribute.String("net.peer.ip", "10.0.0.1"),
+		attribute.String("messaging.destination", "orders"),
+	}))
+}
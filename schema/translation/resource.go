@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/middleware-labs/otel/schema/translation"
+
+import (
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+// Resource returns res translated to the schema version of t.
+//
+// A Resource without a schema URL is returned unchanged. An error is
+// returned if the schema URL of res cannot be translated by t.
+func (t *Translator) Resource(res *resource.Resource) (*resource.Resource, error) {
+	if res == nil || res.SchemaURL() == "" || res.SchemaURL() == t.schemaURL {
+		return res, nil
+	}
+	tr, err := t.Translation(res.SchemaURL(), t.schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewWithAttributes(t.schemaURL, tr.Resource(res.Attributes())...), nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/middleware-labs/otel/schema/translation"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/middleware-labs/otel"
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/resource"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+)
+
+// NewSpanExporter returns a SpanExporter that translates spans to the schema
+// version of t before exporting them with exporter.
+//
+// The attributes and events of a span are translated from the schema URL of
+// its instrumentation scope, and its resource from the resource schema URL.
+// Data without a schema URL is not translated. Data with a schema URL that
+// cannot be translated by t is exported unchanged and the error is sent to
+// the global ErrorHandler.
+func NewSpanExporter(t *Translator, exporter sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return &spanExporter{translator: t, SpanExporter: exporter}
+}
+
+type spanExporter struct {
+	translator *Translator
+	sdktrace.SpanExporter
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	translated := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		translated[i] = e.translator.span(s)
+	}
+	return e.SpanExporter.ExportSpans(ctx, translated)
+}
+
+// NewSpanProcessor returns a SpanProcessor that translates spans to the
+// schema version of t before passing them to the OnEnd method of processor.
+// Spans are translated the same way as by NewSpanExporter.
+func NewSpanProcessor(t *Translator, processor sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+	return &spanProcessor{translator: t, SpanProcessor: processor}
+}
+
+type spanProcessor struct {
+	translator *Translator
+	sdktrace.SpanProcessor
+}
+
+func (p *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.SpanProcessor.OnEnd(p.translator.span(s))
+}
+
+// translatedSpan is a ReadOnlySpan with translated data.
+type translatedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+	res    *resource.Resource
+	scope  instrumentation.Scope
+}
+
+func (s *translatedSpan) Attributes() []attribute.KeyValue { return s.attrs }
+func (s *translatedSpan) Events() []sdktrace.Event         { return s.events }
+func (s *translatedSpan) Resource() *resource.Resource     { return s.res }
+
+func (s *translatedSpan) InstrumentationScope() instrumentation.Scope { return s.scope }
+
+func (s *translatedSpan) InstrumentationLibrary() instrumentation.Library { return s.scope }
+
+func (t *Translator) span(s sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	res, err := t.Resource(s.Resource())
+	if err != nil {
+		otel.Handle(fmt.Errorf("schema translation: resource: %w", err))
+		res = s.Resource()
+	}
+
+	scope := s.InstrumentationScope()
+	var tr *Translation
+	if scope.SchemaURL != "" && scope.SchemaURL != t.schemaURL {
+		tr, err = t.Translation(scope.SchemaURL, t.schemaURL)
+		if err != nil {
+			otel.Handle(fmt.Errorf("schema translation: span %q: %w", s.Name(), err))
+		} else {
+			scope.SchemaURL = t.schemaURL
+		}
+	}
+	if tr.IsIdentity() && res == s.Resource() && scope == s.InstrumentationScope() {
+		return s
+	}
+
+	out := &translatedSpan{
+		ReadOnlySpan: s,
+		attrs:        tr.Span(s.Name(), s.Attributes()),
+		res:          res,
+		scope:        scope,
+	}
+	if events := s.Events(); len(events) > 0 {
+		out.events = make([]sdktrace.Event, len(events))
+		for i, e := range events {
+			e.Name, e.Attributes = tr.Event(s.Name(), e.Name, e.Attributes)
+			out.events[i] = e
+		}
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/instrumentation"
+	"github.com/middleware-labs/otel/sdk/resource"
+	sdktrace "github.com/middleware-labs/otel/sdk/trace"
+	"github.com/middleware-labs/otel/sdk/trace/tracetest"
+)
+
+func TestTranslatorResource(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	res, err := tr.Resource(resource.NewWithAttributes(v100, attribute.String("net.peer.name", "host")))
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(v120, attribute.String("server.address", "host")), res)
+
+	schemaless := resource.NewSchemaless(attribute.String("net.peer.name", "host"))
+	res, err = tr.Resource(schemaless)
+	require.NoError(t, err)
+	assert.Same(t, schemaless, res)
+
+	_, err = tr.Resource(resource.NewWithAttributes("https://example.com/schemas/0.1.0"))
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestSpanExporter(t *testing.T) {
+	tr := newTestTranslator(t)
+	mem := tracetest.NewInMemoryExporter()
+	exp := NewSpanExporter(tr, mem)
+
+	stubs := tracetest.SpanStubs{
+		{
+			Name:       "HTTP GET",
+			Attributes: []attribute.KeyValue{attribute.String("http.method", "GET")},
+			Events: []sdktrace.Event{{
+				Name:       "exception.stacktrace",
+				Attributes: []attribute.KeyValue{attribute.String("exception.msg", "boom")},
+			}},
+			Resource:               resource.NewWithAttributes(v110, attribute.String("net.peer.name", "host")),
+			InstrumentationLibrary: instrumentation.Library{Name: "lib", SchemaURL: v100},
+		},
+		{
+			Name:                   "HTTP GET",
+			Attributes:             []attribute.KeyValue{attribute.String("http.method", "GET")},
+			InstrumentationLibrary: instrumentation.Library{Name: "schemaless"},
+		},
+	}
+	require.NoError(t, exp.ExportSpans(context.Background(), stubs.Snapshots()))
+
+	got := mem.GetSpans()
+	require.Len(t, got, 2)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("http.request.method", "GET")}, got[0].Attributes)
+	require.Len(t, got[0].Events, 1)
+	assert.Equal(t, "exception.stack_trace", got[0].Events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exception.message", "boom")}, got[0].Events[0].Attributes)
+	assert.Equal(t, resource.NewWithAttributes(v120, attribute.String("server.address", "host")), got[0].Resource)
+	assert.Equal(t, v120, got[0].InstrumentationLibrary.SchemaURL)
+
+	assert.Equal(t, stubs[1].Attributes, got[1].Attributes)
+	assert.Equal(t, "", got[1].InstrumentationLibrary.SchemaURL)
+}
+
+type recordingProcessor struct {
+	sdktrace.SpanProcessor
+	ended []sdktrace.ReadOnlySpan
+}
+
+func (p *recordingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.ended = append(p.ended, s)
+}
+
+func TestSpanProcessor(t *testing.T) {
+	tr := newTestTranslator(t)
+	rec := &recordingProcessor{}
+	p := NewSpanProcessor(tr, rec)
+
+	stub := tracetest.SpanStub{
+		Name:                   "HTTP GET",
+		Attributes:             []attribute.KeyValue{attribute.String("net.peer.name", "host")},
+		InstrumentationLibrary: instrumentation.Library{Name: "lib", SchemaURL: v110},
+	}
+	p.OnEnd(stub.Snapshot())
+
+	require.Len(t, rec.ended, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("server.address", "host")}, rec.ended[0].Attributes())
+	assert.Equal(t, v120, rec.ended[0].InstrumentationScope().SchemaURL)
+}
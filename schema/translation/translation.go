@@ -0,0 +1,563 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/middleware-labs/otel/schema/translation"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/schema/v1.1/ast"
+)
+
+var (
+	// ErrUnknownVersion is returned when a schema URL refers to a version
+	// that is not defined by the schema file.
+	ErrUnknownVersion = errors.New("unknown schema version")
+	// ErrSchemaFamily is returned when a schema URL does not belong to the
+	// same schema family as the schema file.
+	ErrSchemaFamily = errors.New("schema URL from a different schema family")
+)
+
+// Translator translates telemetry to the version of a schema file.
+type Translator struct {
+	schemaURL string
+	family    string
+	target    *semver.Version
+	versions  []*version
+}
+
+// NewTranslator returns a Translator for the schema s. The Translator
+// translates telemetry to the version identified by the schema URL of s.
+//
+// An error is returned if the version of the schema URL of s, or any version
+// defined in s, is not a valid semantic version.
+func NewTranslator(s *ast.Schema) (*Translator, error) {
+	if s == nil {
+		return nil, errors.New("nil schema")
+	}
+	family, ver, err := splitSchemaURL(s.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	t := &Translator{schemaURL: s.SchemaURL, family: family, target: ver}
+	for tv, def := range s.Versions {
+		v, err := semver.StrictNewVersion(string(tv))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema version %q: %w", tv, err)
+		}
+		t.versions = append(t.versions, newVersion(v, def))
+	}
+	sort.Slice(t.versions, func(i, j int) bool {
+		return t.versions[i].ver.LessThan(t.versions[j].ver)
+	})
+	return t, nil
+}
+
+// SchemaURL returns the schema URL telemetry is translated to.
+func (t *Translator) SchemaURL() string {
+	return t.schemaURL
+}
+
+// Translation returns the Translation from the schema version identified by
+// the schema URL from to the one identified by the schema URL to. Both schema
+// URLs need to belong to the schema family of t and their versions need to be
+// defined by the schema file of t, or be its own version.
+func (t *Translator) Translation(from, to string) (*Translation, error) {
+	fromVer, err := t.version(from)
+	if err != nil {
+		return nil, err
+	}
+	toVer, err := t.version(to)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &Translation{}
+	switch fromVer.Compare(toVer) {
+	case -1:
+		// Upgrade: apply the changes of all versions in (from, to].
+		for _, v := range t.versions {
+			if v.ver.GreaterThan(fromVer) && !v.ver.GreaterThan(toVer) {
+				tr.steps = append(tr.steps, v.upgrade())
+			}
+		}
+	case 1:
+		// Downgrade: revert the changes of all versions in (to, from] in
+		// reverse order.
+		for i := len(t.versions) - 1; i >= 0; i-- {
+			v := t.versions[i]
+			if v.ver.GreaterThan(toVer) && !v.ver.GreaterThan(fromVer) {
+				tr.steps = append(tr.steps, v.downgrade())
+			}
+		}
+	}
+	return tr, nil
+}
+
+func (t *Translator) version(schemaURL string) (*semver.Version, error) {
+	family, ver, err := splitSchemaURL(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if family != t.family {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaFamily, schemaURL)
+	}
+	if ver.Equal(t.target) {
+		return ver, nil
+	}
+	for _, v := range t.versions {
+		if v.ver.Equal(ver) {
+			return ver, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownVersion, schemaURL)
+}
+
+// splitSchemaURL splits a schema URL into its schema family, everything up to
+// the last path segment, and its version, the last path segment.
+func splitSchemaURL(schemaURL string) (string, *semver.Version, error) {
+	i := strings.LastIndex(schemaURL, "/")
+	if i < 0 {
+		return "", nil, fmt.Errorf("invalid schema URL %q: missing version", schemaURL)
+	}
+	ver, err := semver.StrictNewVersion(schemaURL[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+	}
+	return schemaURL[:i], ver, nil
+}
+
+// Translation translates telemetry between two versions of a schema. The zero
+// value performs no translation.
+type Translation struct {
+	steps []step
+}
+
+// IsIdentity returns true if t does not change any telemetry.
+func (t *Translation) IsIdentity() bool {
+	return t == nil || len(t.steps) == 0
+}
+
+// Resource returns the resource attributes attrs translated.
+func (t *Translation) Resource(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if t.IsIdentity() {
+		return attrs
+	}
+	for _, s := range t.steps {
+		attrs = s.apply(attrs, s.resources)
+	}
+	return attrs
+}
+
+// Span returns the attributes attrs of the span named spanName translated.
+func (t *Translation) Span(spanName string, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if t.IsIdentity() {
+		return attrs
+	}
+	for _, s := range t.steps {
+		if s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+		for _, c := range s.spans {
+			if c.applies(spanName) {
+				attrs = rename(attrs, c.attributes)
+			}
+		}
+		if !s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+	}
+	return attrs
+}
+
+// Event returns the name and attributes of the event named eventName, of the
+// span named spanName, translated.
+func (t *Translation) Event(spanName, eventName string, attrs []attribute.KeyValue) (string, []attribute.KeyValue) {
+	if t.IsIdentity() {
+		return eventName, attrs
+	}
+	for _, s := range t.steps {
+		if s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+		for _, c := range s.events {
+			if n, ok := c.names[eventName]; ok {
+				eventName = n
+			}
+			if c.applies(spanName, eventName) {
+				attrs = rename(attrs, c.attributes)
+			}
+		}
+		if !s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+	}
+	return eventName, attrs
+}
+
+// Metric returns the name of the metric named name and the attributes attrs
+// of one of its data points translated. Metrics that are split, or merged,
+// by the schema are translated to a name that depends on attrs.
+func (t *Translation) Metric(name string, attrs []attribute.KeyValue) (string, []attribute.KeyValue) {
+	if t.IsIdentity() {
+		return name, attrs
+	}
+	for _, s := range t.steps {
+		if s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+		for _, c := range s.metrics {
+			if n, ok := c.names[name]; ok {
+				name = n
+			}
+			if c.applies(name) {
+				attrs = rename(attrs, c.attributes)
+			}
+			if c.split != nil {
+				name, attrs = c.split.apply(name, attrs)
+			}
+		}
+		if !s.allFirst {
+			attrs = renameAll(attrs, s.all)
+		}
+	}
+	return name, attrs
+}
+
+// version holds the changes introduced by a version of a schema.
+type version struct {
+	ver *semver.Version
+
+	all       []attributeMap
+	resources []attributeMap
+	spans     []spansChange
+	events    []eventsChange
+	metrics   []metricsChange
+}
+
+func newVersion(v *semver.Version, def ast.VersionDef) *version {
+	out := &version{ver: v}
+	for _, c := range def.All.Changes {
+		if c.RenameAttributes != nil {
+			out.all = append(out.all, attributeMap(c.RenameAttributes.AttributeMap))
+		}
+	}
+	for _, c := range def.Resources.Changes {
+		if c.RenameAttributes != nil {
+			out.resources = append(out.resources, attributeMap(c.RenameAttributes.AttributeMap))
+		}
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes != nil {
+			out.spans = append(out.spans, spansChange{
+				spans:      nameSet(c.RenameAttributes.ApplyToSpans),
+				attributes: attributeMap(c.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, c := range def.SpanEvents.Changes {
+		var ec eventsChange
+		if c.RenameEvents != nil {
+			ec.names = c.RenameEvents.EventNameMap
+		}
+		if c.RenameAttributes != nil {
+			ec.spans = nameSet(c.RenameAttributes.ApplyToSpans)
+			ec.events = nameSet(c.RenameAttributes.ApplyToEvents)
+			ec.attributes = attributeMap(c.RenameAttributes.AttributeMap)
+		}
+		out.events = append(out.events, ec)
+	}
+	for _, c := range def.Metrics.Changes {
+		var mc metricsChange
+		if len(c.RenameMetrics) > 0 {
+			mc.names = make(map[string]string, len(c.RenameMetrics))
+			for k, v := range c.RenameMetrics {
+				mc.names[string(k)] = string(v)
+			}
+		}
+		if c.RenameAttributes != nil {
+			mc.metrics = nameSet(c.RenameAttributes.ApplyToMetrics)
+			mc.attributes = attributeMap(c.RenameAttributes.AttributeMap)
+		}
+		if c.Split != nil {
+			mc.split = newSplit(c.Split)
+		}
+		out.metrics = append(out.metrics, mc)
+	}
+	return out
+}
+
+// upgrade returns the step applying the changes of v.
+func (v *version) upgrade() step {
+	return step{
+		allFirst:  true,
+		all:       v.all,
+		resources: v.resources,
+		spans:     v.spans,
+		events:    v.events,
+		metrics:   v.metrics,
+	}
+}
+
+// downgrade returns the step reverting the changes of v.
+func (v *version) downgrade() step {
+	s := step{}
+	for i := len(v.all) - 1; i >= 0; i-- {
+		s.all = append(s.all, v.all[i].invert())
+	}
+	for i := len(v.resources) - 1; i >= 0; i-- {
+		s.resources = append(s.resources, v.resources[i].invert())
+	}
+	for i := len(v.spans) - 1; i >= 0; i-- {
+		c := v.spans[i]
+		s.spans = append(s.spans, spansChange{spans: c.spans, attributes: c.attributes.invert()})
+	}
+	for i := len(v.events) - 1; i >= 0; i-- {
+		c := v.events[i]
+		c.names = invert(c.names)
+		c.attributes = c.attributes.invert()
+		s.events = append(s.events, c)
+	}
+	for i := len(v.metrics) - 1; i >= 0; i-- {
+		c := v.metrics[i]
+		c.names = invert(c.names)
+		c.attributes = c.attributes.invert()
+		if c.split != nil {
+			c.split = c.split.invert()
+		}
+		s.metrics = append(s.metrics, c)
+	}
+	return s
+}
+
+// step is a directed set of changes of a single version. When upgrading, the
+// changes that apply to all attributes are applied before the data type
+// specific ones, when downgrading they are reverted after them.
+type step struct {
+	allFirst bool
+
+	all       []attributeMap
+	resources []attributeMap
+	spans     []spansChange
+	events    []eventsChange
+	metrics   []metricsChange
+}
+
+func (s step) apply(attrs []attribute.KeyValue, maps []attributeMap) []attribute.KeyValue {
+	if s.allFirst {
+		attrs = renameAll(attrs, s.all)
+	}
+	attrs = renameAll(attrs, maps)
+	if !s.allFirst {
+		attrs = renameAll(attrs, s.all)
+	}
+	return attrs
+}
+
+type spansChange struct {
+	// spans the change applies to. All spans if empty.
+	spans      map[string]struct{}
+	attributes attributeMap
+}
+
+func (c spansChange) applies(spanName string) bool {
+	return matches(c.spans, spanName)
+}
+
+type eventsChange struct {
+	names map[string]string
+
+	// spans and events the attribute changes apply to. All if empty.
+	spans      map[string]struct{}
+	events     map[string]struct{}
+	attributes attributeMap
+}
+
+func (c eventsChange) applies(spanName, eventName string) bool {
+	return matches(c.spans, spanName) && matches(c.events, eventName)
+}
+
+type metricsChange struct {
+	names map[string]string
+
+	// metrics the attribute changes apply to. All if empty.
+	metrics    map[string]struct{}
+	attributes attributeMap
+
+	split *split
+}
+
+func (c metricsChange) applies(metricName string) bool {
+	return matches(c.metrics, metricName)
+}
+
+// split moves the data points of a metric with different values of an
+// attribute to different metrics. When reverted, it merges the metrics back
+// into one, adding the attribute.
+type split struct {
+	reverse bool
+
+	metric    string
+	attribute attribute.Key
+	// metrics maps new metric names to the attribute value they are created
+	// from.
+	metrics map[string]interface{}
+}
+
+func newSplit(s *ast.SplitMetric) *split {
+	out := &split{
+		metric:    string(s.ApplyToMetric),
+		attribute: attribute.Key(s.ByAttribute),
+		metrics:   make(map[string]interface{}, len(s.MetricsFromAttributes)),
+	}
+	for k, v := range s.MetricsFromAttributes {
+		out.metrics[string(k)] = v
+	}
+	return out
+}
+
+func (s *split) invert() *split {
+	out := *s
+	out.reverse = !s.reverse
+	return &out
+}
+
+func (s *split) apply(name string, attrs []attribute.KeyValue) (string, []attribute.KeyValue) {
+	if s.reverse {
+		v, ok := s.metrics[name]
+		if !ok {
+			return name, attrs
+		}
+		attrs = removeKey(attrs, s.attribute)
+		return s.metric, append(attrs, attributeFromValue(s.attribute, v))
+	}
+
+	if name != s.metric {
+		return name, attrs
+	}
+	for _, kv := range attrs {
+		if kv.Key != s.attribute {
+			continue
+		}
+		for n, v := range s.metrics {
+			if fmt.Sprint(v) == kv.Value.Emit() {
+				return n, removeKey(attrs, s.attribute)
+			}
+		}
+	}
+	return name, attrs
+}
+
+func attributeFromValue(k attribute.Key, v interface{}) attribute.KeyValue {
+	switch val := v.(type) {
+	case bool:
+		return k.Bool(val)
+	case int:
+		return k.Int(val)
+	case int64:
+		return k.Int64(val)
+	case float64:
+		return k.Float64(val)
+	case string:
+		return k.String(val)
+	}
+	return k.String(fmt.Sprint(v))
+}
+
+// attributeMap maps old attribute names to new ones.
+type attributeMap map[string]string
+
+func (m attributeMap) invert() attributeMap {
+	return invert(m)
+}
+
+func invert(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[v] = k
+	}
+	return out
+}
+
+func renameAll(attrs []attribute.KeyValue, maps []attributeMap) []attribute.KeyValue {
+	for _, m := range maps {
+		attrs = rename(attrs, m)
+	}
+	return attrs
+}
+
+// rename returns attrs with the attributes renamed according to m. A renamed
+// attribute replaces an attribute already using its new name.
+func rename(attrs []attribute.KeyValue, m attributeMap) []attribute.KeyValue {
+	if len(m) == 0 || len(attrs) == 0 {
+		return attrs
+	}
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	renamed := make([]bool, 0, len(attrs))
+	index := make(map[attribute.Key]int, len(attrs))
+	for _, kv := range attrs {
+		n, ok := m[string(kv.Key)]
+		if ok {
+			kv.Key = attribute.Key(n)
+		}
+		if i, dup := index[kv.Key]; dup {
+			if ok || !renamed[i] {
+				out[i], renamed[i] = kv, ok
+			}
+			continue
+		}
+		index[kv.Key] = len(out)
+		out = append(out, kv)
+		renamed = append(renamed, ok)
+	}
+	return out
+}
+
+func removeKey(attrs []attribute.KeyValue, key attribute.Key) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if kv.Key != key {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+func matches(set map[string]struct{}, name string) bool {
+	if len(set) == 0 {
+		return true
+	}
+	_, ok := set[name]
+	return ok
+}
+
+func nameSet[T ~string](names []T) map[string]struct{} {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[string(n)] = struct{}{}
+	}
+	return set
+}
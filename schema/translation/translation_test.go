@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	schema "github.com/middleware-labs/otel/schema/v1.1"
+)
+
+const (
+	v100 = "https://example.com/schemas/1.0.0"
+	v110 = "https://example.com/schemas/1.1.0"
+	v120 = "https://example.com/schemas/1.2.0"
+)
+
+func newTestTranslator(t *testing.T) *Translator {
+	t.Helper()
+	s, err := schema.ParseFile("testdata/schema.yaml")
+	require.NoError(t, err)
+	tr, err := NewTranslator(s)
+	require.NoError(t, err)
+	return tr
+}
+
+func TestTranslationErrors(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	_, err := tr.Translation("https://example.com/schemas/0.9.0", v120)
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+
+	_, err = tr.Translation("https://other.com/schemas/1.0.0", v120)
+	assert.ErrorIs(t, err, ErrSchemaFamily)
+
+	_, err = tr.Translation("https://example.com/schemas/latest", v120)
+	assert.Error(t, err)
+}
+
+func TestTranslationIdentity(t *testing.T) {
+	tr := newTestTranslator(t)
+	tl, err := tr.Translation(v120, v120)
+	require.NoError(t, err)
+	assert.True(t, tl.IsIdentity())
+
+	attrs := []attribute.KeyValue{attribute.String("net.peer.name", "a")}
+	assert.Equal(t, attrs, tl.Span("span", attrs))
+}
+
+func TestTranslationResource(t *testing.T) {
+	tr := newTestTranslator(t)
+	attrs := []attribute.KeyValue{
+		attribute.String("telemetry.auto.version", "1"),
+		attribute.String("net.peer.name", "host"),
+	}
+
+	up, err := tr.Translation(v100, v120)
+	require.NoError(t, err)
+	upgraded := up.Resource(attrs)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("telemetry.auto_instr.version", "1"),
+		attribute.String("server.address", "host"),
+	}, upgraded)
+
+	down, err := tr.Translation(v120, v100)
+	require.NoError(t, err)
+	assert.Equal(t, attrs, down.Resource(upgraded))
+
+	// Partial upgrade only applies the changes of 1.1.0.
+	partial, err := tr.Translation(v100, v110)
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("telemetry.auto_instr.version", "1"),
+		attribute.String("net.peer.name", "host"),
+	}, partial.Resource(attrs))
+}
+
+func TestTranslationSpan(t *testing.T) {
+	tr := newTestTranslator(t)
+	up, err := tr.Translation(v100, v120)
+	require.NoError(t, err)
+
+	attrs := []attribute.KeyValue{attribute.String("http.method", "GET")}
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.request.method", "GET"),
+	}, up.Span("HTTP GET", attrs))
+	assert.Equal(t, attrs, up.Span("HTTP POST", attrs), "apply_to_spans")
+}
+
+func TestTranslationEvent(t *testing.T) {
+	tr := newTestTranslator(t)
+	up, err := tr.Translation(v100, v120)
+	require.NoError(t, err)
+
+	name, attrs := up.Event("span", "exception.stacktrace", []attribute.KeyValue{
+		attribute.String("exception.msg", "boom"),
+	})
+	assert.Equal(t, "exception.stack_trace", name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exception.message", "boom")}, attrs)
+
+	name, attrs = up.Event("span", "other", []attribute.KeyValue{
+		attribute.String("exception.msg", "boom"),
+	})
+	assert.Equal(t, "other", name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exception.msg", "boom")}, attrs, "apply_to_events")
+
+	down, err := tr.Translation(v120, v100)
+	require.NoError(t, err)
+	name, attrs = down.Event("span", "exception.stack_trace", []attribute.KeyValue{
+		attribute.String("exception.message", "boom"),
+	})
+	assert.Equal(t, "exception.stacktrace", name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exception.msg", "boom")}, attrs)
+}
+
+func TestTranslationMetric(t *testing.T) {
+	tr := newTestTranslator(t)
+	up, err := tr.Translation(v100, v120)
+	require.NoError(t, err)
+	down, err := tr.Translation(v120, v100)
+	require.NoError(t, err)
+
+	name, attrs := up.Metric("process.runtime.go.mem", nil)
+	assert.Equal(t, "process.runtime.go.memory", name)
+	assert.Empty(t, attrs)
+
+	name, attrs = up.Metric("http.server.duration", []attribute.KeyValue{attribute.Int("http.status_code", 200)})
+	assert.Equal(t, "http.server.duration", name)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("http.response.status_code", 200)}, attrs)
+
+	name, attrs = up.Metric("system.paging.operations", []attribute.KeyValue{
+		attribute.String("direction", "in"),
+		attribute.String("type", "major"),
+	})
+	assert.Equal(t, "system.paging.operations.in", name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("type", "major")}, attrs)
+
+	name, attrs = down.Metric("system.paging.operations.out", []attribute.KeyValue{
+		attribute.String("type", "major"),
+	})
+	assert.Equal(t, "system.paging.operations", name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("type", "major"),
+		attribute.String("direction", "out"),
+	}, attrs)
+}
+
+func TestRenameConflict(t *testing.T) {
+	got := rename([]attribute.KeyValue{
+		attribute.String("new", "existing"),
+		attribute.String("old", "renamed"),
+	}, attributeMap{"old": "new"})
+	assert.Equal(t, []attribute.KeyValue{attribute.String("new", "renamed")}, got)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/sdk/resource"
 )
 
@@ -27,6 +28,14 @@ type config struct {
 	res     *resource.Resource
 	readers []Reader
 	views   []View
+
+	// resProvider provides the resource if it can be updated. It takes
+	// precedence over res.
+	resProvider *resource.Provider
+
+	// baggageFilter selects the baggage members added as attributes to
+	// synchronous measurements. If nil, no baggage members are added.
+	baggageFilter func(baggage.Member) bool
 }
 
 // readerSignals returns a force-flush and shutdown function for a
@@ -117,6 +126,27 @@ func WithResource(res *resource.Resource) Option {
 	})
 }
 
+// WithResourceProvider associates the Resource provided by p with a
+// MeterProvider. Unlike with WithResource, the Resource can be updated after
+// the MeterProvider is created, see resource.Provider. Metrics collected
+// after an update are associated with the updated Resource.
+//
+// This option takes precedence over WithResource.
+func WithResourceProvider(p *resource.Provider) Option {
+	return optionFunc(func(conf config) config {
+		conf.resProvider = p
+		return conf
+	})
+}
+
+// resourceProvider returns the provider of the configured resource.
+func (c config) resourceProvider() *resource.Provider {
+	if c.resProvider != nil {
+		return c.resProvider
+	}
+	return resource.NewProvider(c.res)
+}
+
 // WithReader associates Reader r with a MeterProvider.
 //
 // By default, if this option is not used, the MeterProvider will perform no
@@ -144,3 +174,30 @@ func WithView(views ...View) Option {
 		return cfg
 	})
 }
+
+// WithBaggageAttributes configures a MeterProvider to add the members of the
+// baggage contained in the context of a synchronous measurement as
+// attributes of the measurement. The member key is used as the attribute key
+// and the member value as the string attribute value. Attributes passed with
+// the measurement take precedence over baggage members with the same key.
+//
+// Only members filter returns true for are added. The filter should only
+// accept a known set of low cardinality members, each distinct member value
+// creates a new attribute set for every instrument. For example, to only add
+// the "tenant" member:
+//
+//	WithBaggageAttributes(func(m baggage.Member) bool {
+//		return m.Key() == "tenant"
+//	})
+//
+// If filter is nil, no member is added. Use a View AttributeFilter to limit
+// the baggage attributes recorded for specific instruments.
+//
+// By default, if this option is not used, baggage is not added to
+// measurements.
+func WithBaggageAttributes(filter func(baggage.Member) bool) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.baggageFilter = filter
+		return cfg
+	})
+}
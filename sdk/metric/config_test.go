@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/middleware-labs/otel/attribute"
 	"github.com/middleware-labs/otel/sdk/metric/aggregation"
 	"github.com/middleware-labs/otel/sdk/metric/metricdata"
 	"github.com/middleware-labs/otel/sdk/resource"
@@ -125,6 +126,30 @@ func TestWithResource(t *testing.T) {
 	assert.Same(t, res, c.res)
 }
 
+func TestWithResourceProvider(t *testing.T) {
+	p := resource.NewProvider(resource.NewSchemaless())
+	c := newConfig([]Option{WithResourceProvider(p), WithResource(resource.Empty())})
+	assert.Same(t, p, c.resourceProvider())
+
+	c = newConfig([]Option{WithResource(resource.Empty())})
+	assert.Same(t, resource.Empty(), c.resourceProvider().Resource())
+}
+
+func TestMeterProviderResourceUpdate(t *testing.T) {
+	p := resource.NewProvider(resource.NewSchemaless(attribute.String("k", "v")))
+	r := NewManualReader()
+	_ = NewMeterProvider(WithReader(r), WithResourceProvider(p))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(context.Background(), &rm))
+	assert.Equal(t, p.Resource(), rm.Resource)
+
+	require.NoError(t, p.Merge(resource.NewSchemaless(attribute.String("late", "v"))))
+	require.NoError(t, r.Collect(context.Background(), &rm))
+	want := resource.NewSchemaless(attribute.String("k", "v"), attribute.String("late", "v"))
+	assert.True(t, want.Equal(rm.Resource), "resource not updated: %s", rm.Resource)
+}
+
 func TestWithReader(t *testing.T) {
 	r := &reader{}
 	c := newConfig([]Option{WithReader(r)})
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/metric/embedded"
 	"github.com/middleware-labs/otel/metric/instrument"
 	"github.com/middleware-labs/otel/sdk/instrumentation"
@@ -172,6 +173,9 @@ type streamID struct {
 
 type instrumentImpl[N int64 | float64] struct {
 	aggregators []internal.Aggregator[N]
+	// baggageFilter selects the baggage members added as attributes to
+	// measurements. If nil, no baggage members are added.
+	baggageFilter func(baggage.Member) bool
 
 	embedded.Float64Counter
 	embedded.Float64UpDownCounter
@@ -200,6 +204,9 @@ func (i *instrumentImpl[N]) aggregate(ctx context.Context, val N, attrs []attrib
 	if err := ctx.Err(); err != nil {
 		return
 	}
+	if i.baggageFilter != nil {
+		attrs = baggageAttributes(ctx, i.baggageFilter, attrs)
+	}
 	// Do not use single attribute.Sortable and attribute.NewSetWithSortable,
 	// this method needs to be concurrent safe. Let the sync.Pool in the
 	// attribute package handle allocations of the Sortable.
@@ -209,6 +216,24 @@ func (i *instrumentImpl[N]) aggregate(ctx context.Context, val N, attrs []attrib
 	}
 }
 
+// baggageAttributes returns attrs with the baggage members of ctx selected by
+// filter prepended. Prepending ensures attrs take precedence when the
+// attribute set is de-duplicated (the last value of a key is kept).
+func baggageAttributes(ctx context.Context, filter func(baggage.Member) bool, attrs []attribute.KeyValue) []attribute.KeyValue {
+	members := baggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return attrs
+	}
+
+	out := make([]attribute.KeyValue, 0, len(members)+len(attrs))
+	for _, m := range members {
+		if filter(m) {
+			out = append(out, attribute.String(m.Key(), m.Value()))
+		}
+	}
+	return append(out, attrs...)
+}
+
 // observablID is a comparable unique identifier of an observable.
 type observablID[N int64 | float64] struct {
 	name        string
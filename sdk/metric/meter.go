@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/internal/global"
 	"github.com/middleware-labs/otel/metric"
 	"github.com/middleware-labs/otel/metric/embedded"
@@ -42,7 +43,7 @@ type meter struct {
 	float64IP *instProvider[float64]
 }
 
-func newMeter(s instrumentation.Scope, p pipelines) *meter {
+func newMeter(s instrumentation.Scope, p pipelines, bf func(baggage.Member) bool) *meter {
 	// viewCache ensures instrument conflicts, including number conflicts, this
 	// meter is asked to create are logged to the user.
 	var viewCache cache[string, streamID]
@@ -50,8 +51,8 @@ func newMeter(s instrumentation.Scope, p pipelines) *meter {
 	return &meter{
 		scope:     s,
 		pipes:     p,
-		int64IP:   newInstProvider[int64](s, p, &viewCache),
-		float64IP: newInstProvider[float64](s, p, &viewCache),
+		int64IP:   newInstProvider[int64](s, p, &viewCache, bf),
+		float64IP: newInstProvider[float64](s, p, &viewCache, bf),
 	}
 }
 
@@ -369,13 +370,14 @@ func (noopRegister) Unregister() error {
 
 // instProvider provides all OpenTelemetry instruments.
 type instProvider[N int64 | float64] struct {
-	scope   instrumentation.Scope
-	pipes   pipelines
-	resolve resolver[N]
+	scope         instrumentation.Scope
+	pipes         pipelines
+	resolve       resolver[N]
+	baggageFilter func(baggage.Member) bool
 }
 
-func newInstProvider[N int64 | float64](s instrumentation.Scope, p pipelines, c *cache[string, streamID]) *instProvider[N] {
-	return &instProvider[N]{scope: s, pipes: p, resolve: newResolver[N](p, c)}
+func newInstProvider[N int64 | float64](s instrumentation.Scope, p pipelines, c *cache[string, streamID], bf func(baggage.Member) bool) *instProvider[N] {
+	return &instProvider[N]{scope: s, pipes: p, resolve: newResolver[N](p, c), baggageFilter: bf}
 }
 
 func (p *instProvider[N]) aggs(kind InstrumentKind, name, desc, u string) ([]internal.Aggregator[N], error) {
@@ -392,7 +394,7 @@ func (p *instProvider[N]) aggs(kind InstrumentKind, name, desc, u string) ([]int
 // lookup returns the resolved instrumentImpl.
 func (p *instProvider[N]) lookup(kind InstrumentKind, name, desc, u string) (*instrumentImpl[N], error) {
 	aggs, err := p.aggs(kind, name, desc, u)
-	return &instrumentImpl[N]{aggregators: aggs}, err
+	return &instrumentImpl[N]{aggregators: aggs, baggageFilter: p.baggageFilter}, err
 }
 
 type int64ObservProvider struct{ *instProvider[int64] }
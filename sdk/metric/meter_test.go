@@ -28,6 +28,7 @@ import (
 
 	"github.com/middleware-labs/otel"
 	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/metric"
 	"github.com/middleware-labs/otel/metric/instrument"
 	"github.com/middleware-labs/otel/sdk/instrumentation"
@@ -1538,3 +1539,82 @@ func BenchmarkInstrumentCreation(b *testing.B) {
 		sfHistogram, _ = meter.Float64Histogram("sync.float64.histogram")
 	}
 }
+
+func TestBaggageAttributes(t *testing.T) {
+	user, err := baggage.NewMember("user", "alice")
+	require.NoError(t, err)
+	tenant, err := baggage.NewMember("tenant", "t1")
+	require.NoError(t, err)
+	bag, err := baggage.New(user, tenant)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	rdr := NewManualReader()
+	mp := NewMeterProvider(
+		WithReader(rdr),
+		WithBaggageAttributes(func(m baggage.Member) bool { return m.Key() != "user" }),
+	)
+	ctr, err := mp.Meter("TestBaggageAttributes").Int64Counter("ctr")
+	require.NoError(t, err)
+
+	ctr.Add(ctx, 1)
+	ctr.Add(ctx, 2, attribute.String("tenant", "override"))
+	ctr.Add(context.Background(), 3)
+
+	var got metricdata.ResourceMetrics
+	require.NoError(t, rdr.Collect(context.Background(), &got))
+	want := metricdata.ResourceMetrics{
+		Resource: resource.Default(),
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Scope: instrumentation.Scope{Name: "TestBaggageAttributes"},
+				Metrics: []metricdata.Metrics{
+					{
+						Name: "ctr",
+						Data: metricdata.Sum[int64]{
+							Temporality: metricdata.CumulativeTemporality,
+							IsMonotonic: true,
+							DataPoints: []metricdata.DataPoint[int64]{
+								{
+									Attributes: attribute.NewSet(attribute.String("tenant", "t1")),
+									Value:      1,
+								},
+								{
+									Attributes: attribute.NewSet(attribute.String("tenant", "override")),
+									Value:      2,
+								},
+								{
+									Value: 3,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	metricdatatest.AssertEqual(t, want, got, metricdatatest.IgnoreTimestamp())
+}
+
+func TestBaggageAttributesNilFilter(t *testing.T) {
+	user, err := baggage.NewMember("user", "alice")
+	require.NoError(t, err)
+	bag, err := baggage.New(user)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	rdr := NewManualReader()
+	mp := NewMeterProvider(WithReader(rdr), WithBaggageAttributes(nil))
+	ctr, err := mp.Meter("TestBaggageAttributesNilFilter").Int64Counter("ctr")
+	require.NoError(t, err)
+	ctr.Add(ctx, 1)
+
+	var got metricdata.ResourceMetrics
+	require.NoError(t, rdr.Collect(context.Background(), &got))
+	require.Len(t, got.ScopeMetrics, 1)
+	require.Len(t, got.ScopeMetrics[0].Metrics, 1)
+	sum, ok := got.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, 0, sum.DataPoints[0].Attributes.Len())
+}
@@ -52,7 +52,7 @@ type Metrics struct {
 }
 
 // Aggregation is the store of data reported by an Instrument.
-// It will be one of: Gauge, Sum, Histogram.
+// It will be one of: Gauge, Sum, Histogram, Summary.
 type Aggregation interface {
 	privateAggregation()
 }
@@ -137,6 +137,53 @@ type HistogramDataPoint[N int64 | float64] struct {
 	Exemplars []Exemplar[N] `json:",omitempty"`
 }
 
+// Summary metric data are used to convey quantile summaries, a Prometheus
+// (see: https://prometheus.io/docs/concepts/metric_types/#summary) and
+// OpenCensus data type.
+//
+// Summaries cannot be produced by OpenTelemetry instruments. They are only
+// used by bridges from other instrumentation libraries.
+type Summary struct {
+	// DataPoints are the individual aggregated measurements with unique
+	// Attributes.
+	DataPoints []SummaryDataPoint
+}
+
+func (Summary) privateAggregation() {}
+
+// SummaryDataPoint is a single data point in a timeseries that describes the
+// time-varying values of a Summary metric.
+type SummaryDataPoint struct {
+	// Attributes is the set of key value pairs that uniquely identify the
+	// timeseries.
+	Attributes attribute.Set
+	// StartTime is when the timeseries was started.
+	StartTime time.Time
+	// Time is the time when the timeseries was recorded.
+	Time time.Time
+
+	// Count is the number of updates this summary has been calculated with.
+	Count uint64
+	// Sum is the sum of the values recorded.
+	Sum float64
+
+	// QuantileValues are the values of the summary at the quantiles it
+	// tracks. (optional)
+	QuantileValues []QuantileValue
+}
+
+// QuantileValue is the value at a given quantile of a summary.
+type QuantileValue struct {
+	// Quantile is the quantile of this value.
+	//
+	// Must be in the interval [0.0, 1.0].
+	Quantile float64
+	// Value is the value at the given quantile of a summary.
+	//
+	// Quantile values must NOT be negative.
+	Value float64
+}
+
 // Extrema is the minimum or maximum value of a dataset.
 type Extrema[N int64 | float64] struct {
 	value N
@@ -41,6 +41,9 @@ type Datatypes interface {
 		metricdata.ScopeMetrics |
 		metricdata.Sum[float64] |
 		metricdata.Sum[int64] |
+		metricdata.Summary |
+		metricdata.SummaryDataPoint |
+		metricdata.QuantileValue |
 		metricdata.Exemplar[float64] |
 		metricdata.Exemplar[int64]
 
@@ -134,6 +137,12 @@ func AssertEqual[T Datatypes](t *testing.T, expected, actual T, opts ...Option)
 		r = equalSums(e, aIface.(metricdata.Sum[int64]), cfg)
 	case metricdata.Sum[float64]:
 		r = equalSums(e, aIface.(metricdata.Sum[float64]), cfg)
+	case metricdata.Summary:
+		r = equalSummary(e, aIface.(metricdata.Summary), cfg)
+	case metricdata.SummaryDataPoint:
+		r = equalSummaryDataPoint(e, aIface.(metricdata.SummaryDataPoint), cfg)
+	case metricdata.QuantileValue:
+		r = equalQuantileValue(e, aIface.(metricdata.QuantileValue), cfg)
 	default:
 		// We control all types passed to this, panic to signal developers
 		// early they changed things in an incompatible way.
@@ -192,6 +201,12 @@ func AssertHasAttributes[T Datatypes](t *testing.T, actual T, attrs ...attribute
 		reasons = hasAttributesHistogram(e, attrs...)
 	case metricdata.Histogram[float64]:
 		reasons = hasAttributesHistogram(e, attrs...)
+	case metricdata.Summary:
+		reasons = hasAttributesSummary(e, attrs...)
+	case metricdata.SummaryDataPoint:
+		reasons = hasAttributesSummaryDataPoint(e, attrs...)
+	case metricdata.QuantileValue:
+		// Nothing to check.
 	case metricdata.Metrics:
 		reasons = hasAttributesMetrics(e, attrs...)
 	case metricdata.ScopeMetrics:
@@ -51,6 +51,9 @@ func TestFailAssertEqual(t *testing.T) {
 	t.Run("ExemplarInt64", testFailDatatype(exemplarInt64A, exemplarInt64B))
 	t.Run("ExemplarFloat64", testFailDatatype(exemplarFloat64A, exemplarFloat64B))
 	t.Run("Extrema", testFailDatatype(minA, minB))
+	t.Run("Summary", testFailDatatype(summaryA, summaryB))
+	t.Run("SummaryDataPoint", testFailDatatype(summaryDataPointA, summaryDataPointB))
+	t.Run("QuantileValue", testFailDatatype(quantileValueA, quantileValueB))
 }
 
 func TestFailAssertAggregationsEqual(t *testing.T) {
@@ -63,6 +66,7 @@ func TestFailAssertAggregationsEqual(t *testing.T) {
 	AssertAggregationsEqual(t, gaugeFloat64A, gaugeFloat64B)
 	AssertAggregationsEqual(t, histogramInt64A, histogramInt64B)
 	AssertAggregationsEqual(t, histogramFloat64A, histogramFloat64B)
+	AssertAggregationsEqual(t, summaryA, summaryB)
 }
 
 func TestFailAssertAttribute(t *testing.T) {
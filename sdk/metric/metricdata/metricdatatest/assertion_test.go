@@ -280,6 +280,47 @@ var (
 		DataPoints:  []metricdata.HistogramDataPoint[float64]{histogramDataPointFloat64C},
 	}
 
+	quantileValueA = metricdata.QuantileValue{
+		Quantile: 0.0,
+		Value:    0.1,
+	}
+	quantileValueB = metricdata.QuantileValue{
+		Quantile: 0.1,
+		Value:    0.2,
+	}
+	summaryDataPointA = metricdata.SummaryDataPoint{
+		Attributes:     attrA,
+		StartTime:      startA,
+		Time:           endA,
+		Count:          2,
+		Sum:            3,
+		QuantileValues: []metricdata.QuantileValue{quantileValueA},
+	}
+	summaryDataPointB = metricdata.SummaryDataPoint{
+		Attributes:     attrB,
+		StartTime:      startB,
+		Time:           endB,
+		Count:          3,
+		QuantileValues: []metricdata.QuantileValue{quantileValueB},
+	}
+	summaryDataPointC = metricdata.SummaryDataPoint{
+		Attributes:     attrA,
+		StartTime:      startB,
+		Time:           endB,
+		Count:          2,
+		Sum:            3,
+		QuantileValues: []metricdata.QuantileValue{quantileValueA},
+	}
+	summaryA = metricdata.Summary{
+		DataPoints: []metricdata.SummaryDataPoint{summaryDataPointA},
+	}
+	summaryB = metricdata.Summary{
+		DataPoints: []metricdata.SummaryDataPoint{summaryDataPointB},
+	}
+	summaryC = metricdata.Summary{
+		DataPoints: []metricdata.SummaryDataPoint{summaryDataPointC},
+	}
+
 	metricsA = metricdata.Metrics{
 		Name:        "A",
 		Description: "A desc",
@@ -378,6 +419,9 @@ func TestAssertEqual(t *testing.T) {
 	t.Run("ExtremaFloat64", testDatatype(minFloat64A, minFloat64B, equalExtrema[float64]))
 	t.Run("ExemplarInt64", testDatatype(exemplarInt64A, exemplarInt64B, equalExemplars[int64]))
 	t.Run("ExemplarFloat64", testDatatype(exemplarFloat64A, exemplarFloat64B, equalExemplars[float64]))
+	t.Run("Summary", testDatatype(summaryA, summaryB, equalSummary))
+	t.Run("SummaryDataPoint", testDatatype(summaryDataPointA, summaryDataPointB, equalSummaryDataPoint))
+	t.Run("QuantileValue", testDatatype(quantileValueA, quantileValueB, equalQuantileValue))
 }
 
 func TestAssertEqualIgnoreTime(t *testing.T) {
@@ -398,6 +442,8 @@ func TestAssertEqualIgnoreTime(t *testing.T) {
 	t.Run("ExtremaFloat64", testDatatypeIgnoreTime(minFloat64A, minFloat64C, equalExtrema[float64]))
 	t.Run("ExemplarInt64", testDatatypeIgnoreTime(exemplarInt64A, exemplarInt64C, equalExemplars[int64]))
 	t.Run("ExemplarFloat64", testDatatypeIgnoreTime(exemplarFloat64A, exemplarFloat64C, equalExemplars[float64]))
+	t.Run("Summary", testDatatypeIgnoreTime(summaryA, summaryC, equalSummary))
+	t.Run("SummaryDataPoint", testDatatypeIgnoreTime(summaryDataPointA, summaryDataPointC, equalSummaryDataPoint))
 }
 
 func TestAssertEqualIgnoreExemplars(t *testing.T) {
@@ -430,6 +476,7 @@ func TestAssertAggregationsEqual(t *testing.T) {
 	AssertAggregationsEqual(t, gaugeFloat64A, gaugeFloat64A)
 	AssertAggregationsEqual(t, histogramInt64A, histogramInt64A)
 	AssertAggregationsEqual(t, histogramFloat64A, histogramFloat64A)
+	AssertAggregationsEqual(t, summaryA, summaryA)
 
 	r := equalAggregations(sumInt64A, nil, config{})
 	assert.Len(t, r, 1, "should return nil comparison mismatch only")
@@ -475,6 +522,12 @@ func TestAssertAggregationsEqual(t *testing.T) {
 
 	r = equalAggregations(histogramFloat64A, histogramFloat64C, config{ignoreTimestamp: true})
 	assert.Len(t, r, 0, "histograms should be equal: %v", r)
+
+	r = equalAggregations(summaryA, summaryB, config{})
+	assert.Greaterf(t, len(r), 0, "summaries should not be equal: %v == %v", summaryA, summaryB)
+
+	r = equalAggregations(summaryA, summaryC, config{ignoreTimestamp: true})
+	assert.Len(t, r, 0, "summaries should be equal: %v", r)
 }
 
 func TestAssertAttributes(t *testing.T) {
@@ -491,6 +544,9 @@ func TestAssertAttributes(t *testing.T) {
 	AssertHasAttributes(t, histogramDataPointFloat64A, attribute.Bool("A", true))
 	AssertHasAttributes(t, histogramInt64A, attribute.Bool("A", true))
 	AssertHasAttributes(t, histogramFloat64A, attribute.Bool("A", true))
+	AssertHasAttributes(t, summaryDataPointA, attribute.Bool("A", true))
+	AssertHasAttributes(t, summaryA, attribute.Bool("A", true))
+	AssertHasAttributes(t, quantileValueA, attribute.Bool("A", true)) // No-op, always pass.
 	AssertHasAttributes(t, metricsA, attribute.Bool("A", true))
 	AssertHasAttributes(t, scopeMetricsA, attribute.Bool("A", true))
 	AssertHasAttributes(t, resourceMetricsA, attribute.Bool("A", true))
@@ -533,6 +589,13 @@ func TestAssertAttributes(t *testing.T) {
 	assert.Greater(t, len(r), 0, "histogramIntA does not have Attribute B")
 	r = hasAttributesAggregation(histogramFloat64A, attribute.Bool("B", true))
 	assert.Greater(t, len(r), 0, "histogramFloatA does not have Attribute B")
+
+	r = hasAttributesAggregation(summaryA, attribute.Bool("A", true))
+	assert.Equal(t, len(r), 0, "summaryA has A=True")
+	r = hasAttributesAggregation(summaryA, attribute.Bool("A", false))
+	assert.Greater(t, len(r), 0, "summaryA does not have A=False")
+	r = hasAttributesAggregation(summaryA, attribute.Bool("B", true))
+	assert.Greater(t, len(r), 0, "summaryA does not have Attribute B")
 }
 
 func TestAssertAttributesFail(t *testing.T) {
@@ -549,6 +612,8 @@ func TestAssertAttributesFail(t *testing.T) {
 	assert.False(t, AssertHasAttributes(fakeT, histogramDataPointFloat64A, attribute.Bool("B", true)))
 	assert.False(t, AssertHasAttributes(fakeT, histogramInt64A, attribute.Bool("A", false)))
 	assert.False(t, AssertHasAttributes(fakeT, histogramFloat64A, attribute.Bool("B", true)))
+	assert.False(t, AssertHasAttributes(fakeT, summaryDataPointA, attribute.Bool("A", false)))
+	assert.False(t, AssertHasAttributes(fakeT, summaryA, attribute.Bool("B", true)))
 	assert.False(t, AssertHasAttributes(fakeT, metricsA, attribute.Bool("A", false)))
 	assert.False(t, AssertHasAttributes(fakeT, metricsA, attribute.Bool("B", true)))
 	assert.False(t, AssertHasAttributes(fakeT, resourceMetricsA, attribute.Bool("A", false)))
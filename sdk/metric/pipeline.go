@@ -52,9 +52,9 @@ type instrumentSync struct {
 	aggregator  aggregator
 }
 
-func newPipeline(res *resource.Resource, reader Reader, views []View) *pipeline {
+func newPipeline(res *resource.Provider, reader Reader, views []View) *pipeline {
 	if res == nil {
-		res = resource.Empty()
+		res = resource.NewProvider(nil)
 	}
 	return &pipeline{
 		resource:     res,
@@ -70,7 +70,7 @@ func newPipeline(res *resource.Resource, reader Reader, views []View) *pipeline
 // As instruments are created the instrument should be checked if it exists in the
 // views of a the Reader, and if so each aggregator should be added to the pipeline.
 type pipeline struct {
-	resource *resource.Resource
+	resource *resource.Provider
 
 	reader Reader
 	views  []View
@@ -152,7 +152,10 @@ func (p *pipeline) produce(ctx context.Context, rm *metricdata.ResourceMetrics)
 		}
 	}
 
-	rm.Resource = p.resource
+	rm.Resource = nil
+	if p.resource != nil {
+		rm.Resource = p.resource.Resource()
+	}
 	rm.ScopeMetrics = internal.ReuseSlice(rm.ScopeMetrics, len(p.aggregations))
 
 	i := 0
@@ -483,7 +486,7 @@ func isAggregatorCompatible(kind InstrumentKind, agg aggregation.Aggregation) er
 // measurement.
 type pipelines []*pipeline
 
-func newPipelines(res *resource.Resource, readers []Reader, views []View) pipelines {
+func newPipelines(res *resource.Provider, readers []Reader, views []View) pipelines {
 	pipes := make([]*pipeline, 0, len(readers))
 	for _, r := range readers {
 		p := &pipeline{
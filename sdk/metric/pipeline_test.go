@@ -91,7 +91,7 @@ func TestNewPipeline(t *testing.T) {
 
 func TestPipelineUsesResource(t *testing.T) {
 	res := resource.NewWithAttributes("noSchema", attribute.String("test", "resource"))
-	pipe := newPipeline(res, nil, nil)
+	pipe := newPipeline(resource.NewProvider(res), nil, nil)
 
 	output := metricdata.ResourceMetrics{}
 	err := pipe.produce(context.Background(), &output)
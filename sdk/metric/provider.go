@@ -17,6 +17,7 @@ package metric // import "github.com/middleware-labs/otel/sdk/metric"
 import (
 	"context"
 
+	"github.com/middleware-labs/otel/baggage"
 	"github.com/middleware-labs/otel/metric"
 	"github.com/middleware-labs/otel/metric/embedded"
 	"github.com/middleware-labs/otel/sdk/instrumentation"
@@ -32,6 +33,8 @@ type MeterProvider struct {
 	pipes  pipelines
 	meters cache[instrumentation.Scope, *meter]
 
+	baggageFilter func(baggage.Member) bool
+
 	forceFlush, shutdown func(context.Context) error
 }
 
@@ -48,9 +51,10 @@ func NewMeterProvider(options ...Option) *MeterProvider {
 	conf := newConfig(options)
 	flush, sdown := conf.readerSignals()
 	return &MeterProvider{
-		pipes:      newPipelines(conf.res, conf.readers, conf.views),
-		forceFlush: flush,
-		shutdown:   sdown,
+		pipes:         newPipelines(conf.resourceProvider(), conf.readers, conf.views),
+		baggageFilter: conf.baggageFilter,
+		forceFlush:    flush,
+		shutdown:      sdown,
 	}
 }
 
@@ -75,7 +79,7 @@ func (mp *MeterProvider) Meter(name string, options ...metric.MeterOption) metri
 		SchemaURL: c.SchemaURL(),
 	}
 	return mp.meters.Lookup(s, func() *meter {
-		return newMeter(s, mp.pipes)
+		return newMeter(s, mp.pipes, mp.baggageFilter)
 	})
 }
 
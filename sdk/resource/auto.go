@@ -47,12 +47,13 @@ type Detector interface {
 // It returns the merged error too.
 func Detect(ctx context.Context, detectors ...Detector) (*Resource, error) {
 	r := new(Resource)
-	return r, detect(ctx, r, detectors)
+	return r, detect(ctx, r, detectors, nil)
 }
 
-// detect runs all detectors using ctx and merges the result into res. This
+// detect runs all detectors using ctx and merges the result into res. If t is
+// not nil, it is used to merge resources with different schema URLs. This
 // assumes res is allocated and not nil, it will panic otherwise.
-func detect(ctx context.Context, res *Resource, detectors []Detector) error {
+func detect(ctx context.Context, res *Resource, detectors []Detector, t SchemaTranslator) error {
 	var (
 		r    *Resource
 		errs detectErrs
@@ -70,7 +71,7 @@ func detect(ctx context.Context, res *Resource, detectors []Detector) error {
 				continue
 			}
 		}
-		r, err = Merge(res, r)
+		r, err = MergeWithSchemas(res, r, t)
 		if err != nil {
 			errs = append(errs, err)
 		}
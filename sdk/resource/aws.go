@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+const (
+	// defaultEC2Endpoint is the base URL of the EC2 instance metadata service.
+	defaultEC2Endpoint = "http://169.254.169.254"
+	// ecsMetadataEndpointEnv is the environment variable ECS sets to the
+	// base URL of the task metadata endpoint version 4.
+	ecsMetadataEndpointEnv = "ECS_CONTAINER_METADATA_URI_V4"
+)
+
+// ec2Detector detects the attributes of the EC2 instance the process runs
+// on using the instance metadata service version 2 (IMDSv2).
+type ec2Detector struct {
+	cfg metadataConfig
+}
+
+// ec2Identity is the instance identity document of an EC2 instance.
+type ec2Identity struct {
+	AccountID        string `json:"accountId"`
+	Architecture     string `json:"architecture"`
+	AvailabilityZone string `json:"availabilityZone"`
+	ImageID          string `json:"imageId"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	Region           string `json:"region"`
+}
+
+var _ Detector = ec2Detector{}
+
+// Detect returns a Resource describing the EC2 instance. If the instance
+// metadata service is not reachable, an empty Resource is returned.
+func (d ec2Detector) Detect(ctx context.Context) (*Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.timeout)
+	defer cancel()
+
+	token, err := d.cfg.request(ctx, http.MethodPut, "/latest/api/token", http.Header{
+		"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"60"},
+	})
+	if err != nil {
+		// Not running on EC2.
+		return Empty(), nil
+	}
+	header := http.Header{"X-Aws-Ec2-Metadata-Token": {string(token)}}
+
+	doc, err := d.cfg.request(ctx, http.MethodGet, "/latest/dynamic/instance-identity/document", header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPartialResource, err)
+	}
+	var id ec2Identity
+	if err := json.Unmarshal(doc, &id); err != nil {
+		return nil, fmt.Errorf("%w: invalid instance identity document: %v", ErrPartialResource, err)
+	}
+
+	m := metadataAttrs{}
+	m.attrs = append(m.attrs, semconv.CloudProviderAWS, semconv.CloudPlatformAWSEC2)
+	m.add(semconv.CloudAccountID, id.AccountID)
+	m.add(semconv.CloudRegion, id.Region)
+	m.add(semconv.CloudAvailabilityZone, id.AvailabilityZone)
+	m.add(semconv.HostID, id.InstanceID)
+	m.add(semconv.HostType, id.InstanceType)
+	m.add(semconv.HostImageID, id.ImageID)
+	switch id.Architecture {
+	case "x86_64":
+		m.attrs = append(m.attrs, semconv.HostArchAMD64)
+	case "arm64":
+		m.attrs = append(m.attrs, semconv.HostArchARM64)
+	case "i386":
+		m.attrs = append(m.attrs, semconv.HostArchX86)
+	}
+
+	hostname, err := d.cfg.request(ctx, http.MethodGet, "/latest/meta-data/hostname", header)
+	if err != nil {
+		m.error(err)
+	} else {
+		m.add(semconv.HostName, strings.TrimSpace(string(hostname)))
+	}
+
+	return m.resource(semconv.SchemaURL)
+}
+
+// ecsDetector detects the attributes of the ECS task the process runs in
+// using the task metadata endpoint version 4.
+type ecsDetector struct {
+	cfg metadataConfig
+}
+
+// ecsContainer is the container metadata of the ECS task metadata endpoint.
+type ecsContainer struct {
+	DockerID     string            `json:"DockerId"`
+	Name         string            `json:"Name"`
+	ContainerARN string            `json:"ContainerARN"`
+	LogDriver    string            `json:"LogDriver"`
+	LogOptions   map[string]string `json:"LogOptions"`
+}
+
+// ecsTask is the task metadata of the ECS task metadata endpoint.
+type ecsTask struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+var _ Detector = ecsDetector{}
+
+// Detect returns a Resource describing the ECS task and container. If no
+// metadata endpoint is configured, an empty Resource is returned.
+func (d ecsDetector) Detect(ctx context.Context) (*Resource, error) {
+	cfg := d.cfg
+	if cfg.endpoint == "" {
+		cfg.endpoint = strings.TrimRight(os.Getenv(ecsMetadataEndpointEnv), "/")
+	}
+	if cfg.endpoint == "" {
+		// Not running on ECS.
+		return Empty(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	var task ecsTask
+	if err := cfg.requestJSON(ctx, "/task", &task); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPartialResource, err)
+	}
+
+	m := metadataAttrs{}
+	m.attrs = append(m.attrs, semconv.CloudProviderAWS, semconv.CloudPlatformAWSECS)
+	region, account := parseARN(task.TaskARN)
+	m.add(semconv.CloudRegion, region)
+	m.add(semconv.CloudAccountID, account)
+	m.add(semconv.CloudAvailabilityZone, task.AvailabilityZone)
+	m.add(semconv.AWSECSTaskARN, task.TaskARN)
+	m.add(semconv.AWSECSTaskFamily, task.Family)
+	m.add(semconv.AWSECSTaskRevision, task.Revision)
+	cluster := task.Cluster
+	if cluster != "" && !strings.HasPrefix(cluster, "arn:") && region != "" && account != "" {
+		cluster = fmt.Sprintf("arn:aws:ecs:%s:%s:cluster/%s", region, account, cluster)
+	}
+	m.add(semconv.AWSECSClusterARN, cluster)
+	switch strings.ToUpper(task.LaunchType) {
+	case "EC2":
+		m.attrs = append(m.attrs, semconv.AWSECSLaunchtypeEC2)
+	case "FARGATE":
+		m.attrs = append(m.attrs, semconv.AWSECSLaunchtypeFargate)
+	}
+
+	var container ecsContainer
+	if err := cfg.requestJSON(ctx, "", &container); err != nil {
+		m.error(err)
+		return m.resource(semconv.SchemaURL)
+	}
+	m.add(semconv.ContainerID, container.DockerID)
+	m.add(semconv.ContainerName, container.Name)
+	m.add(semconv.AWSECSContainerARN, container.ContainerARN)
+	if container.LogDriver == "awslogs" {
+		if group := container.LogOptions["awslogs-group"]; group != "" {
+			m.attrs = append(m.attrs, semconv.AWSLogGroupNames(group))
+		}
+		if stream := container.LogOptions["awslogs-stream"]; stream != "" {
+			m.attrs = append(m.attrs, semconv.AWSLogStreamNames(stream))
+		}
+	}
+
+	return m.resource(semconv.SchemaURL)
+}
+
+// parseARN returns the region and account ID of an AWS ARN.
+func parseARN(arn string) (region, account string) {
+	// arn:partition:service:region:account-id:resource
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", ""
+	}
+	return parts[3], parts[4]
+}
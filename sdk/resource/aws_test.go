@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+const ec2IdentityDocument = `{
+  "accountId": "123456789012",
+  "architecture": "arm64",
+  "availabilityZone": "us-west-2b",
+  "imageId": "ami-5fb8c835",
+  "instanceId": "i-1234567890abcdef0",
+  "instanceType": "t4g.micro",
+  "region": "us-west-2"
+}`
+
+// ec2Auth authorizes IMDSv2 requests: every request but the token request
+// requires the session token.
+func ec2Auth(r *http.Request) bool {
+	if r.Method == http.MethodPut {
+		return r.Header.Get("X-Aws-Ec2-Metadata-Token-Ttl-Seconds") != ""
+	}
+	return r.Header.Get("X-Aws-Ec2-Metadata-Token") == "token"
+}
+
+func TestEC2Detector(t *testing.T) {
+	srv := newMetadataServer(t, ec2Auth, map[string]string{
+		"PUT /latest/api/token":                          "token",
+		"GET /latest/dynamic/instance-identity/document": ec2IdentityDocument,
+		"GET /latest/meta-data/hostname":                 "ip-10-0-0-1.us-west-2.compute.internal\n",
+	})
+
+	d := ec2Detector{cfg: newMetadataConfig(defaultEC2Endpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+		semconv.CloudAccountID("123456789012"),
+		semconv.CloudRegion("us-west-2"),
+		semconv.CloudAvailabilityZone("us-west-2b"),
+		semconv.HostID("i-1234567890abcdef0"),
+		semconv.HostType("t4g.micro"),
+		semconv.HostImageID("ami-5fb8c835"),
+		semconv.HostArchARM64,
+		semconv.HostName("ip-10-0-0-1.us-west-2.compute.internal"),
+	), res)
+}
+
+func TestEC2DetectorPartial(t *testing.T) {
+	srv := newMetadataServer(t, ec2Auth, map[string]string{
+		"PUT /latest/api/token":                          "token",
+		"GET /latest/dynamic/instance-identity/document": ec2IdentityDocument,
+	})
+
+	d := ec2Detector{cfg: newMetadataConfig(defaultEC2Endpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	res, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+	assert.Equal(t, "i-1234567890abcdef0", attrValue(res, semconv.HostIDKey))
+	assert.Equal(t, "", attrValue(res, semconv.HostNameKey))
+}
+
+func TestEC2DetectorInvalidDocument(t *testing.T) {
+	srv := newMetadataServer(t, ec2Auth, map[string]string{
+		"PUT /latest/api/token":                          "token",
+		"GET /latest/dynamic/instance-identity/document": "<html>",
+	})
+
+	d := ec2Detector{cfg: newMetadataConfig(defaultEC2Endpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	_, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+}
+
+const (
+	ecsTaskMetadata = `{
+  "Cluster": "default",
+  "TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c",
+  "Family": "curltest",
+  "Revision": "26",
+  "AvailabilityZone": "us-west-2d",
+  "LaunchType": "FARGATE"
+}`
+	ecsContainerMetadata = `{
+  "DockerId": "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66",
+  "Name": "curl",
+  "ContainerARN": "arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9",
+  "LogDriver": "awslogs",
+  "LogOptions": {
+    "awslogs-group": "/ecs/metadata",
+    "awslogs-region": "us-west-2",
+    "awslogs-stream": "ecs/curl/158d1c8083dd49d6b527399fd6414f5c"
+  }
+}`
+)
+
+func TestECSDetector(t *testing.T) {
+	srv := newMetadataServer(t, func(*http.Request) bool { return true }, map[string]string{
+		"GET /v4/container":      ecsContainerMetadata,
+		"GET /v4/container/task": ecsTaskMetadata,
+	})
+	t.Setenv(ecsMetadataEndpointEnv, srv.URL+"/v4/container")
+
+	res, err := ecsDetector{cfg: newMetadataConfig("", nil)}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.CloudRegion("us-west-2"),
+		semconv.CloudAccountID("111122223333"),
+		semconv.CloudAvailabilityZone("us-west-2d"),
+		semconv.AWSECSTaskARN("arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c"),
+		semconv.AWSECSTaskFamily("curltest"),
+		semconv.AWSECSTaskRevision("26"),
+		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
+		semconv.AWSECSLaunchtypeFargate,
+		semconv.ContainerID("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerName("curl"),
+		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
+		semconv.AWSLogGroupNames("/ecs/metadata"),
+		semconv.AWSLogStreamNames("ecs/curl/158d1c8083dd49d6b527399fd6414f5c"),
+	), res)
+}
+
+func TestECSDetectorEndpointOption(t *testing.T) {
+	srv := newMetadataServer(t, func(*http.Request) bool { return true }, map[string]string{
+		"GET /task": ecsTaskMetadata,
+	})
+	t.Setenv(ecsMetadataEndpointEnv, "http://ignored.invalid")
+
+	d := ecsDetector{cfg: newMetadataConfig("", []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	res, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+	assert.Equal(t, "curltest", attrValue(res, semconv.AWSECSTaskFamilyKey))
+}
+
+func TestECSDetectorNotOnECS(t *testing.T) {
+	t.Setenv(ecsMetadataEndpointEnv, "")
+
+	res, err := ecsDetector{cfg: newMetadataConfig("", nil)}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Empty(), res)
+}
+
+func TestParseARN(t *testing.T) {
+	region, account := parseARN("arn:aws:ecs:eu-west-1:123456789012:task/cluster/id")
+	assert.Equal(t, "eu-west-1", region)
+	assert.Equal(t, "123456789012", account)
+
+	region, account = parseARN("default")
+	assert.Empty(t, region)
+	assert.Empty(t, account)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+const (
+	// defaultAzureEndpoint is the base URL of the Azure instance metadata
+	// service.
+	defaultAzureEndpoint = "http://169.254.169.254"
+	// azureComputePath is the path of the compute metadata of the Azure
+	// instance metadata service.
+	azureComputePath = "/metadata/instance/compute?api-version=2021-12-13&format=json"
+)
+
+// azureDetector detects the attributes of the Azure Function app or virtual
+// machine the process runs on. Function apps are detected from the
+// environment, virtual machines with the Azure instance metadata service.
+type azureDetector struct {
+	cfg metadataConfig
+}
+
+// azureCompute is the compute metadata of an Azure virtual machine.
+type azureCompute struct {
+	Location       string `json:"location"`
+	Name           string `json:"name"`
+	OSType         string `json:"osType"`
+	SubscriptionID string `json:"subscriptionId"`
+	VMID           string `json:"vmId"`
+	VMSize         string `json:"vmSize"`
+	Zone           string `json:"zone"`
+}
+
+var _ Detector = azureDetector{}
+
+// Detect returns a Resource describing the Azure environment. If the process
+// does not run in an Azure Function app and the instance metadata service is
+// not reachable, an empty Resource is returned.
+func (d azureDetector) Detect(ctx context.Context) (*Resource, error) {
+	if os.Getenv("FUNCTIONS_WORKER_RUNTIME") != "" {
+		return d.detectFunction()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.timeout)
+	defer cancel()
+
+	body, err := d.cfg.request(ctx, http.MethodGet, azureComputePath, http.Header{
+		"Metadata": {"true"},
+	})
+	if err != nil {
+		// Not running on Azure.
+		return Empty(), nil
+	}
+	var compute azureCompute
+	if err := json.Unmarshal(body, &compute); err != nil {
+		return nil, fmt.Errorf("%w: invalid compute metadata: %v", ErrPartialResource, err)
+	}
+
+	m := metadataAttrs{}
+	m.attrs = append(m.attrs, semconv.CloudProviderAzure, semconv.CloudPlatformAzureVM)
+	m.add(semconv.CloudAccountID, compute.SubscriptionID)
+	m.add(semconv.CloudRegion, compute.Location)
+	m.add(semconv.CloudAvailabilityZone, compute.Zone)
+	m.add(semconv.HostID, compute.VMID)
+	m.add(semconv.HostName, compute.Name)
+	m.add(semconv.HostType, compute.VMSize)
+	return m.resource(semconv.SchemaURL)
+}
+
+// detectFunction returns a Resource describing the Azure Function app from
+// the environment variables of the Functions runtime.
+func (d azureDetector) detectFunction() (*Resource, error) {
+	m := metadataAttrs{}
+	m.attrs = append(m.attrs, semconv.CloudProviderAzure, semconv.CloudPlatformAzureFunctions)
+	m.add(semconv.CloudRegion, os.Getenv("REGION_NAME"))
+	m.add(semconv.FaaSName, os.Getenv("WEBSITE_SITE_NAME"))
+	m.add(semconv.FaaSInstance, os.Getenv("WEBSITE_INSTANCE_ID"))
+	if v := os.Getenv("WEBSITE_MEMORY_LIMIT_MB"); v != "" {
+		mb, err := strconv.Atoi(v)
+		if err != nil {
+			m.error(fmt.Errorf("invalid WEBSITE_MEMORY_LIMIT_MB: %w", err))
+		} else {
+			m.attrs = append(m.attrs, semconv.FaaSMaxMemory(mb))
+		}
+	}
+	return m.resource(semconv.SchemaURL)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+const azureComputeMetadata = `{
+  "location": "westeurope",
+  "name": "vm-1",
+  "osType": "Linux",
+  "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+  "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+  "vmSize": "Standard_D2s_v3",
+  "zone": "1"
+}`
+
+func setupAzure(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{
+		"FUNCTIONS_WORKER_RUNTIME", "REGION_NAME", "WEBSITE_SITE_NAME",
+		"WEBSITE_INSTANCE_ID", "WEBSITE_MEMORY_LIMIT_MB",
+	} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestAzureDetectorVM(t *testing.T) {
+	setupAzure(t)
+	srv := newMetadataServer(t, withHeader("Metadata", "true"), map[string]string{
+		"GET " + azureComputePath: azureComputeMetadata,
+	})
+
+	d := azureDetector{cfg: newMetadataConfig(defaultAzureEndpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+		semconv.CloudAccountID("8d10da13-8125-4ba9-a717-bf7490507b3d"),
+		semconv.CloudRegion("westeurope"),
+		semconv.CloudAvailabilityZone("1"),
+		semconv.HostID("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		semconv.HostName("vm-1"),
+		semconv.HostType("Standard_D2s_v3"),
+	), res)
+}
+
+func TestAzureDetectorInvalidMetadata(t *testing.T) {
+	setupAzure(t)
+	srv := newMetadataServer(t, withHeader("Metadata", "true"), map[string]string{
+		"GET " + azureComputePath: "{",
+	})
+
+	d := azureDetector{cfg: newMetadataConfig(defaultAzureEndpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+	_, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+}
+
+func TestAzureDetectorFunctions(t *testing.T) {
+	setupAzure(t)
+	t.Setenv("FUNCTIONS_WORKER_RUNTIME", "custom")
+	t.Setenv("REGION_NAME", "West Europe")
+	t.Setenv("WEBSITE_SITE_NAME", "my-function-app")
+	t.Setenv("WEBSITE_INSTANCE_ID", "5a3c7d")
+	t.Setenv("WEBSITE_MEMORY_LIMIT_MB", "1536")
+
+	// The metadata service must not be queried.
+	d := azureDetector{cfg: newMetadataConfig("http://unreachable.invalid", nil)}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureFunctions,
+		semconv.CloudRegion("West Europe"),
+		semconv.FaaSName("my-function-app"),
+		semconv.FaaSInstance("5a3c7d"),
+		semconv.FaaSMaxMemory(1536),
+	), res)
+}
+
+func TestAzureDetectorFunctionsInvalidMemory(t *testing.T) {
+	setupAzure(t)
+	t.Setenv("FUNCTIONS_WORKER_RUNTIME", "node")
+	t.Setenv("WEBSITE_SITE_NAME", "my-function-app")
+	t.Setenv("WEBSITE_MEMORY_LIMIT_MB", "lots")
+
+	res, err := azureDetector{}.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+	assert.Equal(t, "my-function-app", attrValue(res, semconv.FaaSNameKey))
+}
@@ -26,6 +26,8 @@ type config struct {
 	detectors []Detector
 	// SchemaURL to associate with the Resource.
 	schemaURL string
+	// translator used to merge resources with different schema URLs.
+	translator SchemaTranslator
 }
 
 // Option is the interface that applies a configuration option.
@@ -93,6 +95,23 @@ func (o schemaURLOption) apply(cfg config) config {
 	return cfg
 }
 
+// WithSchemaTranslator sets the SchemaTranslator used to merge the resources
+// of detectors with different schema URLs. Instead of failing to merge those
+// resources, they are translated to the newest schema version with t. See
+// MergeWithSchemas for more information.
+func WithSchemaTranslator(t SchemaTranslator) Option {
+	return schemaTranslatorOption{translator: t}
+}
+
+type schemaTranslatorOption struct {
+	translator SchemaTranslator
+}
+
+func (o schemaTranslatorOption) apply(cfg config) config {
+	cfg.translator = o.translator
+	return cfg
+}
+
 // WithOS adds all the OS attributes to the configured Resource.
 // See individual WithOS* functions to configure specific attributes.
 func WithOS() Option {
@@ -195,12 +214,95 @@ func WithProcessRuntimeDescription() Option {
 func WithContainer() Option {
 	return WithDetectors(
 		cgroupContainerIDDetector{},
+		containerRuntimeDetector{},
+		containerImageNameDetector{},
 	)
 }
 
+// WithK8s adds attributes describing the Kubernetes pod the process runs in
+// to the configured Resource. See WithK8sDownwardAPI for the attributes and
+// their sources. The downward API volume is expected to be mounted at
+// /etc/podinfo.
+func WithK8s() Option {
+	return WithK8sDownwardAPI(defaultK8sDownwardAPIPath)
+}
+
+// WithK8sDownwardAPI adds attributes describing the Kubernetes pod the
+// process runs in to the configured Resource, reading the downward API volume
+// mounted at path.
+//
+// The k8s.pod.name, k8s.pod.uid, k8s.namespace.name, k8s.node.name,
+// k8s.container.name, and k8s.deployment.name attributes are read from the
+// K8S_POD_NAME, K8S_POD_UID, K8S_NAMESPACE_NAME, K8S_NODE_NAME,
+// K8S_CONTAINER_NAME, and K8S_DEPLOYMENT_NAME environment variables. The
+// POD_NAME, POD_UID, POD_NAMESPACE, NODE_NAME, and CONTAINER_NAME
+// environment variables are also supported.
+//
+// Otherwise, the pod name, pod UID, namespace, and node name are read from
+// the pod_name, pod_uid, namespace, and node_name files of the downward API
+// volume. The namespace is also read from the service account namespace
+// file. If the pod name is still unknown, the hostname is used. The
+// deployment name is inferred from the pod name, and confirmed with the
+// pod-template-hash label if a labels file is part of the volume.
+func WithK8sDownwardAPI(path string) Option {
+	return WithDetectors(k8sDetector{downwardAPIPath: path})
+}
+
 // WithContainerID adds an attribute with the id of the container to the configured Resource.
 // Note: WithContainerID will not extract the correct container ID in an ECS environment.
 // Please use the ECS resource detector instead (https://pkg.go.dev/go.opentelemetry.io/contrib/detectors/aws/ecs).
 func WithContainerID() Option {
 	return WithDetectors(cgroupContainerIDDetector{})
 }
+
+// WithContainerRuntime adds an attribute with the runtime of the container
+// (docker, containerd, cri-o, or podman) to the configured Resource, if it
+// can be identified.
+func WithContainerRuntime() Option {
+	return WithDetectors(containerRuntimeDetector{})
+}
+
+// WithContainerImageName adds an attribute with the image name of the
+// container to the configured Resource. The image name is only known for
+// containers created by podman.
+func WithContainerImageName() Option {
+	return WithDetectors(containerImageNameDetector{})
+}
+
+// WithAWSEC2 adds attributes describing the EC2 instance the process runs on
+// to the configured Resource. The cloud.* and host.* attributes are read
+// from the instance metadata service (IMDSv2). If the service is not
+// reachable, no attributes are added.
+func WithAWSEC2(opts ...MetadataOption) Option {
+	return WithDetectors(ec2Detector{cfg: newMetadataConfig(defaultEC2Endpoint, opts)})
+}
+
+// WithAWSECS adds attributes describing the ECS task and container the
+// process runs in to the configured Resource. The cloud.*, aws.ecs.*,
+// aws.log.*, and container.* attributes are read from the task metadata
+// endpoint version 4. By default, the endpoint is read from the
+// ECS_CONTAINER_METADATA_URI_V4 environment variable. If it is not set, no
+// attributes are added.
+func WithAWSECS(opts ...MetadataOption) Option {
+	return WithDetectors(ecsDetector{cfg: newMetadataConfig("", opts)})
+}
+
+// WithGCP adds attributes describing the Google Compute Engine instance,
+// Google Kubernetes Engine node, Cloud Run service, or Cloud Function the
+// process runs on to the configured Resource. The cloud.*, host.*, faas.*,
+// and k8s.cluster.name attributes are read from the metadata server and the
+// K_SERVICE, K_REVISION, and FUNCTION_TARGET environment variables. If the
+// metadata server is not reachable, no attributes are added.
+func WithGCP(opts ...MetadataOption) Option {
+	return WithDetectors(gcpDetector{cfg: newMetadataConfig(defaultGCPEndpoint, opts)})
+}
+
+// WithAzure adds attributes describing the Azure virtual machine or Function
+// app the process runs on to the configured Resource. Function apps are
+// described with the cloud.* and faas.* attributes read from the environment
+// of the Functions runtime. Virtual machines are described with the cloud.*
+// and host.* attributes read from the instance metadata service. If the
+// service is not reachable, no attributes are added.
+func WithAzure(opts ...MetadataOption) Option {
+	return WithDetectors(azureDetector{cfg: newMetadataConfig(defaultAzureEndpoint, opts)})
+}
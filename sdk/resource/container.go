@@ -21,20 +21,47 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
 
 	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
 )
 
 type containerIDProvider func() (string, error)
 
+// containerInfoProvider returns the container runtime and image name.
+type containerInfoProvider func() (runtime, imageName string, err error)
+
 var (
-	containerID         containerIDProvider = getContainerIDFromCGroup
-	cgroupContainerIDRe                     = regexp.MustCompile(`^.*/(?:.*-)?([0-9a-f]+)(?:\.|\s*$)`)
+	containerID         containerIDProvider   = getContainerIDFromCGroup
+	containerInfo       containerInfoProvider = getContainerRuntimeAndImage
+	cgroupContainerIDRe                       = regexp.MustCompile(`^.*/(?:.*-)?([0-9a-f]+)(?:\.|\s*$)`)
+	// ecsFargateContainerIDRe matches the cgroup path of an ECS on Fargate
+	// container: /ecs/<task-id>/<task-id>-<container-number>.
+	ecsFargateContainerIDRe = regexp.MustCompile(`/ecs/[0-9a-f]{32}/([0-9a-f]{32}-[0-9]+)\s*$`)
+	// mountinfoContainerIDRe matches the mount sources, of files like
+	// /etc/hostname, located in the directory of a container.
+	mountinfoContainerIDRe = regexp.MustCompile(`/(containers|overlay-containers|io\.containerd\.runtime\.v2\.task/[^/]+)/([0-9a-f]{64})/`)
+)
+
+// Container runtimes, as used by the container.runtime attribute.
+const (
+	runtimeDocker     = "docker"
+	runtimeContainerd = "containerd"
+	runtimeCRIO       = "cri-o"
+	runtimePodman     = "podman"
 )
 
 type cgroupContainerIDDetector struct{}
+type containerRuntimeDetector struct{}
+type containerImageNameDetector struct{}
 
-const cgroupPath = "/proc/self/cgroup"
+const (
+	cgroupPath       = "/proc/self/cgroup"
+	mountinfoPath    = "/proc/self/mountinfo"
+	containerEnvPath = "/run/.containerenv"
+	dockerEnvPath    = "/.dockerenv"
+)
 
 // Detect returns a *Resource that describes the id of the container.
 // If no container id found, an empty resource will be returned.
@@ -50,6 +77,34 @@ func (cgroupContainerIDDetector) Detect(ctx context.Context) (*Resource, error)
 	return NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(containerID)), nil
 }
 
+// Detect returns a *Resource that describes the runtime of the container.
+// If the runtime cannot be determined, an empty resource will be returned.
+func (containerRuntimeDetector) Detect(ctx context.Context) (*Resource, error) {
+	runtime, _, err := containerInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if runtime == "" {
+		return Empty(), nil
+	}
+	return NewWithAttributes(semconv.SchemaURL, semconv.ContainerRuntime(runtime)), nil
+}
+
+// Detect returns a *Resource that describes the image name of the container.
+// If the image name cannot be determined, an empty resource will be returned.
+func (containerImageNameDetector) Detect(ctx context.Context) (*Resource, error) {
+	_, imageName, err := containerInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if imageName == "" {
+		return Empty(), nil
+	}
+	return NewWithAttributes(semconv.SchemaURL, semconv.ContainerImageName(imageName)), nil
+}
+
 var (
 	defaultOSStat = os.Stat
 	osStat        = defaultOSStat
@@ -60,41 +115,174 @@ var (
 	osOpen = defaultOSOpen
 )
 
-// getContainerIDFromCGroup returns the id of the container from the cgroup file.
-// If no container id found, an empty string will be returned.
+// container describes the container the process runs in.
+type container struct {
+	id      string
+	runtime string
+}
+
+// getContainerIDFromCGroup returns the id of the container from the cgroup
+// file. On cgroup v2 hosts the cgroup file does not contain the container id
+// and the mountinfo file is used instead. If no container id found, an empty
+// string will be returned.
 func getContainerIDFromCGroup() (string, error) {
-	if _, err := osStat(cgroupPath); errors.Is(err, os.ErrNotExist) {
+	c, err := getContainer()
+	return c.id, err
+}
+
+// getContainer returns the container described by the cgroup file, or by the
+// mountinfo file if the cgroup file does not identify it.
+func getContainer() (container, error) {
+	c, err := readContainerFile(cgroupPath, getContainerFromReader)
+	if err != nil || c.id != "" {
+		return c, err
+	}
+	return readContainerFile(mountinfoPath, getContainerFromMountinfo)
+}
+
+func readContainerFile(path string, parse func(io.Reader) container) (container, error) {
+	if _, err := osStat(path); errors.Is(err, os.ErrNotExist) {
 		// File does not exist, skip
-		return "", nil
+		return container{}, nil
 	}
 
-	file, err := osOpen(cgroupPath)
+	file, err := osOpen(path)
 	if err != nil {
-		return "", err
+		return container{}, err
 	}
 	defer file.Close()
 
-	return getContainerIDFromReader(file), nil
+	return parse(file), nil
 }
 
 // getContainerIDFromReader returns the id of the container from reader.
 func getContainerIDFromReader(reader io.Reader) string {
+	return getContainerFromReader(reader).id
+}
+
+// getContainerFromReader returns the container from the cgroup file content
+// of reader.
+func getContainerFromReader(reader io.Reader) container {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		if id := getContainerIDFromLine(line); id != "" {
-			return id
+			return container{id: id, runtime: getRuntimeFromLine(line)}
 		}
 	}
-	return ""
+	return container{}
 }
 
 // getContainerIDFromLine returns the id of the container from one string line.
 func getContainerIDFromLine(line string) string {
+	if matches := ecsFargateContainerIDRe.FindStringSubmatch(line); len(matches) > 1 {
+		return matches[1]
+	}
 	matches := cgroupContainerIDRe.FindStringSubmatch(line)
 	if len(matches) <= 1 {
 		return ""
 	}
 	return matches[1]
 }
+
+// getRuntimeFromLine returns the container runtime that created the cgroup of
+// line, if it can be identified.
+func getRuntimeFromLine(line string) string {
+	switch {
+	case strings.Contains(line, "cri-containerd-"), strings.Contains(line, "/containerd/"):
+		return runtimeContainerd
+	case strings.Contains(line, "crio-"):
+		return runtimeCRIO
+	case strings.Contains(line, "libpod"):
+		return runtimePodman
+	case strings.Contains(line, "docker"):
+		return runtimeDocker
+	}
+	return ""
+}
+
+// getContainerFromMountinfo returns the container from the mountinfo file
+// content of reader.
+func getContainerFromMountinfo(reader io.Reader) container {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		matches := mountinfoContainerIDRe.FindStringSubmatch(scanner.Text())
+		if len(matches) <= 2 {
+			continue
+		}
+		c := container{id: matches[2]}
+		switch {
+		case matches[1] == "containers" && strings.Contains(scanner.Text(), "/docker/"):
+			c.runtime = runtimeDocker
+		case strings.HasPrefix(matches[1], "io.containerd."):
+			c.runtime = runtimeContainerd
+		}
+		return c
+	}
+	return container{}
+}
+
+// getContainerRuntimeAndImage returns the runtime and image name of the
+// container. The image name is only known for podman containers, that
+// describe themselves in the /run/.containerenv file.
+func getContainerRuntimeAndImage() (string, string, error) {
+	runtime, imageName, err := readContainerEnv()
+	if err != nil || runtime != "" {
+		return runtime, imageName, err
+	}
+
+	c, err := getContainer()
+	if err != nil {
+		return "", "", err
+	}
+	runtime = c.runtime
+	if runtime == "" {
+		if _, err := osStat(dockerEnvPath); err == nil {
+			runtime = runtimeDocker
+		}
+	}
+	return runtime, imageName, nil
+}
+
+// readContainerEnv parses the /run/.containerenv file created by podman and
+// CRI-O. It returns the container runtime and image name.
+func readContainerEnv() (string, string, error) {
+	if _, err := osStat(containerEnvPath); errors.Is(err, os.ErrNotExist) {
+		return "", "", nil
+	}
+
+	file, err := osOpen(containerEnvPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	runtime, imageName := getContainerEnvFromReader(file)
+	return runtime, imageName, nil
+}
+
+// getContainerEnvFromReader returns the runtime and image name from the
+// content of a .containerenv file. The file is empty unless the container
+// was created by podman.
+func getContainerEnvFromReader(reader io.Reader) (runtime, imageName string) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		if uv, err := strconv.Unquote(v); err == nil {
+			v = uv
+		}
+		switch k {
+		case "engine":
+			if e, _, _ := strings.Cut(v, "-"); e != "" {
+				runtime = e
+			}
+		case "image":
+			imageName = v
+		}
+	}
+	return runtime, imageName
+}
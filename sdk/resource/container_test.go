@@ -15,25 +15,38 @@
 package resource
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
 )
 
 func setDefaultContainerProviders() {
 	setContainerProviders(
 		getContainerIDFromCGroup,
+		getContainerRuntimeAndImage,
 	)
 }
 
+// setContainerProviders sets the container providers. If no info provider is
+// passed, no container runtime or image name is detected.
 func setContainerProviders(
 	idProvider containerIDProvider,
+	infoProviders ...containerInfoProvider,
 ) {
 	containerID = idProvider
+	containerInfo = func() (string, string, error) { return "", "", nil }
+	if len(infoProviders) > 0 {
+		containerInfo = infoProviders[0]
+	}
 }
 
 func TestGetContainerIDFromLine(t *testing.T) {
@@ -167,3 +180,161 @@ func TestGetContainerIDFromCGroup(t *testing.T) {
 		})
 	}
 }
+
+const (
+	fixtureContainerID = "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	fixtureECSTaskID   = "8a0c2d3e4f5a6b7c8d9e0f1a2b3c4d5e"
+)
+
+// useContainerFixtures makes the container detection read the fixture files
+// in testdata/container. The keys of files are the paths read by the
+// detection, the values the fixture file names. Missing paths do not exist.
+func useContainerFixtures(t *testing.T, files map[string]string) {
+	t.Cleanup(func() {
+		osStat = defaultOSStat
+		osOpen = defaultOSOpen
+	})
+	osStat = func(name string) (os.FileInfo, error) {
+		if f, ok := files[name]; ok {
+			return os.Stat(filepath.Join("testdata", "container", f))
+		}
+		return nil, os.ErrNotExist
+	}
+	osOpen = func(name string) (io.ReadCloser, error) {
+		if f, ok := files[name]; ok {
+			return os.Open(filepath.Join("testdata", "container", f))
+		}
+		return nil, os.ErrNotExist
+	}
+}
+
+func TestGetContainerFixtures(t *testing.T) {
+	testCases := []struct {
+		name            string
+		files           map[string]string
+		expectedID      string
+		expectedRuntime string
+		expectedImage   string
+	}{
+		{
+			name:            "cgroup v1 docker",
+			files:           map[string]string{cgroupPath: "cgroup-v1-docker"},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimeDocker,
+		},
+		{
+			name:            "cgroup v2 containerd",
+			files:           map[string]string{cgroupPath: "cgroup-v2-containerd"},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimeContainerd,
+		},
+		{
+			name: "cgroup v2 cri-o",
+			files: map[string]string{
+				cgroupPath:       "cgroup-v2-crio",
+				containerEnvPath: "containerenv-crio",
+			},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimeCRIO,
+		},
+		{
+			name:            "cgroup v2 podman",
+			files:           map[string]string{cgroupPath: "cgroup-v2-podman"},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimePodman,
+		},
+		{
+			name:       "cgroup v1 ECS",
+			files:      map[string]string{cgroupPath: "cgroup-v1-ecs"},
+			expectedID: fixtureContainerID,
+		},
+		{
+			name:       "cgroup v1 ECS on Fargate",
+			files:      map[string]string{cgroupPath: "cgroup-v1-ecs-fargate"},
+			expectedID: fixtureECSTaskID + "-3573222910",
+		},
+		{
+			name: "cgroup v2 docker mountinfo",
+			files: map[string]string{
+				cgroupPath:    "cgroup-v2-private",
+				mountinfoPath: "mountinfo-docker",
+			},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimeDocker,
+		},
+		{
+			name: "cgroup v2 containerd mountinfo",
+			files: map[string]string{
+				cgroupPath:    "cgroup-v2-private",
+				mountinfoPath: "mountinfo-containerd",
+			},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimeContainerd,
+		},
+		{
+			name: "cgroup v2 podman mountinfo and containerenv",
+			files: map[string]string{
+				cgroupPath:       "cgroup-v2-private",
+				mountinfoPath:    "mountinfo-podman",
+				containerEnvPath: "containerenv-podman",
+			},
+			expectedID:      fixtureContainerID,
+			expectedRuntime: runtimePodman,
+			expectedImage:   "docker.io/library/nginx:latest",
+		},
+		{
+			name: "cgroup v2 docker without container id",
+			files: map[string]string{
+				cgroupPath:    "cgroup-v2-private",
+				mountinfoPath: "mountinfo-none",
+				dockerEnvPath: "dockerenv",
+			},
+			expectedRuntime: runtimeDocker,
+		},
+		{
+			name: "not in a container",
+			files: map[string]string{
+				cgroupPath:    "cgroup-v2-private",
+				mountinfoPath: "mountinfo-none",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			useContainerFixtures(t, tc.files)
+
+			id, err := getContainerIDFromCGroup()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id)
+
+			runtime, image, err := getContainerRuntimeAndImage()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRuntime, runtime)
+			assert.Equal(t, tc.expectedImage, image)
+		})
+	}
+}
+
+func TestContainerDetectors(t *testing.T) {
+	t.Cleanup(setDefaultContainerProviders)
+	setContainerProviders(
+		func() (string, error) { return fixtureContainerID, nil },
+		func() (string, string, error) { return runtimePodman, "nginx", nil },
+	)
+
+	res, err := New(context.Background(), WithContainer())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.ContainerID(fixtureContainerID),
+		semconv.ContainerRuntime(runtimePodman),
+		semconv.ContainerImageName("nginx"),
+	), res)
+
+	setContainerProviders(
+		func() (string, error) { return "", nil },
+		func() (string, string, error) { return "", "", errors.New("read error") },
+	)
+	_, err = New(context.Background(), WithContainerRuntime(), WithContainerImageName())
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/middleware-labs/otel/attribute"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+// defaultGCPEndpoint is the base URL of the GCP metadata server.
+const defaultGCPEndpoint = "http://metadata.google.internal/computeMetadata/v1"
+
+// gcpDetector detects the attributes of the Google Compute Engine instance,
+// Google Kubernetes Engine node, Cloud Run service, or Cloud Function the
+// process runs on using the GCP metadata server.
+type gcpDetector struct {
+	cfg metadataConfig
+}
+
+var _ Detector = gcpDetector{}
+
+// gcpHeader is the header required by the GCP metadata server.
+var gcpHeader = http.Header{"Metadata-Flavor": {"Google"}}
+
+// Detect returns a Resource describing the GCP environment. If the metadata
+// server is not reachable, an empty Resource is returned.
+func (d gcpDetector) Detect(ctx context.Context) (*Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.timeout)
+	defer cancel()
+
+	project, err := d.value(ctx, "/project/project-id")
+	if err != nil {
+		// Not running on GCP.
+		return Empty(), nil
+	}
+
+	m := metadataAttrs{}
+	m.attrs = append(m.attrs, semconv.CloudProviderGCP)
+	m.add(semconv.CloudAccountID, project)
+
+	if service := os.Getenv("K_SERVICE"); service != "" {
+		// Cloud Run and Cloud Functions (2nd gen) set K_SERVICE.
+		platform := semconv.CloudPlatformGCPCloudRun
+		if os.Getenv("FUNCTION_TARGET") != "" {
+			platform = semconv.CloudPlatformGCPCloudFunctions
+		}
+		m.attrs = append(m.attrs, platform)
+		m.add(semconv.FaaSName, service)
+		m.add(semconv.FaaSVersion, os.Getenv("K_REVISION"))
+		d.add(ctx, &m, semconv.FaaSInstance, "/instance/id")
+		d.add(ctx, &m, func(v string) attribute.KeyValue {
+			return semconv.CloudRegion(lastPathSegment(v))
+		}, "/instance/region")
+		return m.resource(semconv.SchemaURL)
+	}
+
+	cluster, err := d.value(ctx, "/instance/attributes/cluster-name")
+	switch {
+	case err == nil:
+		m.attrs = append(m.attrs, semconv.CloudPlatformGCPKubernetesEngine)
+		m.add(semconv.K8SClusterName, cluster)
+	case errors.Is(err, errMetadataNotFound):
+		m.attrs = append(m.attrs, semconv.CloudPlatformGCPComputeEngine)
+	default:
+		m.error(err)
+	}
+
+	if zone, err := d.value(ctx, "/instance/zone"); err != nil {
+		m.error(err)
+	} else {
+		zone = lastPathSegment(zone)
+		m.add(semconv.CloudAvailabilityZone, zone)
+		// Zones are named after their region: us-central1-a is in us-central1.
+		if i := strings.LastIndexByte(zone, '-'); i > 0 {
+			m.add(semconv.CloudRegion, zone[:i])
+		}
+	}
+	d.add(ctx, &m, semconv.HostID, "/instance/id")
+	d.add(ctx, &m, semconv.HostName, "/instance/name")
+	d.add(ctx, &m, func(v string) attribute.KeyValue {
+		return semconv.HostType(lastPathSegment(v))
+	}, "/instance/machine-type")
+
+	return m.resource(semconv.SchemaURL)
+}
+
+// value returns the value of the metadata at path.
+func (d gcpDetector) value(ctx context.Context, path string) (string, error) {
+	body, err := d.cfg.request(ctx, http.MethodGet, path, gcpHeader)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// add adds the attribute created by f from the metadata at path to m.
+func (d gcpDetector) add(ctx context.Context, m *metadataAttrs, f func(string) attribute.KeyValue, path string) {
+	v, err := d.value(ctx, path)
+	if err != nil {
+		m.error(err)
+		return
+	}
+	m.add(f, v)
+}
+
+// lastPathSegment returns the last segment of a metadata resource path, such
+// as "us-central1-a" for "projects/123/zones/us-central1-a".
+func lastPathSegment(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
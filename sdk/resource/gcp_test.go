@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+var gcpAuth = withHeader("Metadata-Flavor", "Google")
+
+func gcpTestDetector(t *testing.T, routes map[string]string) gcpDetector {
+	t.Helper()
+	t.Setenv("K_SERVICE", "")
+	t.Setenv("K_REVISION", "")
+	t.Setenv("FUNCTION_TARGET", "")
+	srv := newMetadataServer(t, gcpAuth, routes)
+	return gcpDetector{cfg: newMetadataConfig(defaultGCPEndpoint, []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+	})}
+}
+
+var gceRoutes = map[string]string{
+	"GET /project/project-id":    "my-project",
+	"GET /instance/zone":         "projects/123456789/zones/us-central1-a",
+	"GET /instance/id":           "4520031799277581759",
+	"GET /instance/name":         "instance-1",
+	"GET /instance/machine-type": "projects/123456789/machineTypes/e2-medium",
+}
+
+func TestGCPDetectorComputeEngine(t *testing.T) {
+	d := gcpTestDetector(t, gceRoutes)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountID("my-project"),
+		semconv.CloudPlatformGCPComputeEngine,
+		semconv.CloudAvailabilityZone("us-central1-a"),
+		semconv.CloudRegion("us-central1"),
+		semconv.HostID("4520031799277581759"),
+		semconv.HostName("instance-1"),
+		semconv.HostType("e2-medium"),
+	), res)
+}
+
+func TestGCPDetectorKubernetesEngine(t *testing.T) {
+	routes := map[string]string{"GET /instance/attributes/cluster-name": "prod"}
+	for k, v := range gceRoutes {
+		routes[k] = v
+	}
+	d := gcpTestDetector(t, routes)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, semconv.CloudPlatformGCPKubernetesEngine.Value.AsString(), attrValue(res, semconv.CloudPlatformKey))
+	assert.Equal(t, "prod", attrValue(res, semconv.K8SClusterNameKey))
+}
+
+func TestGCPDetectorCloudRun(t *testing.T) {
+	d := gcpTestDetector(t, map[string]string{
+		"GET /project/project-id": "my-project",
+		"GET /instance/id":        "00bf4bf02d",
+		"GET /instance/region":    "projects/123456789/regions/europe-west1",
+	})
+	t.Setenv("K_SERVICE", "hello")
+	t.Setenv("K_REVISION", "hello-00001-abc")
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountID("my-project"),
+		semconv.CloudPlatformGCPCloudRun,
+		semconv.FaaSName("hello"),
+		semconv.FaaSVersion("hello-00001-abc"),
+		semconv.FaaSInstance("00bf4bf02d"),
+		semconv.CloudRegion("europe-west1"),
+	), res)
+}
+
+func TestGCPDetectorCloudFunctions(t *testing.T) {
+	d := gcpTestDetector(t, map[string]string{
+		"GET /project/project-id": "my-project",
+		"GET /instance/id":        "00bf4bf02d",
+		"GET /instance/region":    "projects/123456789/regions/europe-west1",
+	})
+	t.Setenv("K_SERVICE", "function-1")
+	t.Setenv("FUNCTION_TARGET", "HelloWorld")
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, semconv.CloudPlatformGCPCloudFunctions.Value.AsString(), attrValue(res, semconv.CloudPlatformKey))
+	assert.Equal(t, "function-1", attrValue(res, semconv.FaaSNameKey))
+}
+
+func TestGCPDetectorPartial(t *testing.T) {
+	d := gcpTestDetector(t, map[string]string{
+		"GET /project/project-id": "my-project",
+		"GET /instance/id":        "4520031799277581759",
+	})
+
+	res, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, ErrPartialResource)
+	assert.Equal(t, "4520031799277581759", attrValue(res, semconv.HostIDKey))
+}
+
+func TestGCPDetectorNotOnGCP(t *testing.T) {
+	d := gcpTestDetector(t, nil)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Empty(), res)
+}
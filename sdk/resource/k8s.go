@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/middleware-labs/otel/attribute"
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+const (
+	// defaultK8sDownwardAPIPath is the default directory of the downward
+	// API volume read by the Kubernetes detector.
+	defaultK8sDownwardAPIPath = "/etc/podinfo"
+
+	// Names of the files of a downward API volume.
+	k8sPodNameFile   = "pod_name"
+	k8sPodUIDFile    = "pod_uid"
+	k8sNamespaceFile = "namespace"
+	k8sNodeNameFile  = "node_name"
+	k8sLabelsFile    = "labels"
+
+	// k8sPodTemplateHashLabel is the label added by the Deployment controller
+	// to the pods of a ReplicaSet.
+	k8sPodTemplateHashLabel = "pod-template-hash"
+)
+
+var (
+	// k8sServiceAccountNamespacePath is the file holding the namespace of
+	// the pod when a service account token is mounted.
+	k8sServiceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	// k8sHostname returns the hostname of the pod, which is the pod name
+	// unless overridden in the pod specification.
+	k8sHostname = os.Hostname
+
+	// k8sDeploymentPodRe matches the name of a pod created by a Deployment:
+	// <deployment>-<pod-template-hash>-<suffix>. Generated names only use
+	// the characters of k8sSafeEncodeChars.
+	k8sDeploymentPodRe = regexp.MustCompile(`^(.+)-([bcdfghjklmnpqrstvwxz2456789]{5,10})-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+
+	// Environment variables, in priority order, holding each attribute.
+	k8sPodNameEnv        = []string{"K8S_POD_NAME", "OTEL_RESOURCE_ATTRIBUTES_POD_NAME", "POD_NAME"}
+	k8sPodUIDEnv         = []string{"K8S_POD_UID", "OTEL_RESOURCE_ATTRIBUTES_POD_UID", "POD_UID"}
+	k8sNamespaceEnv      = []string{"K8S_NAMESPACE_NAME", "POD_NAMESPACE"}
+	k8sNodeNameEnv       = []string{"K8S_NODE_NAME", "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME", "NODE_NAME"}
+	k8sContainerNameEnv  = []string{"K8S_CONTAINER_NAME", "CONTAINER_NAME"}
+	k8sDeploymentNameEnv = []string{"K8S_DEPLOYMENT_NAME"}
+)
+
+// k8sDetector detects the Kubernetes pod the process runs in.
+type k8sDetector struct {
+	// downwardAPIPath is the directory of a downward API volume.
+	downwardAPIPath string
+}
+
+// Detect returns a *Resource that describes the Kubernetes pod the process
+// runs in. An empty resource is returned if the process does not run in
+// Kubernetes.
+//
+// Attributes are read, in priority order, from environment variables, the
+// files of a downward API volume, and the service account namespace file.
+// The pod name defaults to the hostname, and the deployment name is inferred
+// from the pod name and the pod-template-hash label.
+func (d k8sDetector) Detect(ctx context.Context) (*Resource, error) {
+	var errs []string
+	readFile := func(name string) string {
+		if d.downwardAPIPath == "" {
+			return ""
+		}
+		v, err := readK8sFile(filepath.Join(d.downwardAPIPath, name))
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		return v
+	}
+
+	podName := firstNonEmpty(lookupEnv(k8sPodNameEnv), readFile(k8sPodNameFile))
+	podUID := firstNonEmpty(lookupEnv(k8sPodUIDEnv), readFile(k8sPodUIDFile))
+	namespace := firstNonEmpty(lookupEnv(k8sNamespaceEnv), readFile(k8sNamespaceFile))
+	if namespace == "" {
+		var err error
+		namespace, err = readK8sFile(k8sServiceAccountNamespacePath)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	nodeName := firstNonEmpty(lookupEnv(k8sNodeNameEnv), readFile(k8sNodeNameFile))
+	containerName := lookupEnv(k8sContainerNameEnv)
+
+	var labels map[string]string
+	if d.downwardAPIPath != "" {
+		var err error
+		labels, err = readK8sLabels(filepath.Join(d.downwardAPIPath, k8sLabelsFile))
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if podName == "" && podUID == "" && namespace == "" && nodeName == "" {
+		// Not running in Kubernetes, or nothing is exposed to the process.
+		if _, ok := os.LookupEnv("KUBERNETES_SERVICE_HOST"); !ok {
+			return Empty(), detectK8sErr(errs)
+		}
+	}
+	if podName == "" {
+		if h, err := k8sHostname(); err == nil {
+			podName = h
+		}
+	}
+	deploymentName := firstNonEmpty(lookupEnv(k8sDeploymentNameEnv), k8sDeploymentName(podName, labels))
+
+	var attrs []attribute.KeyValue
+	add := func(f func(string) attribute.KeyValue, v string) {
+		if v != "" {
+			attrs = append(attrs, f(v))
+		}
+	}
+	add(semconv.K8SPodName, podName)
+	add(semconv.K8SPodUID, podUID)
+	add(semconv.K8SNamespaceName, namespace)
+	add(semconv.K8SNodeName, nodeName)
+	add(semconv.K8SContainerName, containerName)
+	add(semconv.K8SDeploymentName, deploymentName)
+
+	return NewWithAttributes(semconv.SchemaURL, attrs...), detectK8sErr(errs)
+}
+
+func detectK8sErr(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrPartialResource, strings.Join(errs, "; "))
+}
+
+// k8sDeploymentName infers the name of the Deployment that created the pod
+// podName. If the pod labels are known, the pod-template-hash label is used
+// to confirm the pod belongs to a Deployment.
+func k8sDeploymentName(podName string, labels map[string]string) string {
+	m := k8sDeploymentPodRe.FindStringSubmatch(podName)
+	if m == nil {
+		return ""
+	}
+	if labels != nil {
+		hash, ok := labels[k8sPodTemplateHashLabel]
+		if !ok || hash != m[2] {
+			return ""
+		}
+	}
+	return m[1]
+}
+
+func lookupEnv(keys []string) string {
+	for _, k := range keys {
+		if v := strings.TrimSpace(os.Getenv(k)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// readK8sFile returns the trimmed content of the file at path. A missing
+// file is not an error.
+func readK8sFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// readK8sLabels parses a downward API labels file, made of key="value"
+// lines. A nil map is returned if the file does not exist.
+func readK8sLabels(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	labels := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		if uv, err := strconv.Unquote(v); err == nil {
+			v = uv
+		}
+		labels[strings.TrimSpace(k)] = v
+	}
+	return labels, scanner.Err()
+}
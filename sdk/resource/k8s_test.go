@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "github.com/middleware-labs/otel/semconv/v1.17.0"
+)
+
+// setupK8s isolates the Kubernetes detector from the environment it runs in.
+func setupK8s(t *testing.T, hostname string) (dir string) {
+	t.Helper()
+	for _, keys := range [][]string{
+		k8sPodNameEnv, k8sPodUIDEnv, k8sNamespaceEnv, k8sNodeNameEnv,
+		k8sContainerNameEnv, k8sDeploymentNameEnv, {"KUBERNETES_SERVICE_HOST"},
+	} {
+		for _, k := range keys {
+			if v, ok := os.LookupEnv(k); ok {
+				require.NoError(t, os.Unsetenv(k))
+				t.Cleanup(func() { _ = os.Setenv(k, v) })
+			}
+		}
+	}
+
+	dir = t.TempDir()
+	origPath, origHostname := k8sServiceAccountNamespacePath, k8sHostname
+	k8sServiceAccountNamespacePath = filepath.Join(dir, "serviceaccount", "namespace")
+	k8sHostname = func() (string, error) { return hostname, nil }
+	t.Cleanup(func() {
+		k8sServiceAccountNamespacePath, k8sHostname = origPath, origHostname
+	})
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestK8sDetectorNotInK8s(t *testing.T) {
+	dir := setupK8s(t, "laptop")
+
+	res, err := k8sDetector{downwardAPIPath: filepath.Join(dir, "podinfo")}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Empty(), res)
+}
+
+func TestK8sDetectorEnv(t *testing.T) {
+	setupK8s(t, "ignored")
+	t.Setenv("K8S_POD_NAME", "api-7d4b9c8f6d-x2x4q")
+	t.Setenv("POD_UID", "1234")
+	t.Setenv("POD_NAMESPACE", "prod")
+	t.Setenv("NODE_NAME", "node-1")
+	t.Setenv("CONTAINER_NAME", "api")
+
+	res, err := k8sDetector{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SPodName("api-7d4b9c8f6d-x2x4q"),
+		semconv.K8SPodUID("1234"),
+		semconv.K8SNamespaceName("prod"),
+		semconv.K8SNodeName("node-1"),
+		semconv.K8SContainerName("api"),
+		semconv.K8SDeploymentName("api"),
+	), res)
+}
+
+func TestK8sDetectorDownwardAPI(t *testing.T) {
+	dir := setupK8s(t, "ignored")
+	podinfo := filepath.Join(dir, "podinfo")
+	writeFile(t, filepath.Join(podinfo, "pod_name"), "web-frontend-5f6d8b7c9-x2x4q\n")
+	writeFile(t, filepath.Join(podinfo, "pod_uid"), "5678\n")
+	writeFile(t, filepath.Join(podinfo, "node_name"), "node-2\n")
+	writeFile(t, filepath.Join(podinfo, "labels"), "app=\"web\"\npod-template-hash=\"5f6d8b7c9\"\n")
+	writeFile(t, k8sServiceAccountNamespacePath, "staging")
+
+	res, err := k8sDetector{downwardAPIPath: podinfo}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SPodName("web-frontend-5f6d8b7c9-x2x4q"),
+		semconv.K8SPodUID("5678"),
+		semconv.K8SNamespaceName("staging"),
+		semconv.K8SNodeName("node-2"),
+		semconv.K8SDeploymentName("web-frontend"),
+	), res)
+}
+
+func TestK8sDetectorEnvPrecedence(t *testing.T) {
+	dir := setupK8s(t, "ignored")
+	podinfo := filepath.Join(dir, "podinfo")
+	writeFile(t, filepath.Join(podinfo, "namespace"), "from-file")
+	writeFile(t, k8sServiceAccountNamespacePath, "from-service-account")
+	t.Setenv("K8S_NAMESPACE_NAME", "from-env")
+
+	res, err := k8sDetector{downwardAPIPath: podinfo}.Detect(context.Background())
+	require.NoError(t, err)
+	v, ok := res.Set().Value(semconv.K8SNamespaceNameKey)
+	require.True(t, ok)
+	assert.Equal(t, "from-env", v.AsString())
+}
+
+func TestK8sDetectorHostname(t *testing.T) {
+	setupK8s(t, "worker-0")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+
+	res, err := k8sDetector{}.Detect(context.Background())
+	require.NoError(t, err)
+	// StatefulSet pod names are not mistaken for Deployment pods.
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL, semconv.K8SPodName("worker-0")), res)
+}
+
+func TestK8sDetectorLabelsMismatch(t *testing.T) {
+	dir := setupK8s(t, "ignored")
+	podinfo := filepath.Join(dir, "podinfo")
+	writeFile(t, filepath.Join(podinfo, "pod_name"), "web-frontend-5f6d8b7c9-x2x4q")
+	writeFile(t, filepath.Join(podinfo, "labels"), "app=\"web\"\n")
+
+	res, err := k8sDetector{downwardAPIPath: podinfo}.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Set().Value(semconv.K8SDeploymentNameKey)
+	assert.False(t, ok, "no pod-template-hash label")
+}
+
+func TestK8sDetectorPartial(t *testing.T) {
+	dir := setupK8s(t, "ignored")
+	podinfo := filepath.Join(dir, "podinfo")
+	writeFile(t, filepath.Join(podinfo, "pod_uid"), "5678")
+	// A directory cannot be read as a file.
+	require.NoError(t, os.MkdirAll(filepath.Join(podinfo, "node_name"), 0o755))
+	t.Setenv("K8S_POD_NAME", "pod")
+
+	res, err := k8sDetector{downwardAPIPath: podinfo}.Detect(context.Background())
+	assert.True(t, errors.Is(err, ErrPartialResource))
+	assert.Equal(t, NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SPodName("pod"),
+		semconv.K8SPodUID("5678"),
+	), res)
+}
+
+func TestK8sDeploymentName(t *testing.T) {
+	tests := []struct {
+		pod  string
+		want string
+	}{
+		{pod: "api-7d4b9c8f6d-x2x4q", want: "api"},
+		{pod: "my-app-v2-6b5f7c9d8-zz8kq", want: "my-app-v2"},
+		{pod: "worker-0"},
+		{pod: "job-x2x4q"},
+		{pod: "api-ABCDE-x2x4q"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, k8sDeploymentName(tc.pod, nil), tc.pod)
+	}
+}
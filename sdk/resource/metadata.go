@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/middleware-labs/otel/attribute"
+)
+
+// defaultMetadataTimeout is the default time allowed for a cloud detector to
+// query its metadata service. It is kept short so detection does not delay
+// start-up when the process does not run in the cloud.
+const defaultMetadataTimeout = time.Second
+
+// maxMetadataResponseSize limits the size of a metadata service response.
+const maxMetadataResponseSize = 1 << 20
+
+// errMetadataNotFound is returned when the metadata service does not define
+// a requested value.
+var errMetadataNotFound = errors.New("metadata not found")
+
+// MetadataOption configures a cloud detector that queries a metadata
+// service.
+type MetadataOption interface {
+	applyMetadata(metadataConfig) metadataConfig
+}
+
+type metadataOptionFunc func(metadataConfig) metadataConfig
+
+func (fn metadataOptionFunc) applyMetadata(cfg metadataConfig) metadataConfig {
+	return fn(cfg)
+}
+
+// metadataConfig contains the configuration of a metadata service client.
+type metadataConfig struct {
+	// endpoint is the base URL of the metadata service.
+	endpoint string
+	// timeout of the whole detection.
+	timeout time.Duration
+	// client used to send requests.
+	client *http.Client
+}
+
+func newMetadataConfig(endpoint string, opts []MetadataOption) metadataConfig {
+	cfg := metadataConfig{endpoint: endpoint, timeout: defaultMetadataTimeout}
+	for _, opt := range opts {
+		cfg = opt.applyMetadata(cfg)
+	}
+	if cfg.client == nil {
+		cfg.client = http.DefaultClient
+	}
+	cfg.endpoint = strings.TrimRight(cfg.endpoint, "/")
+	return cfg
+}
+
+// WithMetadataEndpoint sets the base URL of the metadata service queried by
+// a cloud detector. This is useful to use a proxy or, in tests, a fake
+// metadata service.
+func WithMetadataEndpoint(url string) MetadataOption {
+	return metadataOptionFunc(func(cfg metadataConfig) metadataConfig {
+		cfg.endpoint = url
+		return cfg
+	})
+}
+
+// WithMetadataTimeout sets the maximum duration of the queries a cloud
+// detector sends to its metadata service. By default, a detector gives up
+// after 1 second.
+func WithMetadataTimeout(timeout time.Duration) MetadataOption {
+	return metadataOptionFunc(func(cfg metadataConfig) metadataConfig {
+		if timeout > 0 {
+			cfg.timeout = timeout
+		}
+		return cfg
+	})
+}
+
+// WithMetadataHTTPClient sets the HTTP client a cloud detector uses to query
+// its metadata service. By default, http.DefaultClient is used.
+func WithMetadataHTTPClient(client *http.Client) MetadataOption {
+	return metadataOptionFunc(func(cfg metadataConfig) metadataConfig {
+		cfg.client = client
+		return cfg
+	})
+}
+
+// request sends a request to the path of the metadata service and returns
+// the response body.
+func (cfg metadataConfig) request(ctx context.Context, method, path string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, cfg.endpoint+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", errMetadataNotFound, path)
+	}
+	return nil, fmt.Errorf("metadata request %s %s: %s", method, path, resp.Status)
+}
+
+// requestJSON decodes the JSON response of a GET request to path into v.
+func (cfg metadataConfig) requestJSON(ctx context.Context, path string, v interface{}) error {
+	body, err := cfg.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid metadata %q: %w", path, err)
+	}
+	return nil
+}
+
+// metadataAttrs collects the attributes and errors of a cloud detector.
+type metadataAttrs struct {
+	attrs []attribute.KeyValue
+	errs  []string
+}
+
+// add adds the attribute created by f from v, if v is not empty.
+func (m *metadataAttrs) add(f func(string) attribute.KeyValue, v string) {
+	if v != "" {
+		m.attrs = append(m.attrs, f(v))
+	}
+}
+
+func (m *metadataAttrs) error(err error) {
+	m.errs = append(m.errs, err.Error())
+}
+
+// resource returns the detected resource and an ErrPartialResource error if
+// any value could not be detected.
+func (m *metadataAttrs) resource(schemaURL string) (*Resource, error) {
+	res := NewWithAttributes(schemaURL, m.attrs...)
+	if len(m.errs) == 0 {
+		return res, nil
+	}
+	return res, fmt.Errorf("%w: %s", ErrPartialResource, strings.Join(m.errs, "; "))
+}
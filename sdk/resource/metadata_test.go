@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+)
+
+// newMetadataServer returns a fake metadata service that responds to the
+// requests of routes, keyed by method and URI, with the corresponding body.
+// Requests not authorized by auth are rejected, other requests return 404.
+func newMetadataServer(t *testing.T, auth func(*http.Request) bool, routes map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auth(r) {
+			http.Error(w, "unauthorized", http.StatusBadRequest)
+			return
+		}
+		body, ok := routes[r.Method+" "+r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// withHeader returns a metadata server auth function that requires the header
+// key to be set to value.
+func withHeader(key, value string) func(*http.Request) bool {
+	return func(r *http.Request) bool { return r.Header.Get(key) == value }
+}
+
+// attrValue returns the string value of the key attribute of res.
+func attrValue(res *Resource, key attribute.Key) string {
+	v, _ := res.Set().Value(key)
+	return v.AsString()
+}
+
+func TestMetadataConfig(t *testing.T) {
+	client := &http.Client{}
+	cfg := newMetadataConfig("http://default/", []MetadataOption{
+		WithMetadataHTTPClient(client),
+		WithMetadataTimeout(-1),
+	})
+	assert.Equal(t, metadataConfig{
+		endpoint: "http://default",
+		timeout:  defaultMetadataTimeout,
+		client:   client,
+	}, cfg)
+
+	cfg = newMetadataConfig("http://default", []MetadataOption{
+		WithMetadataEndpoint("http://localhost:8080/"),
+		WithMetadataTimeout(time.Minute),
+	})
+	assert.Equal(t, metadataConfig{
+		endpoint: "http://localhost:8080",
+		timeout:  time.Minute,
+		client:   http.DefaultClient,
+	}, cfg)
+}
+
+func TestMetadataRequest(t *testing.T) {
+	srv := newMetadataServer(t, withHeader("Metadata", "true"), map[string]string{
+		"GET /value": "42",
+	})
+	cfg := newMetadataConfig(srv.URL, nil)
+	ctx := context.Background()
+
+	body, err := cfg.request(ctx, http.MethodGet, "/value", http.Header{"Metadata": {"true"}})
+	require.NoError(t, err)
+	assert.Equal(t, "42", string(body))
+
+	_, err = cfg.request(ctx, http.MethodGet, "/missing", http.Header{"Metadata": {"true"}})
+	assert.ErrorIs(t, err, errMetadataNotFound)
+
+	_, err = cfg.request(ctx, http.MethodGet, "/value", nil)
+	assert.ErrorContains(t, err, "400 Bad Request")
+	assert.NotErrorIs(t, err, errMetadataNotFound)
+}
+
+func TestMetadataTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-block
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(block) })
+
+	opts := []MetadataOption{
+		WithMetadataEndpoint(srv.URL),
+		WithMetadataTimeout(10 * time.Millisecond),
+	}
+	for name, d := range map[string]Detector{
+		"EC2":   ec2Detector{cfg: newMetadataConfig(defaultEC2Endpoint, opts)},
+		"GCP":   gcpDetector{cfg: newMetadataConfig(defaultGCPEndpoint, opts)},
+		"Azure": azureDetector{cfg: newMetadataConfig(defaultAzureEndpoint, opts)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, Empty(), res)
+		})
+	}
+}
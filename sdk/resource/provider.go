@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Provider provides a Resource that can be updated after the telemetry
+// providers using it are created.
+//
+// Detecting some resource attributes, like the ones read from a cloud
+// metadata service, can take time. A Provider allows an application to start
+// with the resource attributes known at start-up and to merge the late
+// detected attributes when they are available. Telemetry exported after the
+// update is associated with the updated Resource.
+//
+// A Provider is safe for concurrent use.
+type Provider struct {
+	res atomic.Pointer[Resource]
+
+	// mu serializes the updates of the resource and the calls to hooks.
+	mu    sync.Mutex
+	hooks []*func(*Resource)
+}
+
+// NewProvider returns a Provider of res. If res is nil, the Provider
+// initially provides an empty Resource.
+func NewProvider(res *Resource) *Provider {
+	if res == nil {
+		res = Empty()
+	}
+	p := &Provider{}
+	p.res.Store(res)
+	return p
+}
+
+// Resource returns the current Resource of p.
+func (p *Provider) Resource() *Resource {
+	return p.res.Load()
+}
+
+// Merge merges r into the current Resource of p, with the attributes of r
+// taking precedence, and calls the hooks registered with OnChange if the
+// Resource changed. If r cannot be merged, the current Resource is kept and
+// an error is returned.
+func (p *Provider) Merge(r *Resource) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cur := p.res.Load()
+	merged, err := Merge(cur, r)
+	if err != nil {
+		return err
+	}
+	if merged.Equal(cur) && merged.SchemaURL() == cur.SchemaURL() {
+		return nil
+	}
+	p.res.Store(merged)
+	for _, hook := range p.hooks {
+		(*hook)(merged)
+	}
+	return nil
+}
+
+// Detect creates a Resource with opts, as New does, and merges it into the
+// current Resource of p. Detect blocks until all the detectors have returned,
+// it is meant to be called in a goroutine to not delay the start of an
+// application:
+//
+//	p := resource.NewProvider(res)
+//	go func() {
+//		if err := p.Detect(ctx, resource.WithGCP()); err != nil {
+//			otel.Handle(err)
+//		}
+//	}()
+//
+// If a detector returns an ErrPartialResource error, the attributes it
+// detected are merged. The errors of the detectors are returned.
+func (p *Provider) Detect(ctx context.Context, opts ...Option) error {
+	r, err := New(ctx, opts...)
+	if mErr := p.Merge(r); mErr != nil {
+		if err == nil {
+			return mErr
+		}
+		return detectErrs{err, mErr}
+	}
+	return err
+}
+
+// OnChange registers f to be called with the new Resource every time the
+// Resource of p changes. Hooks are called in the order they are registered.
+// The returned function unregisters f.
+//
+// The calls to f are serialized and made synchronously by the Merge or
+// Detect call that changed the Resource. Therefore, f must not call Merge or
+// Detect.
+func (p *Provider) OnChange(f func(*Resource)) (unregister func()) {
+	hook := &f
+	p.mu.Lock()
+	p.hooks = append(p.hooks, hook)
+	p.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			for i, h := range p.hooks {
+				if h == hook {
+					p.hooks = append(p.hooks[:i:i], p.hooks[i+1:]...)
+					break
+				}
+			}
+			p.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+func TestNewProvider(t *testing.T) {
+	assert.Equal(t, resource.Empty(), resource.NewProvider(nil).Resource())
+
+	res := resource.NewSchemaless(attribute.String("k", "v"))
+	assert.Same(t, res, resource.NewProvider(res).Resource())
+}
+
+func TestProviderMerge(t *testing.T) {
+	p := resource.NewProvider(resource.NewWithAttributes("https://example.com/1.0.0",
+		attribute.String("k1", "v1"),
+		attribute.String("k2", "v2"),
+	))
+
+	var got []*resource.Resource
+	p.OnChange(func(r *resource.Resource) { got = append(got, r) })
+
+	require.NoError(t, p.Merge(resource.NewSchemaless(attribute.String("k2", "late"))))
+	want := resource.NewWithAttributes("https://example.com/1.0.0",
+		attribute.String("k1", "v1"),
+		attribute.String("k2", "late"),
+	)
+	assert.Equal(t, want, p.Resource())
+	assert.Equal(t, []*resource.Resource{want}, got)
+
+	// Merging known attributes is not a change.
+	require.NoError(t, p.Merge(resource.NewSchemaless(attribute.String("k1", "v1"))))
+	assert.Len(t, got, 1)
+
+	err := p.Merge(resource.NewWithAttributes("https://example.com/2.0.0", attribute.String("k3", "v3")))
+	assert.Error(t, err)
+	assert.Equal(t, want, p.Resource(), "resource changed on error")
+	assert.Len(t, got, 1)
+}
+
+func TestProviderOnChange(t *testing.T) {
+	p := resource.NewProvider(nil)
+
+	var calls []string
+	unregister := p.OnChange(func(*resource.Resource) { calls = append(calls, "first") })
+	p.OnChange(func(*resource.Resource) { calls = append(calls, "second") })
+
+	require.NoError(t, p.Merge(resource.NewSchemaless(attribute.String("k", "1"))))
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	unregister()
+	unregister()
+	require.NoError(t, p.Merge(resource.NewSchemaless(attribute.String("k", "2"))))
+	assert.Equal(t, []string{"first", "second", "second"}, calls)
+}
+
+func TestProviderDetect(t *testing.T) {
+	p := resource.NewProvider(resource.NewSchemaless(attribute.String("k", "v")))
+
+	errDetect := errors.New("detection failed")
+	err := p.Detect(context.Background(),
+		resource.WithAttributes(attribute.String("late", "v")),
+		resource.WithDetectors(resource.StringDetector("", "partial", func() (string, error) {
+			return "", errDetect
+		})),
+	)
+	assert.ErrorIs(t, err, errDetect)
+	assert.Equal(t, resource.NewSchemaless(
+		attribute.String("k", "v"),
+		attribute.String("late", "v"),
+	), p.Resource())
+}
+
+func TestProviderConcurrentMerge(t *testing.T) {
+	p := resource.NewProvider(nil)
+
+	var changes int
+	p.OnChange(func(*resource.Resource) { changes++ })
+
+	var wg sync.WaitGroup
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, k := range keys {
+		wg.Add(1)
+		go func(k string) {
+			defer wg.Done()
+			_ = p.Resource()
+			assert.NoError(t, p.Merge(resource.NewSchemaless(attribute.String(k, "v"))))
+		}(k)
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(keys), p.Resource().Len())
+	assert.Equal(t, len(keys), changes)
+}
@@ -51,7 +51,7 @@ func New(ctx context.Context, opts ...Option) (*Resource, error) {
 	}
 
 	r := &Resource{schemaURL: cfg.schemaURL}
-	return r, detect(ctx, r, cfg.detectors)
+	return r, detect(ctx, r, cfg.detectors, cfg.translator)
 }
 
 // NewWithAttributes creates a resource from attrs and associates the resource with a
@@ -156,7 +156,8 @@ func (r *Resource) Equal(eq *Resource) bool {
 // The SchemaURL of the resources will be merged according to the spec rules:
 // https://github.com/open-telemetry/opentelemetry-specification/blob/bad49c714a62da5493f2d1d9bafd7ebe8c8ce7eb/specification/resource/sdk.md#merge
 // If the resources have different non-empty schemaURL an empty resource and an error
-// will be returned.
+// will be returned. Use MergeWithSchemas to translate the resources to the same
+// schema instead.
 func Merge(a, b *Resource) (*Resource, error) {
 	if a == nil && b == nil {
 		return Empty(), nil
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "github.com/middleware-labs/otel/sdk/resource"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/middleware-labs/otel/attribute"
+)
+
+// SchemaTranslator translates resource attributes between versions of a
+// telemetry schema.
+//
+// The Registry from the github.com/middleware-labs/otel/schema/translation
+// package implements this interface and knows the OpenTelemetry schema files
+// by default.
+type SchemaTranslator interface {
+	// TranslateResource returns attrs, defined by the schema identified by
+	// the schema URL from, translated to the schema identified by the schema
+	// URL to.
+	TranslateResource(from, to string, attrs []attribute.KeyValue) ([]attribute.KeyValue, error)
+}
+
+// MergeWithSchemas creates a new resource by combining resource a and b in
+// the same way as Merge.
+//
+// Unlike Merge, if a and b have different non-empty schema URLs of the same
+// schema family, the resource with the older schema version is translated to
+// the newer one using t before they are combined. The returned resource has
+// the newer schema URL.
+//
+// An empty resource and an error are returned if the schema URLs are not
+// valid versioned schema URLs, belong to different schema families, or if t
+// fails to translate the resource.
+func MergeWithSchemas(a, b *Resource, t SchemaTranslator) (*Resource, error) {
+	if a == nil || b == nil || t == nil ||
+		a.schemaURL == "" || b.schemaURL == "" || a.schemaURL == b.schemaURL {
+		return Merge(a, b)
+	}
+
+	aFamily, aVer, err := parseSchemaURL(a.schemaURL)
+	if err != nil {
+		return Empty(), err
+	}
+	bFamily, bVer, err := parseSchemaURL(b.schemaURL)
+	if err != nil {
+		return Empty(), err
+	}
+	if aFamily != bFamily {
+		return Empty(), fmt.Errorf("%w: %s and %s belong to different schema families", errMergeConflictSchemaURL, a.schemaURL, b.schemaURL)
+	}
+
+	if aVer.less(bVer) {
+		a, err = translate(t, a, b.schemaURL)
+	} else {
+		b, err = translate(t, b, a.schemaURL)
+	}
+	if err != nil {
+		return Empty(), err
+	}
+	return Merge(a, b)
+}
+
+func translate(t SchemaTranslator, r *Resource, schemaURL string) (*Resource, error) {
+	attrs, err := t.TranslateResource(r.schemaURL, schemaURL, r.Attributes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot translate resource from %s to %s: %w", r.schemaURL, schemaURL, err)
+	}
+	return NewWithAttributes(schemaURL, attrs...), nil
+}
+
+// schemaVersion is the MAJOR.MINOR.PATCH version of a schema.
+type schemaVersion [3]uint64
+
+func (v schemaVersion) less(o schemaVersion) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// parseSchemaURL splits schemaURL into its schema family, everything before
+// the last path segment, and its version, the last path segment.
+func parseSchemaURL(schemaURL string) (string, schemaVersion, error) {
+	var ver schemaVersion
+	i := strings.LastIndex(schemaURL, "/")
+	if i < 0 {
+		return "", ver, fmt.Errorf("invalid schema URL %q: missing version", schemaURL)
+	}
+	parts := strings.Split(schemaURL[i+1:], ".")
+	if len(parts) != len(ver) {
+		return "", ver, fmt.Errorf("invalid schema URL %q: version is not MAJOR.MINOR.PATCH", schemaURL)
+	}
+	for j, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return "", ver, fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+		}
+		ver[j] = n
+	}
+	return schemaURL[:i], ver, nil
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/sdk/resource"
+)
+
+const (
+	schemaV1 = "https://example.com/schemas/1.9.0"
+	schemaV2 = "https://example.com/schemas/1.10.0"
+)
+
+// renameTranslator renames the "old" attribute to "new" when translating
+// from schemaV1 to schemaV2.
+type renameTranslator struct {
+	calls int
+}
+
+func (t *renameTranslator) TranslateResource(from, to string, attrs []attribute.KeyValue) ([]attribute.KeyValue, error) {
+	t.calls++
+	if from != schemaV1 || to != schemaV2 {
+		return nil, errors.New("unsupported translation")
+	}
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if kv.Key == "old" {
+			kv.Key = "new"
+		}
+		out = append(out, kv)
+	}
+	return out, nil
+}
+
+func TestMergeWithSchemas(t *testing.T) {
+	older := resource.NewWithAttributes(schemaV1, attribute.String("old", "a"), attribute.String("k", "older"))
+	newer := resource.NewWithAttributes(schemaV2, attribute.String("k", "newer"))
+
+	tr := &renameTranslator{}
+	got, err := resource.MergeWithSchemas(older, newer, tr)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(schemaV2,
+		attribute.String("new", "a"),
+		attribute.String("k", "newer"),
+	), got)
+
+	// The order of the resources only determines which values win.
+	got, err = resource.MergeWithSchemas(newer, older, tr)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(schemaV2,
+		attribute.String("new", "a"),
+		attribute.String("k", "older"),
+	), got)
+	assert.Equal(t, 2, tr.calls)
+}
+
+func TestMergeWithSchemasNoTranslation(t *testing.T) {
+	tr := &renameTranslator{}
+	a := resource.NewWithAttributes(schemaV1, attribute.String("old", "a"))
+	b := resource.NewSchemaless(attribute.String("k", "v"))
+
+	got, err := resource.MergeWithSchemas(a, b, tr)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(schemaV1,
+		attribute.String("old", "a"),
+		attribute.String("k", "v"),
+	), got)
+	assert.Equal(t, 0, tr.calls)
+}
+
+func TestMergeWithSchemasErrors(t *testing.T) {
+	tr := &renameTranslator{}
+	tests := []struct {
+		name string
+		a, b *resource.Resource
+	}{
+		{
+			name: "DifferentFamily",
+			a:    resource.NewWithAttributes(schemaV1, attribute.String("k", "v")),
+			b:    resource.NewWithAttributes("https://other.com/schemas/1.10.0", attribute.String("k", "v")),
+		},
+		{
+			name: "InvalidVersion",
+			a:    resource.NewWithAttributes(schemaV1, attribute.String("k", "v")),
+			b:    resource.NewWithAttributes("https://example.com/schemas/latest", attribute.String("k", "v")),
+		},
+		{
+			name: "TranslationFailure",
+			a:    resource.NewWithAttributes("https://example.com/schemas/1.8.0", attribute.String("k", "v")),
+			b:    resource.NewWithAttributes(schemaV2, attribute.String("k", "v")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resource.MergeWithSchemas(tc.a, tc.b, tr)
+			assert.Error(t, err)
+			assert.Equal(t, resource.Empty(), got)
+		})
+	}
+}
+
+func TestNewWithSchemaTranslator(t *testing.T) {
+	res, err := resource.New(context.Background(),
+		resource.WithSchemaTranslator(&renameTranslator{}),
+		resource.WithDetectors(
+			resource.StringDetector(schemaV1, "old", func() (string, error) { return "a", nil }),
+			resource.StringDetector(schemaV2, "k", func() (string, error) { return "b", nil }),
+		),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(schemaV2,
+		attribute.String("new", "a"),
+		attribute.String("k", "b"),
+	), res)
+
+	_, err = resource.New(context.Background(),
+		resource.WithDetectors(
+			resource.StringDetector(schemaV1, "old", func() (string, error) { return "a", nil }),
+			resource.StringDetector(schemaV2, "k", func() (string, error) { return "b", nil }),
+		),
+	)
+	assert.Error(t, err, "conflicting schema URLs without a translator")
+}
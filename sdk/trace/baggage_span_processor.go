@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "github.com/middleware-labs/otel/sdk/trace"
+
+import (
+	"context"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
+)
+
+// baggageSpanProcessor is a SpanProcessor that copies baggage members from
+// the parent context onto started spans as attributes.
+type baggageSpanProcessor struct {
+	filter func(baggage.Member) bool
+}
+
+var _ SpanProcessor = (*baggageSpanProcessor)(nil)
+
+// NewBaggageSpanProcessor returns a SpanProcessor that sets the members of
+// the baggage contained in the parent context of a span as attributes of the
+// span when it is started. The member key is used as the attribute key and
+// the member value as the string attribute value.
+//
+// Only members filter returns true for are copied. If filter is nil, no member
+// is copied. To copy all members, use a filter always returning true.
+func NewBaggageSpanProcessor(filter func(baggage.Member) bool) SpanProcessor {
+	return &baggageSpanProcessor{filter: filter}
+}
+
+// OnStart sets the selected baggage members of parent as attributes of s.
+func (bsp *baggageSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	if bsp.filter == nil {
+		return
+	}
+	members := baggage.FromContext(parent).Members()
+	if len(members) == 0 {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(members))
+	for _, m := range members {
+		if bsp.filter(m) {
+			attrs = append(attrs, attribute.String(m.Key(), m.Value()))
+		}
+	}
+	s.SetAttributes(attrs...)
+}
+
+// OnEnd does nothing.
+func (bsp *baggageSpanProcessor) OnEnd(ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (bsp *baggageSpanProcessor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush does nothing.
+func (bsp *baggageSpanProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/baggage"
+)
+
+func TestBaggageSpanProcessor(t *testing.T) {
+	user, err := baggage.NewMember("user", "alice")
+	require.NoError(t, err)
+	tenant, err := baggage.NewMember("tenant", "t1")
+	require.NoError(t, err)
+	bag, err := baggage.New(user, tenant)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	tests := []struct {
+		name   string
+		filter func(baggage.Member) bool
+		want   []attribute.KeyValue
+	}{
+		{
+			name: "nil filter",
+		},
+		{
+			name:   "all members",
+			filter: func(baggage.Member) bool { return true },
+			want: []attribute.KeyValue{
+				attribute.String("tenant", "t1"),
+				attribute.String("user", "alice"),
+			},
+		},
+		{
+			name:   "filtered",
+			filter: func(m baggage.Member) bool { return m.Key() == "user" },
+			want:   []attribute.KeyValue{attribute.String("user", "alice")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			te := NewTestExporter()
+			tp := NewTracerProvider(
+				WithSpanProcessor(NewBaggageSpanProcessor(tc.filter)),
+				WithSyncer(te),
+			)
+			_, span := tp.Tracer(t.Name()).Start(ctx, "span")
+			span.End()
+
+			require.Len(t, te.Spans(), 1)
+			assert.ElementsMatch(t, tc.want, te.Spans()[0].Attributes())
+		})
+	}
+}
+
+func TestBaggageSpanProcessorNoBaggage(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSpanProcessor(NewBaggageSpanProcessor(func(baggage.Member) bool { return true })),
+		WithSyncer(te),
+	)
+	_, span := tp.Tracer(t.Name()).Start(context.Background(), "span")
+	span.End()
+
+	require.Len(t, te.Spans(), 1)
+	assert.Empty(t, te.Spans()[0].Attributes())
+}
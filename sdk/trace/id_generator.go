@@ -68,6 +68,13 @@ func (gen *randomIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.
 	return tid, sid
 }
 
+// isRandomIDGenerator returns if the trace IDs generated by gen are random as
+// defined by the W3C Trace Context Level 2 specification.
+func isRandomIDGenerator(gen IDGenerator) bool {
+	_, ok := gen.(*randomIDGenerator)
+	return ok
+}
+
 func defaultIDGenerator() IDGenerator {
 	gen := &randomIDGenerator{}
 	var rngSeed int64
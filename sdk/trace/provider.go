@@ -51,6 +51,9 @@ type tracerProviderConfig struct {
 
 	// resource contains attributes representing an entity that produces telemetry.
 	resource *resource.Resource
+
+	// resourceProvider provides the resource if it can be updated.
+	resourceProvider *resource.Provider
 }
 
 // MarshalLog is the marshaling function used by the logging system to represent this exporter.
@@ -84,7 +87,7 @@ type TracerProvider struct {
 	sampler     Sampler
 	idGenerator IDGenerator
 	spanLimits  SpanLimits
-	resource    *resource.Resource
+	resource    *resource.Provider
 }
 
 var _ trace.TracerProvider = &TracerProvider{}
@@ -116,7 +119,7 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 		sampler:     o.sampler,
 		idGenerator: o.idGenerator,
 		spanLimits:  o.spanLimits,
-		resource:    o.resource,
+		resource:    o.resourceProvider,
 	}
 	global.Info("TracerProvider created", "config", o)
 
@@ -365,6 +368,22 @@ func WithResource(r *resource.Resource) TracerProviderOption {
 	})
 }
 
+// WithResourceProvider returns a TracerProviderOption that will configure
+// the Resource provided by p as a TracerProvider's Resource. Unlike with
+// WithResource, the Resource can be updated after the TracerProvider is
+// created, see resource.Provider. Spans ended after an update are associated
+// with the updated Resource.
+//
+// The Resource provided by p is used as is, it is not merged with the
+// resource.Environment() Resource. This option takes precedence over
+// WithResource.
+func WithResourceProvider(p *resource.Provider) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.resourceProvider = p
+		return cfg
+	})
+}
+
 // WithIDGenerator returns a TracerProviderOption that will configure the
 // IDGenerator g as a TracerProvider's IDGenerator. The configured IDGenerator
 // is used by the Tracers the TracerProvider creates to generate new Span and
@@ -493,8 +512,13 @@ func ensureValidTracerProviderConfig(cfg tracerProviderConfig) tracerProviderCon
 	if cfg.idGenerator == nil {
 		cfg.idGenerator = defaultIDGenerator()
 	}
-	if cfg.resource == nil {
-		cfg.resource = resource.Default()
+	if cfg.resourceProvider != nil {
+		cfg.resource = cfg.resourceProvider.Resource()
+	} else {
+		if cfg.resource == nil {
+			cfg.resource = resource.Default()
+		}
+		cfg.resourceProvider = resource.NewProvider(cfg.resource)
 	}
 	return cfg
 }
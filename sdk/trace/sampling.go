@@ -44,10 +44,14 @@ type Sampler interface {
 type SamplingParameters struct {
 	ParentContext context.Context
 	TraceID       trace.TraceID
-	Name          string
-	Kind          trace.SpanKind
-	Attributes    []attribute.KeyValue
-	Links         []trace.Link
+	// TraceFlags are the flags of the span being sampled that are known
+	// before the sampling decision is made. The random flag is set if
+	// TraceID is known to be random.
+	TraceFlags trace.TraceFlags
+	Name       string
+	Kind       trace.SpanKind
+	Attributes []attribute.KeyValue
+	Links      []trace.Link
 }
 
 // SamplingDecision indicates whether a span is dropped, recorded and/or sampled.
@@ -81,8 +85,7 @@ type traceIDRatioSampler struct {
 
 func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult {
 	psc := trace.SpanContextFromContext(p.ParentContext)
-	x := binary.BigEndian.Uint64(p.TraceID[8:16]) >> 1
-	if x < ts.traceIDUpperBound {
+	if traceIDRandomness(p) < ts.traceIDUpperBound {
 		return SamplingResult{
 			Decision:   RecordAndSample,
 			Tracestate: psc.TraceState(),
@@ -94,6 +97,25 @@ func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult
 	}
 }
 
+// traceIDRandomness returns the 63 bit value of the trace ID being sampled
+// compared to the upper bound of the sampler.
+//
+// If the random flag is set, either on the sampling parameters or on a parent
+// span context from the same trace, only the 56 rightmost bits of the trace
+// ID, which are then known to be random, are used. Otherwise, the 63
+// rightmost bits are used.
+func traceIDRandomness(p SamplingParameters) uint64 {
+	x := binary.BigEndian.Uint64(p.TraceID[8:16])
+	random := p.TraceFlags.IsRandom()
+	if psc := trace.SpanContextFromContext(p.ParentContext); psc.TraceID() == p.TraceID {
+		random = random || psc.TraceFlags().IsRandom()
+	}
+	if random {
+		return x << 8 >> 1
+	}
+	return x >> 1
+}
+
 func (ts traceIDRatioSampler) Description() string {
 	return ts.description
 }
@@ -103,6 +125,10 @@ func (ts traceIDRatioSampler) Description() string {
 // parent trace's `SampledFlag`, the `TraceIDRatioBased` sampler should be used
 // as a delegate of a `Parent` sampler.
 //
+// If the trace ID is known to be random, as indicated by the W3C Trace
+// Context Level 2 random flag, only its 56 random rightmost bits are used as
+// the source of randomness.
+//
 //nolint:revive // revive complains about stutter of `trace.TraceIDRatioBased`
 func TraceIDRatioBased(fraction float64) Sampler {
 	if fraction >= 1 {
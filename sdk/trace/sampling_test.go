@@ -210,6 +210,30 @@ func TestTraceIdRatioSamplesInclusively(t *testing.T) {
 	}
 }
 
+func TestTraceIDRatioBasedRandomFlag(t *testing.T) {
+	// The 56 random bits of a random trace ID are all zero, any non-zero
+	// ratio samples it. Otherwise, its high ninth byte drops it.
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xff}
+	sampler := TraceIDRatioBased(1e-9)
+
+	params := SamplingParameters{TraceID: traceID, TraceFlags: trace.FlagsRandom}
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision, "random trace ID")
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsRandom,
+	})
+	params = SamplingParameters{
+		ParentContext: trace.ContextWithSpanContext(context.Background(), parent),
+		TraceID:       traceID,
+	}
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision, "random parent")
+
+	params = SamplingParameters{TraceID: traceID}
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision, "non-random trace ID")
+}
+
 func TestTracestateIsPassed(t *testing.T) {
 	testCases := []struct {
 		name    string
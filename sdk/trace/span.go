@@ -628,7 +628,17 @@ func (s *recordingSpan) InstrumentationLibrary() instrumentation.Library {
 func (s *recordingSpan) Resource() *resource.Resource {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return s.tracer.provider.resource
+	return s.tracer.provider.resource.Resource()
+}
+
+// AddLink adds link to the span after its creation. Links with an invalid
+// SpanContext, or added after the span has ended, are dropped.
+//
+// The trace.Span interface does not allow adding links after a span is
+// started. This method is used, through an interface assertion, by bridges
+// from tracing APIs that allow it.
+func (s *recordingSpan) AddLink(link trace.Link) {
+	s.addLink(link)
 }
 
 func (s *recordingSpan) addLink(link trace.Link) {
@@ -702,7 +712,7 @@ func (s *recordingSpan) snapshot() ReadOnlySpan {
 	sd.instrumentationScope = s.tracer.instrumentationScope
 	sd.name = s.name
 	sd.parent = s.parent
-	sd.resource = s.tracer.provider.resource
+	sd.resource = s.tracer.provider.resource.Resource()
 	sd.spanContext = s.spanContext
 	sd.spanKind = s.spanKind
 	sd.startTime = s.startTime
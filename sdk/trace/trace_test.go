@@ -783,6 +783,43 @@ func TestLinks(t *testing.T) {
 	require.Len(t, sdkspan.Links(), 1)
 }
 
+func TestAddLinkAfterStart(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+
+	sc1 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{3}})
+	l1 := trace.Link{SpanContext: sc1, Attributes: []attribute.KeyValue{attribute.String("key1", "value1")}}
+
+	span := startSpan(tp, "AddLink")
+	la, ok := span.(interface{ AddLink(trace.Link) })
+	require.True(t, ok, "span does not support adding links")
+	la.AddLink(l1)
+	la.AddLink(trace.Link{SpanContext: trace.SpanContext{}})
+
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Links added after the span ended are dropped.
+	la.AddLink(l1)
+
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		parent:               sc.WithRemote(true),
+		name:                 "span0",
+		links:                []Link{{l1.SpanContext, l1.Attributes, 0}},
+		spanKind:             trace.SpanKindInternal,
+		instrumentationScope: instrumentation.Scope{Name: "AddLink"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("AddLink: -got +want %s", diff)
+	}
+	require.Len(t, span.(*recordingSpan).Links(), 1)
+}
+
 func TestLinksOverLimit(t *testing.T) {
 	te := NewTestExporter()
 
@@ -1449,6 +1486,35 @@ func TestWithResource(t *testing.T) {
 	}
 }
 
+func TestWithResourceProvider(t *testing.T) {
+	initial := resource.NewSchemaless(attribute.String("rk1", "rv1"))
+	p := resource.NewProvider(initial)
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithResource(resource.NewSchemaless(attribute.String("ignored", "v"))),
+		WithResourceProvider(p),
+	)
+	tr := tp.Tracer("WithResourceProvider")
+
+	_, before := tr.Start(context.Background(), "before")
+	_, during := tr.Start(context.Background(), "during")
+	before.End()
+	assert.Equal(t, initial, during.(ReadOnlySpan).Resource())
+
+	late := resource.NewSchemaless(attribute.String("rk2", "rv2"))
+	require.NoError(t, p.Merge(late))
+	during.End()
+
+	got, ok := te.GetSpan("before")
+	require.True(t, ok)
+	assert.Equal(t, initial, got.Resource())
+
+	got, ok = te.GetSpan("during")
+	require.True(t, ok)
+	assert.Equal(t, mergeResource(t, initial, late), got.Resource())
+}
+
 func TestWithInstrumentationVersionAndSchema(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -1963,6 +2029,23 @@ func TestWithIDGenerator(t *testing.T) {
 	}
 }
 
+func TestRandomTraceFlag(t *testing.T) {
+	tp := NewTracerProvider()
+	ctx, root := tp.Tracer("TestRandomTraceFlag").Start(context.Background(), "root")
+	assert.True(t, root.SpanContext().TraceFlags().IsRandom(), "root span from random ID generator")
+
+	_, child := tp.Tracer("TestRandomTraceFlag").Start(ctx, "child")
+	assert.True(t, child.SpanContext().TraceFlags().IsRandom(), "child span inherits random flag")
+
+	tp = NewTracerProvider(WithIDGenerator(&testIDGenerator{traceID: 1, spanID: 1}))
+	_, custom := tp.Tracer("TestRandomTraceFlag").Start(context.Background(), "custom")
+	assert.False(t, custom.SpanContext().TraceFlags().IsRandom(), "root span from custom ID generator")
+
+	remote := sc.WithTraceFlags(trace.FlagsSampled)
+	_, remoteChild := tp.Tracer("TestRandomTraceFlag").Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), "remote-child")
+	assert.False(t, remoteChild.SpanContext().TraceFlags().IsRandom(), "child of non-random remote parent")
+}
+
 func TestEmptyRecordingSpanAttributes(t *testing.T) {
 	assert.Nil(t, (&recordingSpan{}).Attributes())
 }
@@ -85,8 +85,12 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 	// on a unique span ID, even if the Span is non-recording.
 	var tid trace.TraceID
 	var sid trace.SpanID
+	flags := psc.TraceFlags()
 	if !psc.TraceID().IsValid() {
 		tid, sid = tr.provider.idGenerator.NewIDs(ctx)
+		// A new trace ID is only known to be random if the generator
+		// guarantees it.
+		flags = flags.WithRandom(isRandomIDGenerator(tr.provider.idGenerator))
 	} else {
 		tid = psc.TraceID()
 		sid = tr.provider.idGenerator.NewSpanID(ctx, tid)
@@ -95,6 +99,7 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 	samplingResult := tr.provider.sampler.ShouldSample(SamplingParameters{
 		ParentContext: ctx,
 		TraceID:       tid,
+		TraceFlags:    flags &^ trace.FlagsSampled,
 		Name:          name,
 		Kind:          config.SpanKind(),
 		Attributes:    config.Attributes(),
@@ -106,11 +111,7 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 		SpanID:     sid,
 		TraceState: samplingResult.Tracestate,
 	}
-	if isSampled(samplingResult) {
-		scc.TraceFlags = psc.TraceFlags() | trace.FlagsSampled
-	} else {
-		scc.TraceFlags = psc.TraceFlags() &^ trace.FlagsSampled
-	}
+	scc.TraceFlags = flags.WithSampled(isSampled(samplingResult))
 	sc := trace.NewSpanContext(scc)
 
 	if !isRecording(samplingResult) {
@@ -0,0 +1,361 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/semconv/internal/v2"
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+)
+
+// DBConv are the database semantic convention attributes defined for a
+// version of the OpenTelemetry specification.
+type DBConv struct {
+	NetConv *NetConv
+
+	DBNameKey        attribute.Key
+	DBOperationKey   attribute.Key
+	DBSQLTableKey    attribute.Key
+	DBStatementKey   attribute.Key
+	DBSystemKey      attribute.Key
+	DBSystemOtherSQL attribute.KeyValue
+	DBUserKey        attribute.Key
+}
+
+// dbSystems are the "db.system" values of the database/sql drivers, by the
+// name they are registered with.
+var dbSystems = map[string]string{
+	"clickhouse": "clickhouse",
+	"cockroach":  "cockroachdb",
+	"godror":     "oracle",
+	"mssql":      "mssql",
+	"mysql":      "mysql",
+	"oracle":     "oracle",
+	"pgx":        "postgresql",
+	"postgres":   "postgresql",
+	"spanner":    "spanner",
+	"sqlite":     "sqlite",
+	"sqlite3":    "sqlite",
+	"sqlserver":  "mssql",
+}
+
+// Client returns attributes for a connection to a database made with the
+// driverName database/sql driver and the dsn data source name, the arguments
+// of sql.Open. The "db.system" attribute is always returned, with the
+// "other_sql" value for unknown drivers. The following attributes are
+// returned if dsn defines the related values: "db.name", "db.user",
+// "net.peer.name", and "net.peer.port". The password of dsn is never
+// returned.
+func (c *DBConv) Client(driverName, dsn string) []attribute.KeyValue {
+	system, ok := dbSystems[driverName]
+	d := parseDSN(system, dsn)
+
+	attrs := make([]attribute.KeyValue, 0, 3+nonZeroStr(d.name, d.user))
+	if ok {
+		attrs = append(attrs, c.DBSystemKey.String(system))
+	} else {
+		attrs = append(attrs, c.DBSystemOtherSQL)
+	}
+	if d.name != "" {
+		attrs = append(attrs, c.DBNameKey.String(d.name))
+	}
+	if d.user != "" {
+		attrs = append(attrs, c.DBUserKey.String(d.user))
+	}
+	return append(attrs, c.NetConv.Peer(d.address)...)
+}
+
+// Query returns attributes for the SQL statement of a database query. The
+// "db.statement" attribute is always returned, and the "db.operation" and
+// "db.sql.table" attributes if they are found in statement. Statements must
+// be sanitized of sensitive values before they are passed.
+func (c *DBConv) Query(statement string) []attribute.KeyValue {
+	op, table := parseSQL(statement)
+	attrs := make([]attribute.KeyValue, 0, 1+nonZeroStr(op, table))
+	attrs = append(attrs, c.DBStatementKey.String(statement))
+	if op != "" {
+		attrs = append(attrs, c.DBOperationKey.String(op))
+	}
+	if table != "" {
+		attrs = append(attrs, c.DBSQLTableKey.String(table))
+	}
+	return attrs
+}
+
+// SpanName returns the name of the span of the SQL statement executed on
+// the name database: "<db.operation> <db.name>.<db.sql.table>", or
+// "<db.operation> <db.name>" if the statement does not operate on a single
+// table. The name of the database is used if the operation is unknown, and
+// "DB" if it is also empty.
+func (c *DBConv) SpanName(statement, name string) string {
+	op, table := parseSQL(statement)
+	target := name
+	if table != "" {
+		if target != "" {
+			target += "."
+		}
+		target += table
+	}
+	switch {
+	case op != "" && target != "":
+		return op + " " + target
+	case op != "":
+		return op
+	case name != "":
+		return name
+	default:
+		return "DB"
+	}
+}
+
+// Status returns a span status code and message for the error returned by
+// a database call. The sql.ErrNoRows error is not returned as an error.
+func (c *DBConv) Status(err error) (codes.Code, string) {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return codes.Unset, ""
+	}
+	return codes.Error, err.Error()
+}
+
+// dsn holds the values of a data source name reported as attributes.
+type dsn struct {
+	name, user, address string
+}
+
+// mysqlDSN matches the data source names of the github.com/go-sql-driver/mysql
+// driver: [user[:password]@][net[(addr)]]/dbname[?params].
+var mysqlDSN = regexp.MustCompile(`^(?:([^:@]*)(?::.*)?@)?(?:([^(/]*)(?:\(([^)]*)\))?)?/([^?]*)`)
+
+// parseDSN returns the values of the data source name s of the system
+// database. The format of s is chosen by the system: the MySQL driver format
+// for MySQL, the file of the database for SQLite, and URLs or key value pairs
+// (separated by spaces or semicolons) for the other databases.
+func parseDSN(system, s string) dsn {
+	s = strings.TrimSpace(s)
+	switch system {
+	case "sqlite":
+		name := strings.TrimPrefix(s, "file:")
+		if i := strings.IndexByte(name, '?'); i >= 0 {
+			name = name[:i]
+		}
+		return dsn{name: name}
+	case "mysql":
+		return parseMySQLDSN(s)
+	}
+
+	switch {
+	case strings.Contains(s, "://"):
+		return parseURLDSN(s)
+	case strings.Contains(s, "="):
+		return parseKeyValueDSN(s)
+	}
+	return dsn{}
+}
+
+func parseMySQLDSN(s string) dsn {
+	m := mysqlDSN.FindStringSubmatch(s)
+	if m == nil {
+		return dsn{}
+	}
+	d := dsn{user: m[1], name: m[4]}
+	// The address of Unix domain sockets is not a peer address.
+	if m[2] == "" || strings.HasPrefix(m[2], "tcp") {
+		d.address = m[3]
+	}
+	return d
+}
+
+func parseURLDSN(s string) dsn {
+	u, err := url.Parse(s)
+	if err != nil {
+		return dsn{}
+	}
+	d := dsn{
+		name:    strings.TrimPrefix(u.Path, "/"),
+		address: u.Host,
+	}
+	if u.User != nil {
+		d.user = u.User.Username()
+	}
+	for _, key := range []string{"database", "dbname"} {
+		if v := u.Query().Get(key); v != "" {
+			d.name = v
+		}
+	}
+	return d
+}
+
+// parseKeyValueDSN parses the key value pairs of PostgreSQL connection
+// strings, and of SQL Server and ODBC connection strings.
+func parseKeyValueDSN(s string) dsn {
+	var (
+		d          dsn
+		host, port string
+	)
+	for _, kv := range splitKeyValues(s) {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(strings.TrimSpace(v), `'"`)
+		switch strings.ToLower(strings.TrimSpace(k)) {
+		case "host", "server", "data source", "address", "addr":
+			// SQL Server separates the port with a comma.
+			host = strings.Replace(strings.TrimPrefix(v, "tcp:"), ",", ":", 1)
+		case "port":
+			port = v
+		case "dbname", "database", "initial catalog":
+			d.name = v
+		case "user", "user id", "uid", "username":
+			d.user = v
+		}
+	}
+	// PostgreSQL hosts starting with a slash are Unix domain socket
+	// directories, not peer addresses.
+	if strings.HasPrefix(host, "/") {
+		return d
+	}
+	d.address = host
+	if port != "" && host != "" && !strings.Contains(host, ":") {
+		if _, err := strconv.Atoi(port); err == nil {
+			d.address = net.JoinHostPort(host, port)
+		}
+	}
+	return d
+}
+
+// splitKeyValues splits s at semicolons if it has any, otherwise at spaces
+// outside of single quoted values.
+func splitKeyValues(s string) []string {
+	if strings.Contains(s, ";") {
+		return strings.Split(s, ";")
+	}
+	var (
+		fields []string
+		quoted bool
+		start  int
+	)
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			fields = append(fields, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(fields, s[start:])
+}
+
+// sqlTableKeywords are the keywords followed by the table of the statements
+// operating on a single table, by operation.
+var sqlTableKeywords = map[string]string{
+	"SELECT":  "FROM",
+	"DELETE":  "FROM",
+	"INSERT":  "INTO",
+	"REPLACE": "INTO",
+	"UPDATE":  "",
+}
+
+// parseSQL returns the operation of the SQL statement, its first keyword in
+// uppercase, and the table it operates on if it is a simple SELECT, INSERT,
+// UPDATE, or DELETE statement on a single table.
+func parseSQL(statement string) (op, table string) {
+	words := strings.FieldsFunc(stripSQLComments(statement), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ';'
+	})
+	if len(words) == 0 || !isSQLWord(words[0]) {
+		return "", ""
+	}
+	op = strings.ToUpper(words[0])
+	keyword, ok := sqlTableKeywords[op]
+	if !ok {
+		return op, ""
+	}
+
+	i := 1
+	if keyword != "" {
+		for i < len(words) && !strings.EqualFold(words[i], keyword) {
+			i++
+		}
+		i++
+	} else if i < len(words) && strings.EqualFold(words[i], "ONLY") {
+		i++
+	}
+	if i >= len(words) {
+		return op, ""
+	}
+	table = words[i]
+	// Columns of INSERT statements can follow the table without space.
+	if j := strings.IndexByte(table, '('); j > 0 {
+		table = table[:j]
+	}
+	// Joins, lists of tables, and subqueries do not operate on a single table.
+	if strings.ContainsAny(table, ",()") {
+		return op, ""
+	}
+	if i+1 < len(words) && strings.HasPrefix(words[i+1], ",") {
+		return op, ""
+	}
+	for _, w := range words[i+1:] {
+		if strings.EqualFold(w, "JOIN") {
+			return op, ""
+		}
+	}
+	return op, sqlQuotes.Replace(table)
+}
+
+// sqlQuotes removes the quotes of SQL identifiers.
+var sqlQuotes = strings.NewReplacer("`", "", `"`, "", "[", "", "]", "")
+
+// stripSQLComments returns statement without its leading comments.
+func stripSQLComments(statement string) string {
+	for {
+		statement = strings.TrimLeftFunc(statement, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(statement, "--"):
+			i := strings.IndexByte(statement, '\n')
+			if i < 0 {
+				return ""
+			}
+			statement = statement[i+1:]
+		case strings.HasPrefix(statement, "/*"):
+			i := strings.Index(statement, "*/")
+			if i < 0 {
+				return ""
+			}
+			statement = statement[i+2:]
+		default:
+			return statement
+		}
+	}
+}
+
+func isSQLWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
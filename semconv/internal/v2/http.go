@@ -79,12 +79,7 @@ func (c *HTTPConv) ClientResponse(resp *http.Response) []attribute.KeyValue {
 // "http.request_content_length", "enduser.id".
 func (c *HTTPConv) ClientRequest(req *http.Request) []attribute.KeyValue {
 	n := 3 // URL, peer name, proto, and method.
-	var h string
-	if req.URL != nil {
-		h = req.URL.Host
-	}
-	peer, p := firstHostPort(h, req.Header.Get("Host"))
-	port := requiredHTTPPort(req.URL != nil && req.URL.Scheme == "https", p)
+	peer, port := clientPeerPort(req)
 	if port > 0 {
 		n++
 	}
@@ -165,18 +160,7 @@ func (c *HTTPConv) ServerRequest(server string, req *http.Request) []attribute.K
 	// should be removed as well.
 
 	n := 4 // Method, scheme, proto, and host name.
-	var host string
-	var p int
-	if server == "" {
-		host, p = splitHostPort(req.Host)
-	} else {
-		// Prioritize the primary server name.
-		host, p = splitHostPort(server)
-		if p < 0 {
-			_, p = splitHostPort(req.Host)
-		}
-	}
-	hostPort := requiredHTTPPort(req.TLS != nil, p)
+	host, hostPort := serverHostPort(server, req)
 	if hostPort > 0 {
 		n++
 	}
@@ -234,6 +218,34 @@ func (c *HTTPConv) ServerRequest(server string, req *http.Request) []attribute.K
 	return attrs
 }
 
+// clientPeerPort returns the host name and port of the server req is sent
+// to. The port is -1 if it is the default port of the scheme of req.
+func clientPeerPort(req *http.Request) (peer string, port int) {
+	var h string
+	if req.URL != nil {
+		h = req.URL.Host
+	}
+	peer, p := firstHostPort(h, req.Header.Get("Host"))
+	return peer, requiredHTTPPort(req.URL != nil && req.URL.Scheme == "https", p)
+}
+
+// serverHostPort returns the host name and port of the server receiving req,
+// prioritizing the primary server name. The port is -1 if it is the default
+// port of the scheme of req.
+func serverHostPort(server string, req *http.Request) (host string, port int) {
+	var p int
+	if server == "" {
+		host, p = splitHostPort(req.Host)
+	} else {
+		// Prioritize the primary server name.
+		host, p = splitHostPort(server)
+		if p < 0 {
+			_, p = splitHostPort(req.Host)
+		}
+	}
+	return host, requiredHTTPPort(req.TLS != nil, p)
+}
+
 func (c *HTTPConv) method(method string) attribute.KeyValue {
 	if method == "" {
 		return c.HTTPMethodKey.String(http.MethodGet)
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/semconv/internal/v2"
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/metric/instrument"
+)
+
+// HTTPDurationBoundaries are the explicit bucket boundaries, in milliseconds,
+// recommended by the specification for the histograms of HTTP request
+// durations.
+var HTTPDurationBoundaries = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// HTTPServerResult is the outcome of an HTTP request handled by a server.
+type HTTPServerResult struct {
+	// Route is the matched route of the request, in the format of the
+	// server framework, e.g. "/users/:id". It must be of low cardinality,
+	// and is only reported if it is not empty.
+	Route string
+	// StatusCode is the status code of the response. It is only reported if
+	// it is positive.
+	StatusCode int
+	// Duration is the time taken to handle the request.
+	Duration time.Duration
+	// RequestSize is the size of the request body in bytes, as read by the
+	// server. It is only recorded if it is not nil.
+	RequestSize *int64
+	// ResponseSize is the size of the response body in bytes, as written by
+	// the server. It is only recorded if it is not nil.
+	ResponseSize *int64
+}
+
+// HTTPServerMetrics are the instruments of the HTTP server metrics.
+type HTTPServerMetrics struct {
+	conv *HTTPConv
+
+	duration     instrument.Float64Histogram
+	active       instrument.Int64UpDownCounter
+	requestSize  instrument.Int64Histogram
+	responseSize instrument.Int64Histogram
+}
+
+// NewServerMetrics returns the instruments of the "http.server.duration",
+// "http.server.active_requests", "http.server.request.size", and
+// "http.server.response.size" metrics created with meter.
+func (c *HTTPConv) NewServerMetrics(meter metric.Meter) (*HTTPServerMetrics, error) {
+	m := &HTTPServerMetrics{conv: c}
+	var err error
+	m.duration, err = meter.Float64Histogram(
+		"http.server.duration",
+		instrument.WithUnit("ms"),
+		instrument.WithDescription("Measures the duration of inbound HTTP requests."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.active, err = meter.Int64UpDownCounter(
+		"http.server.active_requests",
+		instrument.WithUnit("{request}"),
+		instrument.WithDescription("Measures the number of concurrent HTTP requests that are currently in-flight."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.requestSize, err = meter.Int64Histogram(
+		"http.server.request.size",
+		instrument.WithUnit("By"),
+		instrument.WithDescription("Measures the size of HTTP request messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.responseSize, err = meter.Int64Histogram(
+		"http.server.response.size",
+		instrument.WithUnit("By"),
+		instrument.WithDescription("Measures the size of HTTP response messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Start records the start of the handling of req by server, the primary
+// server name as passed to ServerRequest, in the
+// "http.server.active_requests" metric.
+func (m *HTTPServerMetrics) Start(ctx context.Context, server string, req *http.Request) {
+	m.active.Add(ctx, 1, m.conv.serverMetric(server, req)...)
+}
+
+// End records the end of the handling of req by server, the primary server
+// name as passed to ServerRequest, in the "http.server.active_requests"
+// metric, and its result in the "http.server.duration",
+// "http.server.request.size", and "http.server.response.size" metrics.
+func (m *HTTPServerMetrics) End(ctx context.Context, server string, req *http.Request, res HTTPServerResult) {
+	attrs := m.conv.serverMetric(server, req)
+	m.active.Add(ctx, -1, attrs...)
+
+	if res.Route != "" {
+		attrs = append(attrs, m.conv.HTTPRouteKey.String(res.Route))
+	}
+	if res.StatusCode > 0 {
+		attrs = append(attrs, m.conv.HTTPStatusCodeKey.Int(res.StatusCode))
+	}
+	m.duration.Record(ctx, milliseconds(res.Duration), attrs...)
+	if res.RequestSize != nil {
+		m.requestSize.Record(ctx, *res.RequestSize, attrs...)
+	}
+	if res.ResponseSize != nil {
+		m.responseSize.Record(ctx, *res.ResponseSize, attrs...)
+	}
+}
+
+// serverMetric returns the attributes of the HTTP server metrics of req
+// received by server: "http.method", "http.scheme", "http.flavor",
+// "net.host.name", and "net.host.port" if it is not the default port. They
+// are the low cardinality subset of the attributes of ServerRequest.
+func (c *HTTPConv) serverMetric(server string, req *http.Request) []attribute.KeyValue {
+	host, port := serverHostPort(server, req)
+	// Room for the port, route, and status code.
+	attrs := make([]attribute.KeyValue, 0, 7)
+	attrs = append(attrs,
+		c.method(req.Method),
+		c.scheme(req.TLS != nil),
+		c.proto(req.Proto),
+		c.NetConv.HostName(host),
+	)
+	if port > 0 {
+		attrs = append(attrs, c.NetConv.HostPort(port))
+	}
+	return attrs
+}
+
+// HTTPClientResult is the outcome of an HTTP request made by a client.
+type HTTPClientResult struct {
+	// StatusCode is the status code of the response. It is only reported if
+	// it is positive, e.g. not if the request failed.
+	StatusCode int
+	// Duration is the time taken by the request, up to the reception of the
+	// response headers.
+	Duration time.Duration
+	// RequestSize is the size of the request body in bytes, as sent by the
+	// client. It is only recorded if it is not nil.
+	RequestSize *int64
+	// ResponseSize is the size of the response body in bytes, as read by the
+	// client. It is only recorded if it is not nil.
+	ResponseSize *int64
+}
+
+// HTTPClientMetrics are the instruments of the HTTP client metrics.
+type HTTPClientMetrics struct {
+	conv *HTTPConv
+
+	duration     instrument.Float64Histogram
+	requestSize  instrument.Int64Histogram
+	responseSize instrument.Int64Histogram
+}
+
+// NewClientMetrics returns the instruments of the "http.client.duration",
+// "http.client.request.size", and "http.client.response.size" metrics
+// created with meter.
+func (c *HTTPConv) NewClientMetrics(meter metric.Meter) (*HTTPClientMetrics, error) {
+	m := &HTTPClientMetrics{conv: c}
+	var err error
+	m.duration, err = meter.Float64Histogram(
+		"http.client.duration",
+		instrument.WithUnit("ms"),
+		instrument.WithDescription("Measures the duration of outbound HTTP requests."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.requestSize, err = meter.Int64Histogram(
+		"http.client.request.size",
+		instrument.WithUnit("By"),
+		instrument.WithDescription("Measures the size of HTTP request messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.responseSize, err = meter.Int64Histogram(
+		"http.client.response.size",
+		instrument.WithUnit("By"),
+		instrument.WithDescription("Measures the size of HTTP response messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Record records the result of req in the "http.client.duration",
+// "http.client.request.size", and "http.client.response.size" metrics.
+func (m *HTTPClientMetrics) Record(ctx context.Context, req *http.Request, res HTTPClientResult) {
+	attrs := m.conv.clientMetric(req)
+	if res.StatusCode > 0 {
+		attrs = append(attrs, m.conv.HTTPStatusCodeKey.Int(res.StatusCode))
+	}
+	m.duration.Record(ctx, milliseconds(res.Duration), attrs...)
+	if res.RequestSize != nil {
+		m.requestSize.Record(ctx, *res.RequestSize, attrs...)
+	}
+	if res.ResponseSize != nil {
+		m.responseSize.Record(ctx, *res.ResponseSize, attrs...)
+	}
+}
+
+// clientMetric returns the attributes of the HTTP client metrics of req:
+// "http.method", "http.flavor", "net.peer.name", and "net.peer.port" if it is
+// not the default port. They are the low cardinality subset of the attributes
+// of ClientRequest.
+func (c *HTTPConv) clientMetric(req *http.Request) []attribute.KeyValue {
+	peer, port := clientPeerPort(req)
+	// Room for the port and status code.
+	attrs := make([]attribute.KeyValue, 0, 5)
+	attrs = append(attrs,
+		c.method(req.Method),
+		c.proto(req.Proto),
+		c.NetConv.PeerName(peer),
+	)
+	if port > 0 {
+		attrs = append(attrs, c.NetConv.PeerPort(port))
+	}
+	return attrs
+}
+
+// milliseconds returns d in milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/semconv/internal/v2"
+
+import (
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+)
+
+// Operations of the messaging semantic conventions.
+const (
+	MessagingPublish = "publish"
+	MessagingReceive = "receive"
+	MessagingProcess = "process"
+)
+
+// Message is the metadata of a message sent or received by a messaging
+// system.
+type Message struct {
+	// System is the identifier of the messaging system, e.g. "kafka" or
+	// "rabbitmq".
+	System string
+	// Destination is the name of the topic or queue the message is sent to,
+	// and received from.
+	Destination string
+	// DestinationKind is the kind of the destination, "topic" or "queue".
+	DestinationKind string
+	// TemporaryDestination is true if the destination only exists as long
+	// as the application using it.
+	TemporaryDestination bool
+	// AnonymousDestination is true if the destination is unnamed, or has an
+	// auto-generated name.
+	AnonymousDestination bool
+
+	// ID is the identifier of the message.
+	ID string
+	// ConversationID is the identifier of the conversation of the message,
+	// also called correlation ID.
+	ConversationID string
+	// PayloadSize is the size of the uncompressed payload in bytes, if it is
+	// positive.
+	PayloadSize int
+
+	// KafkaKey is the key of a Kafka message.
+	KafkaKey string
+	// KafkaPartition is the partition of a Kafka message. It is only
+	// reported for Kafka messages, and if it is not nil.
+	KafkaPartition *int
+	// KafkaOffset is the offset of a Kafka message in its partition. It is
+	// only reported for Kafka messages received by a consumer, and if it is
+	// not nil.
+	KafkaOffset *int64
+	// KafkaConsumerGroup is the consumer group receiving a Kafka message.
+	KafkaConsumerGroup string
+	// KafkaTombstone is true if the Kafka message is a tombstone.
+	KafkaTombstone bool
+
+	// RabbitMQRoutingKey is the routing key of a RabbitMQ message.
+	RabbitMQRoutingKey string
+}
+
+// MessagingConv are the messaging semantic convention attributes defined for
+// a version of the OpenTelemetry specification.
+type MessagingConv struct {
+	MessagingDestinationAnonymousKey          attribute.Key
+	MessagingDestinationKindKey               attribute.Key
+	MessagingDestinationNameKey               attribute.Key
+	MessagingDestinationTemporaryKey          attribute.Key
+	MessagingKafkaConsumerGroupKey            attribute.Key
+	MessagingKafkaDestinationPartitionKey     attribute.Key
+	MessagingKafkaMessageKeyKey               attribute.Key
+	MessagingKafkaMessageOffsetKey            attribute.Key
+	MessagingKafkaMessageTombstoneKey         attribute.Key
+	MessagingKafkaSourcePartitionKey          attribute.Key
+	MessagingMessageConversationIDKey         attribute.Key
+	MessagingMessageIDKey                     attribute.Key
+	MessagingMessagePayloadSizeBytesKey       attribute.Key
+	MessagingOperationKey                     attribute.Key
+	MessagingRabbitmqDestinationRoutingKeyKey attribute.Key
+	MessagingSourceAnonymousKey               attribute.Key
+	MessagingSourceKindKey                    attribute.Key
+	MessagingSourceNameKey                    attribute.Key
+	MessagingSourceTemporaryKey               attribute.Key
+	MessagingSystemKey                        attribute.Key
+}
+
+// kafka is the messaging.system value of Kafka.
+const kafka = "kafka"
+
+// ProducerMessage returns attributes for a message published by a producer.
+// The "messaging.system" and "messaging.operation" attributes are always
+// returned, and the attributes of the destination and of the message if the
+// related values are defined in msg.
+func (c *MessagingConv) ProducerMessage(msg Message) []attribute.KeyValue {
+	attrs := c.message(MessagingPublish, msg)
+	attrs = c.destination(attrs, msg, c.MessagingDestinationNameKey, c.MessagingDestinationKindKey, c.MessagingDestinationTemporaryKey, c.MessagingDestinationAnonymousKey)
+	if msg.System == kafka && msg.KafkaPartition != nil {
+		attrs = append(attrs, c.MessagingKafkaDestinationPartitionKey.Int(*msg.KafkaPartition))
+	}
+	if msg.RabbitMQRoutingKey != "" {
+		attrs = append(attrs, c.MessagingRabbitmqDestinationRoutingKeyKey.String(msg.RabbitMQRoutingKey))
+	}
+	return attrs
+}
+
+// ConsumerMessage returns attributes for a message received, or processed,
+// by a consumer. The operation is either MessagingReceive or
+// MessagingProcess. The "messaging.system" and "messaging.operation"
+// attributes are always returned, and the attributes of the source and of the
+// message if the related values are defined in msg.
+func (c *MessagingConv) ConsumerMessage(operation string, msg Message) []attribute.KeyValue {
+	attrs := c.message(operation, msg)
+	attrs = c.destination(attrs, msg, c.MessagingSourceNameKey, c.MessagingSourceKindKey, c.MessagingSourceTemporaryKey, c.MessagingSourceAnonymousKey)
+	if msg.System == kafka {
+		if msg.KafkaPartition != nil {
+			attrs = append(attrs, c.MessagingKafkaSourcePartitionKey.Int(*msg.KafkaPartition))
+		}
+		if msg.KafkaOffset != nil {
+			attrs = append(attrs, c.MessagingKafkaMessageOffsetKey.Int64(*msg.KafkaOffset))
+		}
+	}
+	if msg.KafkaConsumerGroup != "" {
+		attrs = append(attrs, c.MessagingKafkaConsumerGroupKey.String(msg.KafkaConsumerGroup))
+	}
+	return attrs
+}
+
+// message returns the attributes of msg shared by producers and consumers.
+func (c *MessagingConv) message(operation string, msg Message) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 8)
+	attrs = append(attrs, c.MessagingSystemKey.String(msg.System), c.MessagingOperationKey.String(operation))
+	if msg.ID != "" {
+		attrs = append(attrs, c.MessagingMessageIDKey.String(msg.ID))
+	}
+	if msg.ConversationID != "" {
+		attrs = append(attrs, c.MessagingMessageConversationIDKey.String(msg.ConversationID))
+	}
+	if msg.PayloadSize > 0 {
+		attrs = append(attrs, c.MessagingMessagePayloadSizeBytesKey.Int(msg.PayloadSize))
+	}
+	if msg.KafkaKey != "" {
+		attrs = append(attrs, c.MessagingKafkaMessageKeyKey.String(msg.KafkaKey))
+	}
+	if msg.KafkaTombstone {
+		attrs = append(attrs, c.MessagingKafkaMessageTombstoneKey.Bool(true))
+	}
+	return attrs
+}
+
+// destination appends the attributes of the destination of msg, with the
+// keys of either the destination or the source, to attrs.
+func (c *MessagingConv) destination(attrs []attribute.KeyValue, msg Message, name, kind, temporary, anonymous attribute.Key) []attribute.KeyValue {
+	if msg.Destination != "" {
+		attrs = append(attrs, name.String(msg.Destination))
+	}
+	if msg.DestinationKind != "" {
+		attrs = append(attrs, kind.String(msg.DestinationKind))
+	}
+	if msg.TemporaryDestination {
+		attrs = append(attrs, temporary.Bool(true))
+	}
+	if msg.AnonymousDestination {
+		attrs = append(attrs, anonymous.Bool(true))
+	}
+	return attrs
+}
+
+// SpanName returns the name of the span of the operation on msg:
+// "<destination> <operation>". Temporary and anonymous destinations are
+// named "(temporary)" and "(anonymous)", and the operation alone is returned
+// if the destination is unknown.
+func (c *MessagingConv) SpanName(operation string, msg Message) string {
+	switch {
+	case msg.TemporaryDestination:
+		return "(temporary) " + operation
+	case msg.AnonymousDestination:
+		return "(anonymous) " + operation
+	case msg.Destination != "":
+		return msg.Destination + " " + operation
+	default:
+		return operation
+	}
+}
+
+// Status returns a span status code and message for the error of a
+// messaging operation.
+func (c *MessagingConv) Status(err error) (codes.Code, string) {
+	if err == nil {
+		return codes.Unset, ""
+	}
+	return codes.Error, err.Error()
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+)
+
+var mc = &MessagingConv{
+	MessagingDestinationAnonymousKey:          attribute.Key("messaging.destination.anonymous"),
+	MessagingDestinationKindKey:               attribute.Key("messaging.destination.kind"),
+	MessagingDestinationNameKey:               attribute.Key("messaging.destination.name"),
+	MessagingDestinationTemporaryKey:          attribute.Key("messaging.destination.temporary"),
+	MessagingKafkaConsumerGroupKey:            attribute.Key("messaging.kafka.consumer.group"),
+	MessagingKafkaDestinationPartitionKey:     attribute.Key("messaging.kafka.destination.partition"),
+	MessagingKafkaMessageKeyKey:               attribute.Key("messaging.kafka.message.key"),
+	MessagingKafkaMessageOffsetKey:            attribute.Key("messaging.kafka.message.offset"),
+	MessagingKafkaMessageTombstoneKey:         attribute.Key("messaging.kafka.message.tombstone"),
+	MessagingKafkaSourcePartitionKey:          attribute.Key("messaging.kafka.source.partition"),
+	MessagingMessageConversationIDKey:         attribute.Key("messaging.message.conversation_id"),
+	MessagingMessageIDKey:                     attribute.Key("messaging.message.id"),
+	MessagingMessagePayloadSizeBytesKey:       attribute.Key("messaging.message.payload_size_bytes"),
+	MessagingOperationKey:                     attribute.Key("messaging.operation"),
+	MessagingRabbitmqDestinationRoutingKeyKey: attribute.Key("messaging.rabbitmq.destination.routing_key"),
+	MessagingSourceAnonymousKey:               attribute.Key("messaging.source.anonymous"),
+	MessagingSourceKindKey:                    attribute.Key("messaging.source.kind"),
+	MessagingSourceNameKey:                    attribute.Key("messaging.source.name"),
+	MessagingSourceTemporaryKey:               attribute.Key("messaging.source.temporary"),
+	MessagingSystemKey:                        attribute.Key("messaging.system"),
+}
+
+var (
+	partition = 3
+	offset    = int64(1024)
+)
+
+var kafkaMsg = Message{
+	System:             "kafka",
+	Destination:        "orders",
+	DestinationKind:    "topic",
+	ID:                 "a1",
+	PayloadSize:        128,
+	KafkaKey:           "order-42",
+	KafkaPartition:     &partition,
+	KafkaOffset:        &offset,
+	KafkaConsumerGroup: "billing",
+}
+
+func TestMessagingProducerMessage(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.operation", "publish"),
+		attribute.String("messaging.message.id", "a1"),
+		attribute.Int("messaging.message.payload_size_bytes", 128),
+		attribute.String("messaging.kafka.message.key", "order-42"),
+		attribute.String("messaging.destination.name", "orders"),
+		attribute.String("messaging.destination.kind", "topic"),
+		attribute.Int("messaging.kafka.destination.partition", 3),
+	}, mc.ProducerMessage(kafkaMsg))
+
+	msg := Message{
+		System:               "rabbitmq",
+		DestinationKind:      "queue",
+		TemporaryDestination: true,
+		AnonymousDestination: true,
+		ConversationID:       "c1",
+		RabbitMQRoutingKey:   "orders.created",
+	}
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.operation", "publish"),
+		attribute.String("messaging.message.conversation_id", "c1"),
+		attribute.String("messaging.destination.kind", "queue"),
+		attribute.Bool("messaging.destination.temporary", true),
+		attribute.Bool("messaging.destination.anonymous", true),
+		attribute.String("messaging.rabbitmq.destination.routing_key", "orders.created"),
+	}, mc.ProducerMessage(msg))
+}
+
+func TestMessagingConsumerMessage(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.operation", "process"),
+		attribute.String("messaging.message.id", "a1"),
+		attribute.Int("messaging.message.payload_size_bytes", 128),
+		attribute.String("messaging.kafka.message.key", "order-42"),
+		attribute.String("messaging.source.name", "orders"),
+		attribute.String("messaging.source.kind", "topic"),
+		attribute.Int("messaging.kafka.source.partition", 3),
+		attribute.Int64("messaging.kafka.message.offset", 1024),
+		attribute.String("messaging.kafka.consumer.group", "billing"),
+	}, mc.ConsumerMessage(MessagingProcess, kafkaMsg))
+
+	msg := kafkaMsg
+	msg.KafkaPartition = nil
+	msg.KafkaOffset = nil
+	msg.KafkaTombstone = true
+	got := mc.ConsumerMessage(MessagingReceive, msg)
+	assert.Contains(t, got, attribute.String("messaging.operation", "receive"))
+	assert.Contains(t, got, attribute.Bool("messaging.kafka.message.tombstone", true))
+	for _, kv := range got {
+		assert.NotEqual(t, attribute.Key("messaging.kafka.source.partition"), kv.Key)
+		assert.NotEqual(t, attribute.Key("messaging.kafka.message.offset"), kv.Key)
+	}
+
+	// The zero partition and offset are valid values.
+	var zero int
+	var zeroOffset int64
+	msg.KafkaPartition, msg.KafkaOffset = &zero, &zeroOffset
+	got = mc.ConsumerMessage(MessagingReceive, msg)
+	assert.Contains(t, got, attribute.Int("messaging.kafka.source.partition", 0))
+	assert.Contains(t, got, attribute.Int64("messaging.kafka.message.offset", 0))
+}
+
+func TestMessagingSpanName(t *testing.T) {
+	assert.Equal(t, "orders publish", mc.SpanName(MessagingPublish, kafkaMsg))
+	assert.Equal(t, "(temporary) receive", mc.SpanName(MessagingReceive, Message{Destination: "tmp-1", TemporaryDestination: true}))
+	assert.Equal(t, "(anonymous) receive", mc.SpanName(MessagingReceive, Message{AnonymousDestination: true}))
+	assert.Equal(t, "process", mc.SpanName(MessagingProcess, Message{}))
+}
+
+func TestMessagingStatus(t *testing.T) {
+	got, msg := mc.Status(nil)
+	assert.Equal(t, codes.Unset, got)
+	assert.Empty(t, msg)
+
+	got, msg = mc.Status(errors.New("broker unavailable"))
+	assert.Equal(t, codes.Error, got)
+	assert.Equal(t, "broker unavailable", msg)
+}
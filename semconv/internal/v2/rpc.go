@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/middleware-labs/otel/semconv/internal/v2"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+)
+
+// RPCConv are the RPC semantic convention attributes defined for a version of
+// the OpenTelemetry specification.
+type RPCConv struct {
+	NetConv *NetConv
+
+	RPCGRPCStatusCodeKey attribute.Key
+	RPCMethodKey         attribute.Key
+	RPCServiceKey        attribute.Key
+	RPCSystemGRPC        attribute.KeyValue
+}
+
+// maxGRPCStatusCode is the greatest gRPC status code, Unauthenticated.
+const maxGRPCStatusCode = 16
+
+// grpcServerErrors are the gRPC status codes returned by servers that
+// indicate a server error: Unknown, DeadlineExceeded, Unimplemented,
+// Internal, Unavailable, and DataLoss.
+var grpcServerErrors = map[uint32]bool{2: true, 4: true, 12: true, 13: true, 14: true, 15: true}
+
+// GRPCClientRequest returns attributes for a gRPC call made by a client to
+// target, the target of the connection, of the fullMethod method, in the
+// "/package.service/method" form. The "rpc.system" attribute is always
+// returned. The "rpc.service" and "rpc.method" attributes are returned if
+// fullMethod is valid, and the "net.peer.name" and "net.peer.port" attributes
+// if target is a network address.
+func (c *RPCConv) GRPCClientRequest(target, fullMethod string) []attribute.KeyValue {
+	return append(c.grpc(fullMethod), c.NetConv.Peer(grpcTargetAddress(target))...)
+}
+
+// grpcTargetAddress returns the network address of the gRPC target, or an
+// empty string if the target is not a network address, like the Unix domain
+// socket targets.
+func grpcTargetAddress(target string) string {
+	if strings.HasPrefix(target, "unix:") || strings.HasPrefix(target, "unix-abstract:") {
+		return ""
+	}
+	// Strip the resolver scheme and authority, e.g. "dns:///" or
+	// "dns://8.8.8.8/", of the target. The address is the endpoint that
+	// follows them.
+	if i := strings.Index(target, "://"); i >= 0 {
+		rest := target[i+len("://"):]
+		j := strings.IndexByte(rest, '/')
+		if j < 0 {
+			return ""
+		}
+		return rest[j+1:]
+	}
+	return target
+}
+
+// GRPCServerRequest returns attributes for a gRPC call of the fullMethod
+// method, in the "/package.service/method" form, received by a server from
+// peer, the network address of the client. The "rpc.system" attribute is
+// always returned. The "rpc.service" and "rpc.method" attributes are returned
+// if fullMethod is valid, and the "net.sock.peer.addr" and
+// "net.sock.peer.port" attributes if peer is a network address.
+func (c *RPCConv) GRPCServerRequest(fullMethod, peer string) []attribute.KeyValue {
+	attrs := c.grpc(fullMethod)
+	if addr, port := splitHostPort(peer); addr != "" {
+		attrs = append(attrs, c.NetConv.SockPeerAddr(addr))
+		if port > 0 {
+			attrs = append(attrs, c.NetConv.SockPeerPort(port))
+		}
+	}
+	return attrs
+}
+
+func (c *RPCConv) grpc(fullMethod string) []attribute.KeyValue {
+	service, method := parseFullMethod(fullMethod)
+	attrs := make([]attribute.KeyValue, 0, 5)
+	attrs = append(attrs, c.RPCSystemGRPC)
+	if service != "" {
+		attrs = append(attrs, c.RPCServiceKey.String(service), c.RPCMethodKey.String(method))
+	}
+	return attrs
+}
+
+// GRPCStatus returns the "rpc.grpc.status_code" attribute of the gRPC status
+// code.
+func (c *RPCConv) GRPCStatus(code uint32) attribute.KeyValue {
+	return c.RPCGRPCStatusCodeKey.Int(int(code))
+}
+
+// GRPCClientStatus returns a span status code and message for a gRPC status
+// code received by a client. All status codes other than OK are errors.
+func (c *RPCConv) GRPCClientStatus(code uint32) (codes.Code, string) {
+	if code > maxGRPCStatusCode {
+		return codes.Error, fmt.Sprintf("Invalid gRPC status code %d", code)
+	}
+	if code != 0 {
+		return codes.Error, ""
+	}
+	return codes.Unset, ""
+}
+
+// GRPCServerStatus returns a span status code and message for a gRPC status
+// code returned by a server. Only the status codes indicating a server error
+// are errors: Unknown, DeadlineExceeded, Unimplemented, Internal,
+// Unavailable, and DataLoss.
+func (c *RPCConv) GRPCServerStatus(code uint32) (codes.Code, string) {
+	if code > maxGRPCStatusCode {
+		return codes.Error, fmt.Sprintf("Invalid gRPC status code %d", code)
+	}
+	if grpcServerErrors[code] {
+		return codes.Error, ""
+	}
+	return codes.Unset, ""
+}
+
+// GRPCSpanName returns the name of the span of a gRPC call of the fullMethod
+// method: "package.service/method". An invalid fullMethod is returned as is.
+func (c *RPCConv) GRPCSpanName(fullMethod string) string {
+	if service, method := parseFullMethod(fullMethod); service != "" {
+		return service + "/" + method
+	}
+	return fullMethod
+}
+
+// parseFullMethod returns the service and method of a gRPC method name in the
+// "/package.service/method" form. Empty strings are returned if fullMethod is
+// not in this form.
+func parseFullMethod(fullMethod string) (service, method string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if name == fullMethod {
+		return "", ""
+	}
+	service, method, ok := strings.Cut(name, "/")
+	if !ok || service == "" || method == "" || strings.Contains(method, "/") {
+		return "", ""
+	}
+	return service, method
+}
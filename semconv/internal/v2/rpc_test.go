@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+)
+
+var rc = &RPCConv{
+	NetConv: nc,
+
+	RPCGRPCStatusCodeKey: attribute.Key("rpc.grpc.status_code"),
+	RPCMethodKey:         attribute.Key("rpc.method"),
+	RPCServiceKey:        attribute.Key("rpc.service"),
+	RPCSystemGRPC:        attribute.String("rpc.system", "grpc"),
+}
+
+func TestRPCGRPCClientRequest(t *testing.T) {
+	tests := []struct {
+		target, method string
+		want           []attribute.KeyValue
+	}{
+		{
+			target: "dns:///api.example.com:443",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+				attribute.String("net.peer.name", "api.example.com"),
+				attribute.Int("net.peer.port", 443),
+			},
+		},
+		{
+			target: "127.0.0.1:50051",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+				attribute.String("net.peer.name", "127.0.0.1"),
+				attribute.Int("net.peer.port", 50051),
+			},
+		},
+		{
+			target: "dns://8.8.8.8/api.example.com:443",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+				attribute.String("net.peer.name", "api.example.com"),
+				attribute.Int("net.peer.port", 443),
+			},
+		},
+		{
+			target: "unix:///tmp/grpc.sock",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+			},
+		},
+		{
+			target: "unix-abstract:grpc",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+			},
+		},
+		{
+			target: "unix:/tmp/grpc.sock",
+			method: "/helloworld.Greeter/SayHello",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+			},
+		},
+		{
+			target: "api.example.com",
+			method: "invalid",
+			want: []attribute.KeyValue{
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("net.peer.name", "api.example.com"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, rc.GRPCClientRequest(test.target, test.method), test.target)
+	}
+}
+
+func TestRPCGRPCServerRequest(t *testing.T) {
+	got := rc.GRPCServerRequest("/helloworld.Greeter/SayHello", "10.0.0.1:52314")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "helloworld.Greeter"),
+		attribute.String("rpc.method", "SayHello"),
+		attribute.String("net.sock.peer.addr", "10.0.0.1"),
+		attribute.Int("net.sock.peer.port", 52314),
+	}, got)
+
+	got = rc.GRPCServerRequest("/helloworld.Greeter/SayHello", "")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "helloworld.Greeter"),
+		attribute.String("rpc.method", "SayHello"),
+	}, got)
+}
+
+func TestRPCGRPCStatus(t *testing.T) {
+	assert.Equal(t, attribute.Int("rpc.grpc.status_code", 5), rc.GRPCStatus(5))
+}
+
+func TestRPCGRPCClientStatus(t *testing.T) {
+	for code := uint32(0); code <= maxGRPCStatusCode; code++ {
+		want := codes.Error
+		if code == 0 {
+			want = codes.Unset
+		}
+		got, msg := rc.GRPCClientStatus(code)
+		assert.Equalf(t, want, got, "code %d", code)
+		assert.Emptyf(t, msg, "code %d", code)
+	}
+
+	got, msg := rc.GRPCClientStatus(17)
+	assert.Equal(t, codes.Error, got)
+	assert.Equal(t, "Invalid gRPC status code 17", msg)
+}
+
+func TestRPCGRPCServerStatus(t *testing.T) {
+	errs := map[uint32]bool{2: true, 4: true, 12: true, 13: true, 14: true, 15: true}
+	for code := uint32(0); code <= maxGRPCStatusCode; code++ {
+		want := codes.Unset
+		if errs[code] {
+			want = codes.Error
+		}
+		got, msg := rc.GRPCServerStatus(code)
+		assert.Equalf(t, want, got, "code %d", code)
+		assert.Emptyf(t, msg, "code %d", code)
+	}
+
+	got, msg := rc.GRPCServerStatus(17)
+	assert.Equal(t, codes.Error, got)
+	assert.Equal(t, "Invalid gRPC status code 17", msg)
+}
+
+func TestRPCGRPCSpanName(t *testing.T) {
+	tests := map[string]string{
+		"/helloworld.Greeter/SayHello": "helloworld.Greeter/SayHello",
+		"/Greeter/SayHello":            "Greeter/SayHello",
+		"helloworld.Greeter/SayHello":  "helloworld.Greeter/SayHello",
+		"/helloworld.Greeter":          "/helloworld.Greeter",
+		"/a/b/c":                       "/a/b/c",
+		"":                             "",
+	}
+	for method, want := range tests {
+		assert.Equal(t, want, rc.GRPCSpanName(method), method)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbconv // import "github.com/middleware-labs/otel/semconv/v1.18.0/dbconv"
+
+import (
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/semconv/internal/v2"
+	semconv "github.com/middleware-labs/otel/semconv/v1.18.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var (
+	nc = &internal.NetConv{
+		NetHostNameKey:     semconv.NetHostNameKey,
+		NetHostPortKey:     semconv.NetHostPortKey,
+		NetPeerNameKey:     semconv.NetPeerNameKey,
+		NetPeerPortKey:     semconv.NetPeerPortKey,
+		NetSockPeerAddrKey: semconv.NetSockPeerAddrKey,
+		NetSockPeerPortKey: semconv.NetSockPeerPortKey,
+		NetTransportOther:  semconv.NetTransportOther,
+		NetTransportTCP:    semconv.NetTransportTCP,
+		NetTransportUDP:    semconv.NetTransportUDP,
+		NetTransportInProc: semconv.NetTransportInProc,
+	}
+
+	dc = &internal.DBConv{
+		NetConv: nc,
+
+		DBNameKey:        semconv.DBNameKey,
+		DBOperationKey:   semconv.DBOperationKey,
+		DBSQLTableKey:    semconv.DBSQLTableKey,
+		DBStatementKey:   semconv.DBStatementKey,
+		DBSystemKey:      semconv.DBSystemKey,
+		DBSystemOtherSQL: semconv.DBSystemOtherSQL,
+		DBUserKey:        semconv.DBUserKey,
+	}
+)
+
+// ClientSpanKind is the kind of the spans of database calls.
+const ClientSpanKind = trace.SpanKindClient
+
+// Client returns trace attributes for a connection to a database made with
+// the driverName database/sql driver and the dsn data source name, the
+// arguments of sql.Open. The "db.system" attribute is always returned, with
+// the "other_sql" value for unknown drivers. The following attributes are
+// returned if dsn defines the related values: "db.name", "db.user",
+// "net.peer.name", "net.peer.port". The password of dsn is never returned.
+//
+// URL, key/value pairs, MySQL driver, and SQLite data source names are
+// supported.
+func Client(driverName, dsn string) []attribute.KeyValue {
+	return dc.Client(driverName, dsn)
+}
+
+// Query returns trace attributes for the SQL statement of a database query.
+// The "db.statement" attribute is always returned, the "db.operation" and
+// "db.sql.table" attributes are returned if they are found in statement.
+//
+// The statement is recorded as is, it must be sanitized of sensitive values
+// before it is passed.
+func Query(statement string) []attribute.KeyValue {
+	return dc.Query(statement)
+}
+
+// SpanName returns the name of the span of the SQL statement executed on the
+// name database, for example "SELECT shop.orders". The name of the database
+// is returned if the operation of statement is unknown, and "DB" if the name
+// is also empty.
+func SpanName(statement, name string) string {
+	return dc.SpanName(statement, name)
+}
+
+// Status returns a span status code and message for the error returned by a
+// database call. The sql.ErrNoRows error is not returned as an error.
+func Status(err error) (codes.Code, string) {
+	return dc.Status(err)
+}
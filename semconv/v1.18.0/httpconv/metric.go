@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpconv // import "github.com/middleware-labs/otel/semconv/v1.18.0/httpconv"
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/middleware-labs/otel/metric"
+	"github.com/middleware-labs/otel/semconv/internal/v2"
+)
+
+// DurationBoundaries returns the explicit bucket boundaries, in milliseconds,
+// recommended for the "http.server.duration" and "http.client.duration"
+// histograms. They are the default boundaries of the SDK
+// (github.com/middleware-labs/otel/sdk/metric), and can be set with a View of
+// the SDK otherwise. For example:
+//
+//	metric.NewView(
+//		metric.Instrument{Name: "http.*.duration"},
+//		metric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{
+//			Boundaries: httpconv.DurationBoundaries(),
+//		}},
+//	)
+func DurationBoundaries() []float64 {
+	return append([]float64(nil), internal.HTTPDurationBoundaries...)
+}
+
+// ServerResult is the outcome of an HTTP request handled by a server.
+//
+// The request and response sizes are only recorded if they are set.
+type ServerResult = internal.HTTPServerResult
+
+// ServerMetrics records the HTTP server metrics: "http.server.duration",
+// "http.server.active_requests", "http.server.request.size", and
+// "http.server.response.size".
+//
+// The metrics are recorded with the low cardinality subset of the attributes
+// returned by ServerRequest: "http.method", "http.scheme", "http.flavor",
+// "net.host.name", and "net.host.port". The "http.route" and
+// "http.status_code" attributes of the ServerResult are added to all but the
+// "http.server.active_requests" metric.
+type ServerMetrics struct {
+	m *internal.HTTPServerMetrics
+}
+
+// NewServerMetrics returns a new ServerMetrics recording the metrics with
+// instruments created by meter.
+func NewServerMetrics(meter metric.Meter) (*ServerMetrics, error) {
+	m, err := hc.NewServerMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerMetrics{m: m}, nil
+}
+
+// Start records the start of the handling of req. It must be paired with a
+// call to End with the same server and req once req is handled.
+//
+// The server is the primary server name, as passed to ServerRequest.
+func (m *ServerMetrics) Start(ctx context.Context, server string, req *http.Request) {
+	m.m.Start(ctx, server, req)
+}
+
+// End records the end of the handling of req, and its result.
+//
+// The server is the primary server name, as passed to ServerRequest.
+func (m *ServerMetrics) End(ctx context.Context, server string, req *http.Request, res ServerResult) {
+	m.m.End(ctx, server, req, res)
+}
+
+// ClientResult is the outcome of an HTTP request made by a client.
+//
+// The request and response sizes are only recorded if they are set.
+type ClientResult = internal.HTTPClientResult
+
+// ClientMetrics records the HTTP client metrics: "http.client.duration",
+// "http.client.request.size", and "http.client.response.size".
+//
+// The metrics are recorded with the low cardinality subset of the attributes
+// returned by ClientRequest: "http.method", "http.flavor", "net.peer.name",
+// and "net.peer.port", and with the "http.status_code" attribute of the
+// ClientResult.
+type ClientMetrics struct {
+	m *internal.HTTPClientMetrics
+}
+
+// NewClientMetrics returns a new ClientMetrics recording the metrics with
+// instruments created by meter.
+func NewClientMetrics(meter metric.Meter) (*ClientMetrics, error) {
+	m, err := hc.NewClientMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientMetrics{m: m}, nil
+}
+
+// Record records the result of req.
+func (m *ClientMetrics) Record(ctx context.Context, req *http.Request, res ClientResult) {
+	m.m.Record(ctx, req, res)
+}
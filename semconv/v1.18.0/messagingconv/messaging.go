@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messagingconv // import "github.com/middleware-labs/otel/semconv/v1.18.0/messagingconv"
+
+import (
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/semconv/internal/v2"
+	semconv "github.com/middleware-labs/otel/semconv/v1.18.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var mc = &internal.MessagingConv{
+	MessagingDestinationAnonymousKey:          semconv.MessagingDestinationAnonymousKey,
+	MessagingDestinationKindKey:               semconv.MessagingDestinationKindKey,
+	MessagingDestinationNameKey:               semconv.MessagingDestinationNameKey,
+	MessagingDestinationTemporaryKey:          semconv.MessagingDestinationTemporaryKey,
+	MessagingKafkaConsumerGroupKey:            semconv.MessagingKafkaConsumerGroupKey,
+	MessagingKafkaDestinationPartitionKey:     semconv.MessagingKafkaDestinationPartitionKey,
+	MessagingKafkaMessageKeyKey:               semconv.MessagingKafkaMessageKeyKey,
+	MessagingKafkaMessageOffsetKey:            semconv.MessagingKafkaMessageOffsetKey,
+	MessagingKafkaMessageTombstoneKey:         semconv.MessagingKafkaMessageTombstoneKey,
+	MessagingKafkaSourcePartitionKey:          semconv.MessagingKafkaSourcePartitionKey,
+	MessagingMessageConversationIDKey:         semconv.MessagingMessageConversationIDKey,
+	MessagingMessageIDKey:                     semconv.MessagingMessageIDKey,
+	MessagingMessagePayloadSizeBytesKey:       semconv.MessagingMessagePayloadSizeBytesKey,
+	MessagingOperationKey:                     semconv.MessagingOperationKey,
+	MessagingRabbitmqDestinationRoutingKeyKey: semconv.MessagingRabbitmqDestinationRoutingKeyKey,
+	MessagingSourceAnonymousKey:               semconv.MessagingSourceAnonymousKey,
+	MessagingSourceKindKey:                    semconv.MessagingSourceKindKey,
+	MessagingSourceNameKey:                    semconv.MessagingSourceNameKey,
+	MessagingSourceTemporaryKey:               semconv.MessagingSourceTemporaryKey,
+	MessagingSystemKey:                        semconv.MessagingSystemKey,
+}
+
+// Operations of messaging systems.
+const (
+	// PublishOperation is the operation of a producer sending a message.
+	PublishOperation = internal.MessagingPublish
+	// ReceiveOperation is the operation of a consumer receiving a message.
+	ReceiveOperation = internal.MessagingReceive
+	// ProcessOperation is the operation of a consumer processing a message
+	// it received.
+	ProcessOperation = internal.MessagingProcess
+)
+
+// Kinds of the spans of messaging operations.
+const (
+	// ProducerSpanKind is the kind of the spans of PublishOperation.
+	ProducerSpanKind = trace.SpanKindProducer
+	// ConsumerSpanKind is the kind of the spans of ReceiveOperation and
+	// ProcessOperation.
+	ConsumerSpanKind = trace.SpanKindConsumer
+)
+
+// Message is the metadata of a message sent or received by a messaging
+// system. Only its defined values are returned as trace attributes.
+type Message = internal.Message
+
+// ProducerMessage returns trace attributes for a message published by a
+// producer. The "messaging.system" and "messaging.operation" attributes are
+// always returned. The following attributes are returned if the related
+// values are defined in msg: "messaging.destination.name",
+// "messaging.destination.kind", "messaging.destination.temporary",
+// "messaging.destination.anonymous", "messaging.message.id",
+// "messaging.message.conversation_id",
+// "messaging.message.payload_size_bytes", "messaging.kafka.message.key",
+// "messaging.kafka.message.tombstone",
+// "messaging.kafka.destination.partition",
+// "messaging.rabbitmq.destination.routing_key".
+func ProducerMessage(msg Message) []attribute.KeyValue {
+	return mc.ProducerMessage(msg)
+}
+
+// ConsumerMessage returns trace attributes for a message received, or
+// processed, by a consumer. The operation is either ReceiveOperation or
+// ProcessOperation. The "messaging.system" and "messaging.operation"
+// attributes are always returned. The following attributes are returned if
+// the related values are defined in msg: "messaging.source.name",
+// "messaging.source.kind", "messaging.source.temporary",
+// "messaging.source.anonymous", "messaging.message.id",
+// "messaging.message.conversation_id",
+// "messaging.message.payload_size_bytes", "messaging.kafka.message.key",
+// "messaging.kafka.message.tombstone", "messaging.kafka.source.partition",
+// "messaging.kafka.message.offset", "messaging.kafka.consumer.group".
+func ConsumerMessage(operation string, msg Message) []attribute.KeyValue {
+	return mc.ConsumerMessage(operation, msg)
+}
+
+// SpanName returns the name of the span of the operation on msg, for example
+// "orders publish". The "(temporary)" and "(anonymous)" names are used for
+// temporary and anonymous destinations, and the operation alone is returned
+// if the destination is unknown.
+func SpanName(operation string, msg Message) string {
+	return mc.SpanName(operation, msg)
+}
+
+// Status returns a span status code and message for the error of a messaging
+// operation.
+func Status(err error) (codes.Code, string) {
+	return mc.Status(err)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcconv // import "github.com/middleware-labs/otel/semconv/v1.18.0/rpcconv"
+
+import (
+	"github.com/middleware-labs/otel/attribute"
+	"github.com/middleware-labs/otel/codes"
+	"github.com/middleware-labs/otel/semconv/internal/v2"
+	semconv "github.com/middleware-labs/otel/semconv/v1.18.0"
+	"github.com/middleware-labs/otel/trace"
+)
+
+var (
+	nc = &internal.NetConv{
+		NetHostNameKey:     semconv.NetHostNameKey,
+		NetHostPortKey:     semconv.NetHostPortKey,
+		NetPeerNameKey:     semconv.NetPeerNameKey,
+		NetPeerPortKey:     semconv.NetPeerPortKey,
+		NetSockPeerAddrKey: semconv.NetSockPeerAddrKey,
+		NetSockPeerPortKey: semconv.NetSockPeerPortKey,
+		NetTransportOther:  semconv.NetTransportOther,
+		NetTransportTCP:    semconv.NetTransportTCP,
+		NetTransportUDP:    semconv.NetTransportUDP,
+		NetTransportInProc: semconv.NetTransportInProc,
+	}
+
+	rc = &internal.RPCConv{
+		NetConv: nc,
+
+		RPCGRPCStatusCodeKey: semconv.RPCGRPCStatusCodeKey,
+		RPCMethodKey:         semconv.RPCMethodKey,
+		RPCServiceKey:        semconv.RPCServiceKey,
+		RPCSystemGRPC:        semconv.RPCSystemGRPC,
+	}
+)
+
+// Kinds of the spans of RPCs.
+const (
+	ClientSpanKind = trace.SpanKindClient
+	ServerSpanKind = trace.SpanKindServer
+)
+
+// GRPCClientRequest returns trace attributes for a gRPC call made by a client.
+// The target is the target of the client connection, for example
+// "dns:///api.example.com:443", and fullMethod the name of the method in the
+// "/package.service/method" form. The "rpc.system" attribute is always
+// returned. The following attributes are returned if the related values are
+// defined: "rpc.service", "rpc.method", "net.peer.name", "net.peer.port".
+func GRPCClientRequest(target, fullMethod string) []attribute.KeyValue {
+	return rc.GRPCClientRequest(target, fullMethod)
+}
+
+// GRPCServerRequest returns trace attributes for a gRPC call received by a
+// server. The fullMethod is the name of the method in the
+// "/package.service/method" form, and peer the network address of the
+// client. The "rpc.system" attribute is always returned. The following
+// attributes are returned if the related values are defined: "rpc.service",
+// "rpc.method", "net.sock.peer.addr", "net.sock.peer.port".
+func GRPCServerRequest(fullMethod, peer string) []attribute.KeyValue {
+	return rc.GRPCServerRequest(fullMethod, peer)
+}
+
+// GRPCStatus returns the "rpc.grpc.status_code" trace attribute of a gRPC
+// status code.
+func GRPCStatus(code uint32) attribute.KeyValue {
+	return rc.GRPCStatus(code)
+}
+
+// GRPCClientStatus returns a span status code and message for a gRPC status
+// code received by a client. All status codes other than OK are returned as
+// errors.
+func GRPCClientStatus(code uint32) (codes.Code, string) {
+	return rc.GRPCClientStatus(code)
+}
+
+// GRPCServerStatus returns a span status code and message for a gRPC status
+// code returned by a server. Only the Unknown, DeadlineExceeded,
+// Unimplemented, Internal, Unavailable, and DataLoss status codes are
+// returned as errors.
+func GRPCServerStatus(code uint32) (codes.Code, string) {
+	return rc.GRPCServerStatus(code)
+}
+
+// GRPCSpanName returns the name of the span of a gRPC call of the fullMethod
+// method: "package.service/method". An invalid fullMethod is returned as is.
+func GRPCSpanName(fullMethod string) string {
+	return rc.GRPCSpanName(fullMethod)
+}
@@ -417,6 +417,70 @@ func TestTraceFlagsWithSampled(t *testing.T) {
 	}
 }
 
+func TestTraceFlagsIsRandom(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		tf   TraceFlags
+		want bool
+	}{
+		{
+			name: "random",
+			tf:   FlagsRandom,
+			want: true,
+		}, {
+			name: "sampled only",
+			tf:   FlagsSampled,
+			want: false,
+		}, {
+			name: "random and sampled",
+			tf:   FlagsSampled | FlagsRandom,
+			want: true,
+		}, {
+			name: "not random/default",
+			want: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := testcase.tf.IsRandom()
+			if have != testcase.want {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+		})
+	}
+}
+
+func TestTraceFlagsWithRandom(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		start  TraceFlags
+		random bool
+		want   TraceFlags
+	}{
+		{
+			name:   "become random",
+			want:   FlagsRandom,
+			random: true,
+		}, {
+			name:   "sampled bit kept",
+			start:  FlagsSampled,
+			want:   FlagsSampled | FlagsRandom,
+			random: true,
+		}, {
+			name:   "random bit cleared",
+			start:  FlagsSampled | FlagsRandom,
+			want:   FlagsSampled,
+			random: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := testcase.start.WithRandom(testcase.random)
+			if have != testcase.want {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+		})
+	}
+}
+
 func TestStringTraceID(t *testing.T) {
 	for _, testcase := range []struct {
 		name string